@@ -22,6 +22,10 @@ type abstractDocumentQuery struct {
 	queryRaw           string
 	queryParameters    Parameters
 
+	// default operators of enclosing clauses, restored when a sub-clause
+	// is closed
+	outerDefaultOperators []QueryOperator
+
 	isIntersect bool
 	isGroupBy   bool
 
@@ -31,7 +35,7 @@ type abstractDocumentQuery struct {
 
 	selectTokens       []queryToken
 	fromToken          *fromToken
-	declareToken       *declareToken
+	declareTokens      []*declareToken
 	loadTokens         []*loadToken
 	fieldsToFetchToken *fieldsToFetchToken
 
@@ -47,6 +51,10 @@ type abstractDocumentQuery struct {
 	theWaitForNonStaleResults bool
 
 	includes []string
+	// names of counters and paths of compare exchange keys to include
+	// with the results
+	counterIncludes         []string
+	compareExchangeIncludes []string
 
 	queryStats *QueryStatistics
 
@@ -54,8 +62,17 @@ type abstractDocumentQuery struct {
 
 	disableCaching bool
 
+	skipDuplicateChecking    bool
+	projectionBehavior       ProjectionBehavior
+	disableAutoIndexCreation bool
+
 	isInMoreLikeThis bool
 
+	// set when querying an interface type without index or collection.
+	// The query can't be executed until FromIndex or FromCollection
+	// provides them
+	collectionNotInferred bool
+
 	// Go doesn't allow comparing functions so to remove we use index returned
 	// by add() function. We maintain stable index by never shrinking
 	// callback arrays. We assume there is no high churn of adding/removing
@@ -102,7 +119,7 @@ func newAbstractDocumentQuery(opts *DocumentQueryOptions) *abstractDocumentQuery
 		isGroupBy:               opts.isGroupBy,
 		indexName:               opts.IndexName,
 		collectionName:          opts.CollectionName,
-		declareToken:            opts.declareToken,
+		declareTokens:           opts.declareTokens,
 		loadTokens:              opts.loadTokens,
 		theSession:              opts.session,
 		aliasToGroupByFieldName: make(map[string]string),
@@ -116,29 +133,48 @@ func newAbstractDocumentQuery(opts *DocumentQueryOptions) *abstractDocumentQuery
 		return res
 	}
 
+	f := func(queryResult *QueryResult) {
+		res.updateStatsAndHighlightings(queryResult)
+	}
+	res.addAfterQueryExecutedListener(f)
+	res.conventions = opts.session.GetConventions()
+	res.disableAutoIndexCreation = res.conventions.DisableAutoIndexCreation
+	res.disableEntitiesTracking = opts.session.noTracking
+
+	// the query must be fully set up even without index or collection
+	// because they can be provided later with FromIndex or FromCollection
 	if res.queryRaw == "" {
 		if opts.IndexName == "" && opts.CollectionName == "" {
+			if opts.collectionNotInferred {
+				res.collectionNotInferred = true
+				return res
+			}
 			res.err = newIllegalArgumentError("Either indexName or collectionName must be specified")
 			return res
 		}
 		res.fromToken = createFromToken(opts.IndexName, opts.CollectionName, opts.fromAlias)
 	}
-
-	f := func(queryResult *QueryResult) {
-		res.updateStatsAndHighlightings(queryResult)
-	}
-	res.addAfterQueryExecutedListener(f)
-	if opts.session == nil {
-		res.conventions = NewDocumentConventions()
-	} else {
-		res.conventions = opts.session.GetConventions()
-	}
 	return res
 }
 
+// usingDefaultOperator sets operator used between where clauses not joined
+// explicitly with AndAlso or OrElse. It can be set before any where clause of
+// the query or at the start of a sub-clause, in which case it only applies
+// until the sub-clause is closed.
 func (q *abstractDocumentQuery) usingDefaultOperator(operator QueryOperator) error {
-	if len(q.whereTokens) > 0 {
-		return newIllegalStateError("Default operator can only be set before any where clause is added.")
+	if operator != QueryOperatorAnd && operator != QueryOperatorOr {
+		return newIllegalArgumentError("'%s' is not a valid query operator", operator)
+	}
+
+	tokensRef, err := q.getCurrentWhereTokensRef()
+	if err != nil {
+		return err
+	}
+	tokens := *tokensRef
+	if n := len(tokens); n > 0 {
+		if _, ok := tokens[n-1].(*openSubclauseToken); !ok {
+			return newIllegalStateError("Default operator can only be set before any where clause is added or at the start of a sub-clause.")
+		}
 	}
 
 	q.defaultOperator = operator
@@ -153,7 +189,18 @@ func (q *abstractDocumentQuery) waitForNonStaleResults(waitTimeout time.Duration
 	q.timeout = waitTimeout
 }
 
+// initializeQueryOperation lets OnBeforeQuery listeners customize the query
+// and creates query operation for it
 func (q *abstractDocumentQuery) initializeQueryOperation() (*queryOperation, error) {
+	delegate := &DocumentQueryCustomization{
+		query: q,
+	}
+	beforeQueryEventArgs := &BeforeQueryEventArgs{
+		Session:            q.theSession,
+		QueryCustomization: delegate,
+	}
+	q.theSession.onBeforeQueryInvoke(beforeQueryEventArgs)
+
 	indexQuery, err := q.GetIndexQuery()
 	if err != nil {
 		return nil, err
@@ -162,7 +209,60 @@ func (q *abstractDocumentQuery) initializeQueryOperation() (*queryOperation, err
 	return newQueryOperation(q.theSession, q.indexName, indexQuery, q.fieldsToFetchToken, q.disableEntitiesTracking, false, false)
 }
 
+// clone returns a copy of the query that can be modified and executed
+// independently of q
+func (q *abstractDocumentQuery) clone() *abstractDocumentQuery {
+	res := *q
+	res.queryOperation = nil
+	if q.pageSize != nil {
+		pageSize := *q.pageSize
+		res.pageSize = &pageSize
+	}
+	res.aliasToGroupByFieldName = make(map[string]string, len(q.aliasToGroupByFieldName))
+	for k, v := range q.aliasToGroupByFieldName {
+		res.aliasToGroupByFieldName[k] = v
+	}
+	if q.queryParameters != nil {
+		res.queryParameters = make(Parameters, len(q.queryParameters))
+		for k, v := range q.queryParameters {
+			res.queryParameters[k] = v
+		}
+	}
+	res.outerDefaultOperators = append([]QueryOperator(nil), q.outerDefaultOperators...)
+	res.selectTokens = append([]queryToken(nil), q.selectTokens...)
+	res.declareTokens = append([]*declareToken(nil), q.declareTokens...)
+	res.loadTokens = append([]*loadToken(nil), q.loadTokens...)
+	res.groupByTokens = append([]queryToken(nil), q.groupByTokens...)
+	res.orderByTokens = append([]queryToken(nil), q.orderByTokens...)
+	// options of where tokens are modified in place e.g. by Boost()
+	res.whereTokens = make([]queryToken, len(q.whereTokens))
+	for i, token := range q.whereTokens {
+		if wt, ok := token.(*whereToken); ok {
+			wtCopy := *wt
+			if wt.options != nil {
+				options := *wt.options
+				wtCopy.options = &options
+			}
+			token = &wtCopy
+		}
+		res.whereTokens[i] = token
+	}
+	res.includes = stringArrayCopy(q.includes)
+	res.counterIncludes = stringArrayCopy(q.counterIncludes)
+	res.compareExchangeIncludes = stringArrayCopy(q.compareExchangeIncludes)
+	res.beforeQueryExecutedCallback = append(([]func(*IndexQuery))(nil), q.beforeQueryExecutedCallback...)
+	res.afterQueryExecutedCallback = append(([]func(*QueryResult))(nil), q.afterQueryExecutedCallback...)
+	res.afterStreamExecutedCallback = append(([]func(map[string]interface{}))(nil), q.afterStreamExecutedCallback...)
+	return &res
+}
+
 func (q *abstractDocumentQuery) GetIndexQuery() (*IndexQuery, error) {
+	if q.err != nil {
+		return nil, q.err
+	}
+	if q.collectionNotInferred {
+		return nil, ErrCannotInferCollectionForInterface
+	}
 	query, err := q.string()
 	if err != nil {
 		return nil, err
@@ -197,11 +297,7 @@ func (q *abstractDocumentQuery) randomOrderingWithSeed(seed string) error {
 	}
 
 	if stringIsBlank(seed) {
-		err = q.randomOrdering()
-		if err != nil {
-			return err
-		}
-		return nil
+		return q.randomOrdering()
 	}
 
 	q.noCaching()
@@ -220,6 +316,26 @@ func (q *abstractDocumentQuery) assertNoRawQuery() error {
 	return nil
 }
 
+// setFrom replaces the origin of the query i.e. the index or
+// the collection being queried
+func (q *abstractDocumentQuery) setFrom(indexName string, collectionName string) error {
+	if err := q.assertNoRawQuery(); err != nil {
+		return err
+	}
+	if len(q.whereTokens) > 0 || len(q.selectTokens) > 0 || len(q.orderByTokens) > 0 || len(q.groupByTokens) > 0 {
+		return newIllegalStateError("The index or the collection must be set before any other clause of the query")
+	}
+	alias := ""
+	if q.fromToken != nil {
+		alias = q.fromToken.alias
+	}
+	q.indexName = indexName
+	q.collectionName = collectionName
+	q.fromToken = createFromToken(indexName, collectionName, alias)
+	q.collectionNotInferred = false
+	return nil
+}
+
 func (q *abstractDocumentQuery) addParameter(name string, value interface{}) error {
 	name = strings.TrimPrefix(name, "$")
 	if _, ok := q.queryParameters[name]; ok {
@@ -324,6 +440,13 @@ func (q *abstractDocumentQuery) groupByCount(projectedName string) error {
 	return nil
 }
 
+func (q *abstractDocumentQuery) assertIsGroupBy(method string) error {
+	if !q.isGroupBy {
+		return newIllegalStateError("%s can only be used after GroupBy", method)
+	}
+	return nil
+}
+
 func (q *abstractDocumentQuery) whereTrue() error {
 	tokensRef, err := q.getCurrentWhereTokensRef()
 	if err != nil {
@@ -367,6 +490,14 @@ func (q *abstractDocumentQuery) include(path string) {
 	q.includes = append(q.includes, path)
 }
 
+func (q *abstractDocumentQuery) includeCounters(names []string) {
+	q.counterIncludes = append(q.counterIncludes, names...)
+}
+
+func (q *abstractDocumentQuery) includeCompareExchangeValue(path string) {
+	q.compareExchangeIncludes = append(q.compareExchangeIncludes, path)
+}
+
 func (q *abstractDocumentQuery) take(count int) {
 	q.pageSize = &count
 }
@@ -420,6 +551,7 @@ func (q *abstractDocumentQuery) openSubclause() error {
 	tokens := *tokensRef
 	tokens = append(tokens, openSubclauseTokenInstance)
 	*tokensRef = tokens
+	q.outerDefaultOperators = append(q.outerDefaultOperators, q.defaultOperator)
 	return nil
 }
 
@@ -433,6 +565,10 @@ func (q *abstractDocumentQuery) closeSubclause() error {
 	tokens := *tokensRef
 	tokens = append(tokens, closeSubclauseTokenInstance)
 	*tokensRef = tokens
+	if n := len(q.outerDefaultOperators); n > 0 {
+		q.defaultOperator = q.outerDefaultOperators[n-1]
+		q.outerDefaultOperators = q.outerDefaultOperators[:n-1]
+	}
 	return nil
 }
 
@@ -464,6 +600,17 @@ func (q *abstractDocumentQuery) whereEquals(fieldName string, value interface{})
 	return q.whereEqualsWithParams(params)
 }
 
+// whereEqualsNested is like whereEquals but path is always treated as a path
+// inside the document, even if it looks like an id property
+func (q *abstractDocumentQuery) whereEqualsNested(path string, value interface{}) error {
+	params := &whereParams{
+		fieldName:    path,
+		value:        value,
+		isNestedPath: true,
+	}
+	return q.whereEqualsWithParams(params)
+}
+
 func (q *abstractDocumentQuery) whereEqualsWithMethodCall(fieldName string, method MethodCall) error {
 	return q.whereEquals(fieldName, method)
 }
@@ -573,6 +720,10 @@ func (q *abstractDocumentQuery) whereNotEqualsWithParams(whereParams *whereParam
 }
 
 func (q *abstractDocumentQuery) negateNext() {
+	if q.negate && q.conventions.Logger != nil {
+		// valid, but most likely a mistake
+		q.conventions.Logger.Print("Query clause negated twice in a row, the negations cancel each other out")
+	}
 	q.negate = !q.negate
 }
 
@@ -603,8 +754,11 @@ func (q *abstractDocumentQuery) markLastTokenExact() error {
 	return nil
 }
 
-func (q *abstractDocumentQuery) whereIn(fieldName string, values []interface{}) error {
-	var err error
+func (q *abstractDocumentQuery) whereIn(fieldName string, values interface{}) error {
+	items, err := abstractDocumentQueryUnpackCollection(values)
+	if err != nil {
+		return err
+	}
 	fieldName, err = q.ensureValidFieldName(fieldName, false)
 	if err != nil {
 		return err
@@ -623,14 +777,47 @@ func (q *abstractDocumentQuery) whereIn(fieldName string, values []interface{})
 		return err
 	}
 
-	whereToken := createWhereToken(whereOperatorIn, fieldName, q.addQueryParameter(q.transformCollection(fieldName, abstractDocumentQueryUnpackCollection(values))))
+	array := q.transformCollection(fieldName, items)
 
 	tokens := *tokensRef
-	tokens = append(tokens, whereToken)
+	maxValues := q.conventions.MaxNumberOfValuesInWhereIn
+	if maxValues <= 0 || len(array) <= maxValues {
+		whereToken := createWhereToken(whereOperatorIn, fieldName, q.addQueryParameter(array))
+		tokens = append(tokens, whereToken)
+		*tokensRef = tokens
+		return nil
+	}
+
+	// (field in ($p0) or field in ($p1) ...). Negation and the operator
+	// before it were already added so they apply to the whole sub-clause
+	tokens = append(tokens, openSubclauseTokenInstance)
+	for start := 0; start < len(array); start += maxValues {
+		end := start + maxValues
+		if end > len(array) {
+			end = len(array)
+		}
+		if start > 0 {
+			tokens = append(tokens, queryOperatorTokenOr)
+		}
+		whereToken := createWhereToken(whereOperatorIn, fieldName, q.addQueryParameter(array[start:end]))
+		tokens = append(tokens, whereToken)
+	}
+	tokens = append(tokens, closeSubclauseTokenInstance)
 	*tokensRef = tokens
 	return nil
 }
 
+// whereIDEquals filters by document id, regardless of the name of
+// the identity property of the queried type
+func (q *abstractDocumentQuery) whereIDEquals(id string) error {
+	return q.whereEquals(IndexingFieldNameDocumentID, id)
+}
+
+// whereIDIn filters by a set of document ids
+func (q *abstractDocumentQuery) whereIDIn(ids []string) error {
+	return q.whereIn(IndexingFieldNameDocumentID, ids)
+}
+
 func (q *abstractDocumentQuery) whereStartsWith(fieldName string, value interface{}) error {
 	whereParams := &whereParams{
 		fieldName:      fieldName,
@@ -701,6 +888,29 @@ func (q *abstractDocumentQuery) whereEndsWith(fieldName string, value interface{
 	return nil
 }
 
+// isOpenRangeEnd returns true if v is nil, which means the range is unbounded
+// on that end. A typed nil pointer (e.g. (*int)(nil)) is also nil
+func isOpenRangeEnd(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+	rv := reflect.ValueOf(v)
+	return rv.Kind() == reflect.Ptr && rv.IsNil()
+}
+
+// rangeBoundValue returns the value v points to, or v if it's not a pointer.
+// Returns nil if v is an open range end
+func rangeBoundValue(v interface{}) interface{} {
+	if isOpenRangeEnd(v) {
+		return nil
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Ptr {
+		return rv.Elem().Interface()
+	}
+	return v
+}
+
 func (q *abstractDocumentQuery) whereBetween(fieldName string, start interface{}, end interface{}) error {
 	var err error
 	fieldName, err = q.ensureValidFieldName(fieldName, false)
@@ -732,13 +942,13 @@ func (q *abstractDocumentQuery) whereBetween(fieldName string, start interface{}
 	}
 
 	fromParam := interface{}("*")
-	if start != nil {
+	if !isOpenRangeEnd(start) {
 		fromParam = q.transformValueWithRange(startParams, true)
 	}
 	fromParameterName := q.addQueryParameter(fromParam)
 
 	toParam := interface{}("NULL")
-	if end != nil {
+	if !isOpenRangeEnd(end) {
 		toParam = q.transformValueWithRange(endParams, true)
 	}
 	toParameterName := q.addQueryParameter(toParam)
@@ -777,7 +987,7 @@ func (q *abstractDocumentQuery) whereGreaterThan(fieldName string, value interfa
 	}
 
 	paramValue := interface{}("*")
-	if value != nil {
+	if !isOpenRangeEnd(value) {
 		paramValue = q.transformValueWithRange(whereParams, true)
 	}
 	parameter := q.addQueryParameter(paramValue)
@@ -816,7 +1026,7 @@ func (q *abstractDocumentQuery) whereGreaterThanOrEqual(fieldName string, value
 	}
 
 	paramValue := interface{}("*")
-	if value != nil {
+	if !isOpenRangeEnd(value) {
 		paramValue = q.transformValueWithRange(whereParams, true)
 	}
 
@@ -856,7 +1066,7 @@ func (q *abstractDocumentQuery) whereLessThan(fieldName string, value interface{
 	}
 
 	paramValue := interface{}("NULL")
-	if value != nil {
+	if !isOpenRangeEnd(value) {
 		paramValue = q.transformValueWithRange(whereParams, true)
 	}
 	parameter := q.addQueryParameter(paramValue)
@@ -888,7 +1098,7 @@ func (q *abstractDocumentQuery) whereLessThanOrEqual(fieldName string, value int
 	}
 
 	paramValue := interface{}("NULL")
-	if value != nil {
+	if !isOpenRangeEnd(value) {
 		paramValue = q.transformValueWithRange(whereParams, true)
 	}
 	parameter := q.addQueryParameter(paramValue)
@@ -929,7 +1139,7 @@ func (q *abstractDocumentQuery) whereRegex(fieldName string, pattern string) err
 	return nil
 }
 
-func (q *abstractDocumentQuery) andAlso() error {
+func (q *abstractDocumentQuery) andAlso(wrapPrevious bool) error {
 	tokensRef, err := q.getCurrentWhereTokensRef()
 	if err != nil {
 		return err
@@ -946,12 +1156,15 @@ func (q *abstractDocumentQuery) andAlso() error {
 		return newIllegalStateError("Cannot add AND, previous token was already an operator token.")
 	}
 
+	if wrapPrevious {
+		tokens = wrapCurrentClauseTokens(tokens)
+	}
 	tokens = append(tokens, queryOperatorTokenAnd)
 	*tokensRef = tokens
 	return nil
 }
 
-func (q *abstractDocumentQuery) orElse() error {
+func (q *abstractDocumentQuery) orElse(wrapPrevious bool) error {
 	tokensRef, err := q.getCurrentWhereTokensRef()
 	if err != nil {
 		return err
@@ -967,11 +1180,40 @@ func (q *abstractDocumentQuery) orElse() error {
 		return newIllegalStateError("Cannot add OR, previous token was already an operator token.")
 	}
 
+	if wrapPrevious {
+		tokens = wrapCurrentClauseTokens(tokens)
+	}
 	tokens = append(tokens, queryOperatorTokenOr)
 	*tokensRef = tokens
 	return nil
 }
 
+// wrapCurrentClauseTokens puts parentheses around tokens of the innermost
+// sub-clause that is still open (or of the whole query if there's none)
+func wrapCurrentClauseTokens(tokens []queryToken) []queryToken {
+	start := 0
+	depth := 0
+	for i := len(tokens) - 1; i >= 0; i-- {
+		switch tokens[i].(type) {
+		case *closeSubclauseToken:
+			depth++
+		case *openSubclauseToken:
+			depth--
+		}
+		if depth < 0 {
+			start = i + 1
+			break
+		}
+	}
+
+	res := make([]queryToken, 0, len(tokens)+2)
+	res = append(res, tokens[:start]...)
+	res = append(res, openSubclauseTokenInstance)
+	res = append(res, tokens[start:]...)
+	res = append(res, closeSubclauseTokenInstance)
+	return res
+}
+
 func (q *abstractDocumentQuery) boost(boost float64) error {
 	if boost == 1.0 {
 		return nil
@@ -1065,6 +1307,21 @@ func (q *abstractDocumentQuery) orderByWithOrdering(field string, ordering Order
 	return nil
 }
 
+func (q *abstractDocumentQuery) orderByWithSorter(field string, sorterName string, descending bool) error {
+	if err := q.assertNoRawQuery(); err != nil {
+		return err
+	}
+	if stringIsBlank(sorterName) {
+		return newIllegalArgumentError("sorterName cannot be empty")
+	}
+	f, err := q.ensureValidFieldName(field, false)
+	if err != nil {
+		return err
+	}
+	q.orderByTokens = append(q.orderByTokens, orderByTokenCreateWithSorter(f, sorterName, descending))
+	return nil
+}
+
 func (q *abstractDocumentQuery) orderByDescending(field string) error {
 	return q.orderByDescendingWithOrdering(field, OrderingTypeString)
 }
@@ -1134,6 +1391,9 @@ func (q *abstractDocumentQuery) generateIndexQuery(query string) *IndexQuery {
 	indexQuery.waitForNonStaleResultsTimeout = q.timeout
 	indexQuery.queryParameters = q.queryParameters
 	indexQuery.disableCaching = q.disableCaching
+	indexQuery.skipDuplicateChecking = q.skipDuplicateChecking
+	indexQuery.projectionBehavior = q.projectionBehavior
+	indexQuery.disableAutoIndexCreation = q.disableAutoIndexCreation
 
 	if q.pageSize != nil {
 		indexQuery.pageSize = *q.pageSize
@@ -1218,16 +1478,25 @@ func (q *abstractDocumentQuery) string() (string, error) {
 }
 
 func (q *abstractDocumentQuery) buildInclude(queryText *strings.Builder) error {
-	if len(q.includes) == 0 {
+	if len(q.includes) == 0 && len(q.counterIncludes) == 0 && len(q.compareExchangeIncludes) == 0 {
 		return nil
 	}
 
 	q.includes = stringArrayRemoveDuplicates(q.includes)
 	queryText.WriteString(" include ")
-	for i, include := range q.includes {
-		if i > 0 {
+	first := true
+	writeSeparator := func() {
+		if !first {
 			queryText.WriteString(",")
 		}
+		first = false
+	}
+	for _, include := range q.includes {
+		writeSeparator()
+
+		if err := queryFieldUtilValidate(include); err != nil {
+			return err
+		}
 
 		requiredQuotes := false
 
@@ -1239,14 +1508,33 @@ func (q *abstractDocumentQuery) buildInclude(queryText *strings.Builder) error {
 		}
 
 		if requiredQuotes {
-			s := strings.Replace(include, "'", "\\'", -1)
-			queryText.WriteString("'")
-			queryText.WriteString(s)
-			queryText.WriteString("'")
+			queryText.WriteString(queryFieldUtilQuote(include))
 		} else {
 			queryText.WriteString(include)
 		}
 	}
+
+	q.counterIncludes = stringArrayRemoveDuplicates(q.counterIncludes)
+	for _, name := range q.counterIncludes {
+		writeSeparator()
+		if err := queryFieldUtilValidate(name); err != nil {
+			return err
+		}
+		queryText.WriteString("counters(")
+		queryText.WriteString(queryFieldUtilQuote(name))
+		queryText.WriteString(")")
+	}
+
+	q.compareExchangeIncludes = stringArrayRemoveDuplicates(q.compareExchangeIncludes)
+	for _, path := range q.compareExchangeIncludes {
+		writeSeparator()
+		if err := queryFieldUtilValidate(path); err != nil {
+			return err
+		}
+		queryText.WriteString("cmpxchg(")
+		queryText.WriteString(queryFieldUtilQuote(path))
+		queryText.WriteString(")")
+	}
 	return nil
 }
 
@@ -1289,7 +1577,8 @@ func (q *abstractDocumentQuery) whereExists(fieldName string) error {
 	if err != nil {
 		return err
 	}
-	err = q.negateIfNeeded(tokensRef, fieldName)
+	// "exists(f) and not exists(f)" would never match
+	err = q.negateIfNeeded(tokensRef, "")
 	if err != nil {
 		return err
 	}
@@ -1300,8 +1589,11 @@ func (q *abstractDocumentQuery) whereExists(fieldName string) error {
 	return nil
 }
 
-func (q *abstractDocumentQuery) containsAny(fieldName string, values []interface{}) error {
-	var err error
+func (q *abstractDocumentQuery) containsAny(fieldName string, values interface{}) error {
+	items, err := abstractDocumentQueryUnpackCollection(values)
+	if err != nil {
+		return err
+	}
 	fieldName, err = q.ensureValidFieldName(fieldName, false)
 	if err != nil {
 		return err
@@ -1320,17 +1612,25 @@ func (q *abstractDocumentQuery) containsAny(fieldName string, values []interface
 		return err
 	}
 
-	array := q.transformCollection(fieldName, abstractDocumentQueryUnpackCollection(values))
-	whereToken := createWhereTokenWithOptions(whereOperatorIn, fieldName, q.addQueryParameter(array), newWhereOptionsWithExact(false))
+	array := q.transformCollection(fieldName, items)
 
 	tokens := *tokensRef
-	tokens = append(tokens, whereToken)
+	if len(array) == 0 {
+		// "in ()" is not a valid RQL and no value can match an empty set
+		tokens = append(tokens, falseTokenInstance)
+	} else {
+		whereToken := createWhereTokenWithOptions(whereOperatorIn, fieldName, q.addQueryParameter(array), newWhereOptionsWithExact(false))
+		tokens = append(tokens, whereToken)
+	}
 	*tokensRef = tokens
 	return nil
 }
 
-func (q *abstractDocumentQuery) containsAll(fieldName string, values []interface{}) error {
-	var err error
+func (q *abstractDocumentQuery) containsAll(fieldName string, values interface{}) error {
+	items, err := abstractDocumentQueryUnpackCollection(values)
+	if err != nil {
+		return err
+	}
 	fieldName, err = q.ensureValidFieldName(fieldName, false)
 	if err != nil {
 		return err
@@ -1349,7 +1649,7 @@ func (q *abstractDocumentQuery) containsAll(fieldName string, values []interface
 		return err
 	}
 
-	array := q.transformCollection(fieldName, abstractDocumentQueryUnpackCollection(values))
+	array := q.transformCollection(fieldName, items)
 
 	tokens := *tokensRef
 	if len(array) == 0 {
@@ -1375,6 +1675,38 @@ func (q *abstractDocumentQuery) distinct() error {
 	return nil
 }
 
+func (q *abstractDocumentQuery) selectTimeSeries(timeSeriesName string, from time.Time, to time.Time) error {
+	if stringIsBlank(timeSeriesName) {
+		return newIllegalArgumentError("timeSeriesName cannot be empty")
+	}
+	for _, token := range q.selectTokens {
+		if _, ok := token.(*timeSeriesToken); ok {
+			return newIllegalStateError("Query already selects a time series")
+		}
+	}
+
+	name, err := queryFieldUtilEscapeIfNecessary(timeSeriesName)
+	if err != nil {
+		return err
+	}
+	token := &timeSeriesToken{
+		timeSeriesName: name,
+	}
+	if !from.IsZero() || !to.IsZero() {
+		// range open on one side
+		if from.IsZero() {
+			from = timeSeriesMinTime
+		}
+		if to.IsZero() {
+			to = timeSeriesMaxTime
+		}
+		token.fromParameterName = q.addQueryParameter(from.UTC())
+		token.toParameterName = q.addQueryParameter(to.UTC())
+	}
+	q.selectTokens = append(q.selectTokens, token)
+	return nil
+}
+
 func (q *abstractDocumentQuery) updateStatsAndHighlightings(queryResult *QueryResult) {
 	q.queryStats.UpdateQueryStats(queryResult)
 	//TBD 4.1 Highlightings.Update(queryResult);
@@ -1424,8 +1756,10 @@ func (q *abstractDocumentQuery) buildFrom(writer *strings.Builder) error {
 }
 
 func (q *abstractDocumentQuery) buildDeclare(writer *strings.Builder) error {
-	if q.declareToken != nil {
-		return q.declareToken.writeTo(writer)
+	for _, tok := range q.declareTokens {
+		if err := tok.writeTo(writer); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -1472,7 +1806,7 @@ func (q *abstractDocumentQuery) buildWhere(writer *strings.Builder) error {
 	}
 
 	if q.isIntersect {
-		writer.WriteString(") ")
+		writer.WriteString(")")
 	}
 	return nil
 }
@@ -1526,9 +1860,10 @@ func (q *abstractDocumentQuery) appendOperatorIfNeeded(tokensRef *[]queryToken)
 	}
 
 	lastToken := tokens[n-1]
-	_, isWhereToken := lastToken.(*whereToken)
-	_, isCloseSubclauseToken := lastToken.(*closeSubclauseToken)
-	if !isWhereToken && !isCloseSubclauseToken {
+	switch lastToken.(type) {
+	case *whereToken, *closeSubclauseToken, *trueToken, *falseToken:
+		// those end an expression so we need an operator before the next one
+	default:
 		return nil
 	}
 
@@ -1585,12 +1920,10 @@ func (q *abstractDocumentQuery) negateIfNeeded(tokensRef *[]queryToken, fieldNam
 
 	q.negate = false
 
-	tokens := *tokensRef
-
-	n := len(tokens)
+	n := len(*tokensRef)
 	isOpenSubclauseToken := false
 	if n > 0 {
-		_, isOpenSubclauseToken = tokens[n-1].(*openSubclauseToken)
+		_, isOpenSubclauseToken = (*tokensRef)[n-1].(*openSubclauseToken)
 	}
 	if n == 0 || isOpenSubclauseToken {
 		if fieldName != "" {
@@ -1604,42 +1937,100 @@ func (q *abstractDocumentQuery) negateIfNeeded(tokensRef *[]queryToken, fieldNam
 				return err
 			}
 		}
-		err = q.andAlso()
+		err = q.andAlso(false)
 		if err != nil {
 			return err
 		}
 	}
 
+	// whereExists / whereTrue and andAlso above add to *tokensRef
+	tokens := *tokensRef
 	tokens = append(tokens, negateTokenInstance)
 	*tokensRef = tokens
 	return nil
 }
 
-func abstractDocumentQueryUnpackCollection(items []interface{}) []interface{} {
-	var results []interface{}
+// abstractDocumentQueryUnpackCollection flattens values, which must be
+// a slice or an array of any element type, into []interface{}.
+// Nested slices are flattened recursively
+func abstractDocumentQueryUnpackCollection(values interface{}) ([]interface{}, error) {
+	switch v := values.(type) {
+	case nil:
+		return nil, nil
+	case []interface{}:
+		return abstractDocumentQueryUnpackItems(nil, v), nil
+	case []string:
+		results := make([]interface{}, len(v))
+		for i, item := range v {
+			results[i] = item
+		}
+		return results, nil
+	case []int64:
+		results := make([]interface{}, len(v))
+		for i, item := range v {
+			results[i] = item
+		}
+		return results, nil
+	}
+
+	rv := reflect.ValueOf(values)
+	if !isUnpackableCollection(rv) {
+		return nil, newIllegalArgumentError("values must be a slice or an array, got %T", values)
+	}
+	return abstractDocumentQueryUnpackValue(nil, rv), nil
+}
 
+func abstractDocumentQueryUnpackItems(results []interface{}, items []interface{}) []interface{} {
 	for _, item := range items {
 		if itemCollection, ok := item.([]interface{}); ok {
-			els := abstractDocumentQueryUnpackCollection(itemCollection)
-			results = append(results, els...)
-		} else {
-			results = append(results, item)
+			results = abstractDocumentQueryUnpackItems(results, itemCollection)
+			continue
+		}
+		if rv := reflect.ValueOf(item); isUnpackableCollection(rv) {
+			results = abstractDocumentQueryUnpackValue(results, rv)
+			continue
 		}
+		results = append(results, item)
 	}
+	return results
+}
 
+func abstractDocumentQueryUnpackValue(results []interface{}, rv reflect.Value) []interface{} {
+	n := rv.Len()
+	for i := 0; i < n; i++ {
+		el := rv.Index(i)
+		if el.Kind() == reflect.Interface && !el.IsNil() {
+			el = el.Elem()
+		}
+		if isUnpackableCollection(el) {
+			results = abstractDocumentQueryUnpackValue(results, el)
+			continue
+		}
+		results = append(results, el.Interface())
+	}
 	return results
 }
 
+// isUnpackableCollection returns true for slices and arrays, except for
+// []byte which is a single value
+func isUnpackableCollection(rv reflect.Value) bool {
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		return rv.Type().Elem().Kind() != reflect.Uint8
+	}
+	return false
+}
+
 func (q *abstractDocumentQuery) ensureValidFieldName(fieldName string, isNestedPath bool) (string, error) {
 	if q.theSession == nil || q.theSession.GetConventions() == nil || isNestedPath || q.isGroupBy {
-		return queryFieldUtilEscapeIfNecessary(fieldName), nil
+		return queryFieldUtilEscapeIfNecessary(fieldName)
 	}
 
 	if fieldName == documentConventionsIdentityPropertyName {
 		return IndexingFieldNameDocumentID, nil
 	}
 
-	return queryFieldUtilEscapeIfNecessary(fieldName), nil
+	return queryFieldUtilEscapeIfNecessary(fieldName)
 }
 
 func (q *abstractDocumentQuery) transformValue(whereParams *whereParams) interface{} {
@@ -1798,6 +2189,15 @@ func (q *abstractDocumentQuery) noTracking() {
 	q.disableEntitiesTracking = true
 }
 
+func (q *abstractDocumentQuery) setProjectionBehavior(behavior ProjectionBehavior) error {
+	switch behavior {
+	case ProjectionBehaviorDefault, ProjectionBehaviorFromIndex, ProjectionBehaviorFromDocument:
+		q.projectionBehavior = behavior
+		return nil
+	}
+	return newIllegalArgumentError("'%s' is not a valid projection behavior", behavior)
+}
+
 func (q *abstractDocumentQuery) noCaching() {
 	q.disableCaching = true
 }
@@ -1877,7 +2277,7 @@ func (q *abstractDocumentQuery) spatial(fieldName string, shapeWkt string, relat
 }
 
 func (q *abstractDocumentQuery) spatial2(dynamicField DynamicSpatialField, criteria SpatialCriteria) error {
-	if err := q.assertIsDynamicQuery(dynamicField, "spatial"); err != nil {
+	if err := q.assertIsDynamicQuery(dynamicField, "Spatial2", "Spatial3"); err != nil {
 		return err
 	}
 
@@ -1945,7 +2345,7 @@ func (q *abstractDocumentQuery) orderByDistanceLatLongDynamic(field DynamicSpati
 	if field == nil {
 		return newIllegalArgumentError("Field cannot be null")
 	}
-	if err := q.assertIsDynamicQuery(field, "orderByDistance"); err != nil {
+	if err := q.assertIsDynamicQuery(field, "OrderByDistanceLatLongDynamic", "OrderByDistanceLatLong"); err != nil {
 		return err
 	}
 
@@ -1969,7 +2369,7 @@ func (q *abstractDocumentQuery) orderByDistanceWktDynamic(field DynamicSpatialFi
 	if field == nil {
 		return newIllegalArgumentError("Field cannot be null")
 	}
-	err := q.assertIsDynamicQuery(field, "orderByDistance2")
+	err := q.assertIsDynamicQuery(field, "OrderByDistanceWktDynamic", "OrderByDistanceWkt")
 	if err != nil {
 		return err
 	}
@@ -1994,7 +2394,7 @@ func (q *abstractDocumentQuery) orderByDistanceDescendingLatLongDynamic(field Dy
 	if field == nil {
 		return newIllegalArgumentError("Field cannot be null")
 	}
-	err := q.assertIsDynamicQuery(field, "orderByDistanceDescending")
+	err := q.assertIsDynamicQuery(field, "OrderByDistanceDescendingLatLongDynamic", "OrderByDistanceDescendingLatLong")
 	if err != nil {
 		return err
 	}
@@ -2018,7 +2418,7 @@ func (q *abstractDocumentQuery) orderByDistanceDescendingWktDynamic(field Dynami
 	if field == nil {
 		return newIllegalArgumentError("Field cannot be null")
 	}
-	err := q.assertIsDynamicQuery(field, "orderByDistanceDescending2")
+	err := q.assertIsDynamicQuery(field, "OrderByDistanceDescendingWktDynamic", "OrderByDistanceDescendingWkt")
 	if err != nil {
 		return err
 	}
@@ -2038,7 +2438,10 @@ func (q *abstractDocumentQuery) orderByDistanceDescendingWkt(fieldName string, s
 	return nil
 }
 
-func (q *abstractDocumentQuery) assertIsDynamicQuery(dynamicField DynamicSpatialField, methodName string) error {
+// assertIsDynamicQuery returns an error if dynamic spatial field is used
+// when querying a static index. indexFieldMethodName is a method that should
+// be used instead, with a spatial field defined in the index
+func (q *abstractDocumentQuery) assertIsDynamicQuery(dynamicField DynamicSpatialField, methodName string, indexFieldMethodName string) error {
 	if q.fromToken != nil && !q.fromToken.isDynamic {
 		f := func(s string, f bool) (string, error) {
 			return q.ensureValidFieldName(s, f)
@@ -2047,7 +2450,7 @@ func (q *abstractDocumentQuery) assertIsDynamicQuery(dynamicField DynamicSpatial
 		if err != nil {
 			return err
 		}
-		return newIllegalStateError("Cannot execute query method '" + methodName + "'. Field '" + fld + "' cannot be used when static index '" + q.fromToken.indexName + "' is queried. Dynamic spatial fields can only be used with dynamic queries, " + "for static index queries please use valid spatial fields defined in index definition.")
+		return newIllegalStateError("Cannot execute query method '%s'. Field '%s' cannot be used when static index '%s' is queried. Dynamic spatial fields can only be used with dynamic queries, for static index queries use %s with a spatial field defined in the index definition.", methodName, fld, q.fromToken.indexName, indexFieldMethodName)
 	}
 	return nil
 }
@@ -2057,15 +2460,6 @@ func (q *abstractDocumentQuery) initSync() error {
 		return nil
 	}
 
-	delegate := &DocumentQueryCustomization{
-		query: q,
-	}
-	beforeQueryEventArgs := &BeforeQueryEventArgs{
-		Session:            q.theSession,
-		QueryCustomization: delegate,
-	}
-	q.theSession.onBeforeQueryInvoke(beforeQueryEventArgs)
-
 	var err error
 	q.queryOperation, err = q.initializeQueryOperation()
 	if err != nil {
@@ -2075,24 +2469,30 @@ func (q *abstractDocumentQuery) initSync() error {
 }
 
 func (q *abstractDocumentQuery) executeActualQuery() error {
+	return q.executeQueryOperationRequest(q.queryOperation)
+}
+
+// executeQueryOperationRequest sends the query of op to the server and
+// sets its results
+func (q *abstractDocumentQuery) executeQueryOperationRequest(op *queryOperation) error {
 	{
-		context := q.queryOperation.enterQueryContext()
+		context := op.enterQueryContext()
 		defer func() {
 			_ = context.Close()
 		}()
 
-		command, err := q.queryOperation.createRequest()
+		command, err := op.createRequest()
 		if err != nil {
 			return err
 		}
 		if err = q.theSession.GetRequestExecutor().ExecuteCommand(command, q.theSession.sessionInfo); err != nil {
 			return err
 		}
-		if err = q.queryOperation.setResult(command.Result); err != nil {
+		if err = op.setResult(command.Result); err != nil {
 			return err
 		}
 	}
-	q.invokeAfterQueryExecuted(q.queryOperation.currentQueryResults)
+	q.invokeAfterQueryExecuted(op.currentQueryResults)
 	return nil
 }
 
@@ -2125,6 +2525,25 @@ func (q *abstractDocumentQuery) GetResults(results interface{}) error {
 	return q.executeQueryOperation(results, -1)
 }
 
+// GetResultsWithStats executes the query, sets results to returned values
+// and stats to statistics of the query, including TotalResults and
+// SkippedResults, which are useful for paging.
+// results should be of type *[]<type>
+func (q *abstractDocumentQuery) GetResultsWithStats(results interface{}, stats **QueryStatistics) error {
+	if q.err != nil {
+		return q.err
+	}
+	if stats == nil {
+		q.err = newIllegalArgumentError("stats can't be nil")
+		return q.err
+	}
+	if err := q.GetResults(results); err != nil {
+		return err
+	}
+	q.statistics(stats)
+	return nil
+}
+
 func checkValidSingleArg(v interface{}, argName string) error {
 	if v == nil {
 		return newIllegalArgumentError("%s can't be nil", argName)
@@ -2132,14 +2551,15 @@ func checkValidSingleArg(v interface{}, argName string) error {
 	return checkIsPtrPtrStruct(v, argName)
 }
 
-// First runs a query and returns a first result.
-func (q *abstractDocumentQuery) First(result interface{}) error {
+// executeForSingleResult runs a query returning at most maxResults
+// values of result's type. Returns a slice with the results.
+func (q *abstractDocumentQuery) executeForSingleResult(result interface{}, maxResults int) (reflect.Value, error) {
 	if q.err != nil {
-		return q.err
+		return reflect.Value{}, q.err
 	}
 
 	if q.err = checkValidSingleArg(result, "result"); q.err != nil {
-		return q.err
+		return reflect.Value{}, q.err
 	}
 
 	tp := reflect.TypeOf(result)
@@ -2150,42 +2570,65 @@ func (q *abstractDocumentQuery) First(result interface{}) error {
 	// create a pointer to a slice. executeQueryOperation creates the actual slice
 	sliceType := reflect.SliceOf(tp)
 	slicePtr := reflect.New(sliceType)
-	err := q.executeQueryOperation(slicePtr.Interface(), 1)
+	err := q.executeQueryOperation(slicePtr.Interface(), maxResults)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	return slicePtr.Elem(), nil
+}
+
+// First runs a query and returns a first result.
+// If there are no results, it returns ErrNoResults.
+func (q *abstractDocumentQuery) First(result interface{}) error {
+	slice, err := q.executeForSingleResult(result, 1)
 	if err != nil {
 		return err
 	}
-	slice := slicePtr.Elem()
 	if slice.Len() == 0 {
-		return nil
+		return ErrNoResults
 	}
 	el := slice.Index(0)
 	return setInterfaceToValue(result, el.Interface())
 }
 
-// Single runs a query that expects only a single result.
-// If there is more than one result, it returns IllegalStateError.
-func (q *abstractDocumentQuery) Single(result interface{}) error {
-	if q.err != nil {
-		return q.err
+// FirstOrDefault runs a query and returns a first result.
+// If there are no results, it returns false and result is not modified.
+func (q *abstractDocumentQuery) FirstOrDefault(result interface{}) (bool, error) {
+	slice, err := q.executeForSingleResult(result, 1)
+	if err != nil {
+		return false, err
 	}
-
-	if q.err = checkValidSingleArg(result, "result"); q.err != nil {
-		return q.err
+	if slice.Len() == 0 {
+		return false, nil
 	}
+	el := slice.Index(0)
+	if err = setInterfaceToValue(result, el.Interface()); err != nil {
+		return false, err
+	}
+	return true, nil
+}
 
-	tp := reflect.TypeOf(result)
-	// **struct => *struct
-	if tp.Kind() == reflect.Ptr && tp.Elem().Kind() == reflect.Ptr {
-		tp = tp.Elem()
+// Second runs a query and returns a second result.
+// If there are less than 2 results, it returns ErrInsufficientResults.
+func (q *abstractDocumentQuery) Second(result interface{}) error {
+	slice, err := q.executeForSingleResult(result, 2)
+	if err != nil {
+		return err
 	}
-	// create a pointer to a slice. executeQueryOperation creates the actual slice
-	sliceType := reflect.SliceOf(tp)
-	slicePtr := reflect.New(sliceType)
-	err := q.executeQueryOperation(slicePtr.Interface(), 2)
+	if slice.Len() < 2 {
+		return ErrInsufficientResults
+	}
+	el := slice.Index(1)
+	return setInterfaceToValue(result, el.Interface())
+}
+
+// Single runs a query that expects only a single result.
+// If there is more than one result, it returns IllegalStateError.
+func (q *abstractDocumentQuery) Single(result interface{}) error {
+	slice, err := q.executeForSingleResult(result, 2)
 	if err != nil {
 		return err
 	}
-	slice := slicePtr.Elem()
 	if slice.Len() != 1 {
 		return newIllegalStateError("Expected single result, got: %d", slice.Len())
 	}
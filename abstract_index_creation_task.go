@@ -26,6 +26,9 @@ type IndexCreationTask struct {
 
 	OutputReduceToCollection string
 
+	// Configuration overrides server configuration for this index
+	Configuration IndexConfiguration
+
 	// Note: in Go IndexName must provided explicitly
 	// In Java it's dynamically calculated as getClass().getSimpleName()
 	IndexName string
@@ -65,6 +68,7 @@ func (t *IndexCreationTask) CreateIndexDefinition() *IndexDefinition {
 	indexDefinitionBuilder.spatialIndexesStrings = t.SpatialOptionsStrings
 	indexDefinitionBuilder.outputReduceToCollection = t.OutputReduceToCollection
 	indexDefinitionBuilder.additionalSources = t.AdditionalSources
+	indexDefinitionBuilder.configuration = t.Configuration
 
 	// validate for single map (Map set), don't validate multiple map (Maps)
 	validate := len(t.Maps) == 0
@@ -85,6 +89,10 @@ func (t *IndexCreationTask) Execute(store *DocumentStore, conventions *DocumentC
 }
 
 func (t *IndexCreationTask) putIndex(store *DocumentStore, conventions *DocumentConventions, database string) error {
+	if err := indexCreationValidateName(t.IndexName); err != nil {
+		return err
+	}
+
 	oldConventions := t.Conventions
 	defer func() { t.Conventions = oldConventions }()
 
@@ -111,36 +119,54 @@ func (t *IndexCreationTask) putIndex(store *DocumentStore, conventions *Document
 
 // Index registers field to be indexed
 func (t *IndexCreationTask) Index(field string, indexing FieldIndexing) {
+	if t.IndexesStrings == nil {
+		t.IndexesStrings = make(map[string]FieldIndexing)
+	}
 	t.IndexesStrings[field] = indexing
 }
 
 // Spatial registers field to be spatially indexed
 func (t *IndexCreationTask) Spatial(field string, indexing func() *SpatialOptions) {
+	if t.SpatialOptionsStrings == nil {
+		t.SpatialOptionsStrings = make(map[string]*SpatialOptions)
+	}
 	v := indexing()
 	t.SpatialOptionsStrings[field] = v
 }
 
 // StoreAllFields selects if we're storing all fields or not
 func (t *IndexCreationTask) StoreAllFields(storage FieldStorage) {
-	t.StoresStrings[IndexingFieldAllFields] = storage
+	t.Store(IndexingFieldAllFields, storage)
 }
 
 // Store registers field to be stored
 func (t *IndexCreationTask) Store(field string, storage FieldStorage) {
+	if t.StoresStrings == nil {
+		t.StoresStrings = make(map[string]FieldStorage)
+	}
 	t.StoresStrings[field] = storage
 }
 
 // Analyze registers field to be analyzed
 func (t *IndexCreationTask) Analyze(field string, analyzer string) {
+	if t.AnalyzersStrings == nil {
+		t.AnalyzersStrings = make(map[string]string)
+	}
 	t.AnalyzersStrings[field] = analyzer
 }
 
 // TermVector registers field to have term vectors
 func (t *IndexCreationTask) TermVector(field string, termVector FieldTermVector) {
+	if t.TermVectorsStrings == nil {
+		t.TermVectorsStrings = make(map[string]FieldTermVector)
+	}
 	t.TermVectorsStrings[field] = termVector
 }
 
 // Suggestion registers field to be indexed as suggestions
 func (t *IndexCreationTask) Suggestion(field string) {
+	if stringArrayContains(t.IndexSuggestions, field) {
+		return
+	}
 	t.IndexSuggestions = append(t.IndexSuggestions, field)
 }
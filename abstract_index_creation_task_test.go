@@ -0,0 +1,44 @@
+package ravendb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndexCreationTaskFieldOptions(t *testing.T) {
+	// configuration methods also work on a task that isn't created
+	// with NewIndexCreationTask
+	task := &IndexCreationTask{
+		IndexName: "Articles/ByContent",
+		Map:       "from a in docs.Articles select new { a.title, a.content, a.tags }",
+	}
+	task.Index("content", FieldIndexingSearch)
+	task.Store("content", FieldStorageYes)
+	task.TermVector("content", FieldTermVectorWithPositionsAndOffsets)
+	task.Analyze("title", "StandardAnalyzer")
+	task.Suggestion("title")
+	task.Suggestion("title")
+	task.Index("tags", FieldIndexingExact)
+	task.StoreAllFields(FieldStorageNo)
+
+	def := task.CreateIndexDefinition()
+	require.Equal(t, 4, len(def.Fields))
+
+	content := def.Fields["content"]
+	require.NotNil(t, content)
+	assert.Equal(t, FieldIndexing(FieldIndexingSearch), content.Indexing)
+	assert.Equal(t, FieldStorageYes, content.Storage)
+	assert.Equal(t, FieldTermVectorWithPositionsAndOffsets, content.TermVector)
+	assert.False(t, content.Suggestions)
+
+	title := def.Fields["title"]
+	require.NotNil(t, title)
+	assert.Equal(t, "StandardAnalyzer", title.Analyzer)
+	assert.True(t, title.Suggestions)
+	assert.Equal(t, []string{"title"}, task.IndexSuggestions)
+
+	assert.Equal(t, FieldIndexing(FieldIndexingExact), def.Fields["tags"].Indexing)
+	assert.Equal(t, FieldStorageNo, def.Fields[IndexingFieldAllFields].Storage)
+}
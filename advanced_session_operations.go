@@ -42,6 +42,20 @@ func (o *AdvancedSessionOperations) GetMetadataFor(instance interface{}) (*Metad
 	return o.s.GetMetadataFor(instance)
 }
 
+// LoadMetadataOnly returns metadata of documents with given ids without
+// loading the documents. Documents that don't exist are not in the result
+func (o *AdvancedSessionOperations) LoadMetadataOnly(ids []string) (map[string]*MetadataAsDictionary, error) {
+	return o.s.LoadMetadataOnly(ids)
+}
+
+func (o *AdvancedSessionOperations) GetIncludedCountersFor(instance interface{}) (map[string]int64, error) {
+	return o.s.GetIncludedCountersFor(instance)
+}
+
+func (o *AdvancedSessionOperations) GetIncludedTimeSeriesFor(instance interface{}, name string) ([]*TimeSeriesEntry, error) {
+	return o.s.GetIncludedTimeSeriesFor(instance, name)
+}
+
 func (o *AdvancedSessionOperations) GetRequestExecutor() *RequestExecutor {
 	return o.s.GetRequestExecutor()
 }
@@ -51,6 +65,35 @@ func (o *AdvancedSessionOperations) GetNumberOfRequests() int {
 	return o.s.GetNumberOfRequests()
 }
 
+// MaxRequests returns maximum number of requests this session is allowed
+// to send to the server
+func (o *AdvancedSessionOperations) MaxRequests() int {
+	return o.s.maxNumberOfRequestsPerSession
+}
+
+// Diagnostics returns a snapshot of the session's state.
+// It is O(tracked entities): computing PendingSessionChanges serializes
+// every entity tracked by the session, so avoid calling it in hot paths
+func (o *AdvancedSessionOperations) Diagnostics() *SessionDiagnostics {
+	return &SessionDiagnostics{
+		NumberOfRequests:         o.s.GetNumberOfRequests(),
+		UniqueEntitiesInSession:  o.s.GetNumberOfEntitiesInUnitOfWork(),
+		NumberOfDeferredCommands: o.s.GetDeferredCommandsCount(),
+		PendingSessionChanges:    o.s.GetDeferredCommandsCount() > 0 || o.s.clusterTransaction.NumberOfStagedOperations() > 0 || o.s.HasChanges(),
+	}
+}
+
+// ClusterTransaction returns operations on compare exchange values
+// executed atomically by SaveChanges of a cluster-wide session
+func (o *AdvancedSessionOperations) ClusterTransaction() *ClusterTransactionOperations {
+	return o.s.clusterTransaction
+}
+
+// TransactionMode returns transaction mode of the session
+func (o *AdvancedSessionOperations) TransactionMode() TransactionMode {
+	return o.s.transactionMode
+}
+
 func (o *AdvancedSessionOperations) Defer(commands ...ICommandData) {
 	o.s.Defer(commands...)
 }
@@ -119,6 +162,14 @@ func (o *AdvancedSessionOperations) StreamQueryInto(query *DocumentQuery, output
 	return o.s.StreamQueryInto(query, output)
 }
 
+func (o *AdvancedSessionOperations) StreamQueryAsCSV(query *DocumentQuery, output io.Writer, fields []string) error {
+	return o.s.StreamQueryAsCSV(query, output, fields)
+}
+
+func (o *AdvancedSessionOperations) StreamRawQueryAsCSV(query *RawDocumentQuery, output io.Writer, fields []string) error {
+	return o.s.StreamRawQueryAsCSV(query, output, fields)
+}
+
 func (o *AdvancedSessionOperations) Exists(id string) (bool, error) {
 	return o.s.Exists(id)
 }
@@ -131,6 +182,14 @@ func (o *AdvancedSessionOperations) Evict(entity interface{}) error {
 	return o.s.Evict(entity)
 }
 
+func (o *AdvancedSessionOperations) GetRawEntityByID(id string) (map[string]interface{}, bool) {
+	return o.s.GetRawEntityByID(id)
+}
+
+func (o *AdvancedSessionOperations) SetRawEntityByID(id string, raw map[string]interface{}) error {
+	return o.s.SetRawEntityByID(id, raw)
+}
+
 func (o *AdvancedSessionOperations) GetDocumentID(instance interface{}) string {
 	return o.s.GetDocumentID(instance)
 }
@@ -207,6 +266,14 @@ func (o *AdvancedSessionOperations) RemoveBeforeQueryListener(handlerID int) {
 	o.s.RemoveBeforeQueryListener(handlerID)
 }
 
+func (o *AdvancedSessionOperations) OnEntityMaterialized(handler func(entity interface{}, metadata map[string]interface{})) int {
+	return o.s.OnEntityMaterialized(handler)
+}
+
+func (o *AdvancedSessionOperations) RemoveEntityMaterializedListener(handlerID int) {
+	o.s.RemoveEntityMaterializedListener(handlerID)
+}
+
 func (o *AdvancedSessionOperations) LoadStartingWith(results interface{}, args *StartsWithArgs) error {
 	return o.s.LoadStartingWith(results, args)
 }
@@ -219,6 +286,12 @@ func (o *AdvancedSessionOperations) LoadIntoStream(ids []string, output io.Write
 	return o.s.LoadIntoStream(ids, output)
 }
 
+// ExecuteMultiFacets executes aggregation queries in a single request
+// to the server. Results are in the same order as queries.
+func (o *AdvancedSessionOperations) ExecuteMultiFacets(queries ...*AggregationDocumentQuery) ([]map[string]*FacetResult, error) {
+	return executeMultiFacets(o.s.InMemoryDocumentSessionOperations, queries)
+}
+
 func (o *AdvancedSessionOperations) GetMaxNumberOfRequestsPerSession() int {
 	return o.s.maxNumberOfRequestsPerSession
 }
@@ -98,6 +98,21 @@ func (q *AggregationQueryBase) invokeAfterQueryExecuted(result *QueryResult) {
 func (q *aggregationQueryBase) processResults(queryResult *QueryResult, conventions *DocumentConventions) (map[string]*FacetResult, error) {
 	q.invokeAfterQueryExecuted(queryResult)
 
+	results, err := facetResultsFromQueryResult(queryResult)
+	if err != nil {
+		return nil, err
+	}
+
+	err = queryOperationEnsureIsAcceptable(queryResult, q.query.waitForNonStaleResults, q.startTime, q.session)
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// facetResultsFromQueryResult returns facet results of an aggregation query
+// keyed by facet name
+func facetResultsFromQueryResult(queryResult *QueryResult) (map[string]*FacetResult, error) {
 	results := map[string]*FacetResult{}
 	for _, result := range queryResult.Results {
 		res, err := convertValue(result, reflect.TypeOf(&FacetResult{}))
@@ -107,11 +122,6 @@ func (q *aggregationQueryBase) processResults(queryResult *QueryResult, conventi
 		facetResult := res.(*FacetResult)
 		results[facetResult.Name] = facetResult
 	}
-
-	err := queryOperationEnsureIsAcceptable(queryResult, q.query.waitForNonStaleResults, q.startTime, q.session)
-	if err != nil {
-		return nil, err
-	}
 	return results, nil
 }
 
@@ -149,9 +159,56 @@ func (q *AggregationDocumentQuery) AndAggregateByFacet(facet FacetBase) *Aggrega
 }
 
 func (q *AggregationDocumentQuery) GetIndexQuery() (*IndexQuery, error) {
+	if q.err != nil {
+		return nil, q.err
+	}
 	return q.source.GetIndexQuery()
 }
 
 func (q *AggregationDocumentQuery) invokeAfterQueryExecuted(result *QueryResult) {
 	q.source.invokeAfterQueryExecuted(result)
 }
+
+// executeMultiFacets sends all queries to the server in one request.
+// Results are in the same order as queries.
+func executeMultiFacets(session *InMemoryDocumentSessionOperations, queries []*AggregationDocumentQuery) ([]map[string]*FacetResult, error) {
+	var indexQueries []*IndexQuery
+	for _, q := range queries {
+		if q.err != nil {
+			return nil, q.err
+		}
+		if q.session != session {
+			return nil, newIllegalArgumentError("All queries must belong to the same session")
+		}
+		var err error
+		q.query, err = q.GetIndexQuery()
+		if err != nil {
+			return nil, err
+		}
+		indexQueries = append(indexQueries, q.query)
+	}
+
+	command, err := NewGetMultiFacetsCommand(session.GetConventions(), indexQueries)
+	if err != nil {
+		return nil, err
+	}
+
+	startTime := time.Now()
+	if err = session.incrementRequestCount(); err != nil {
+		return nil, err
+	}
+	if err = session.GetRequestExecutor().ExecuteCommand(command, nil); err != nil {
+		return nil, err
+	}
+
+	var results []map[string]*FacetResult
+	for i, q := range queries {
+		q.startTime = startTime
+		res, err := q.processResults(command.QueryResults[i], session.GetConventions())
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, res)
+	}
+	return results, nil
+}
@@ -0,0 +1,90 @@
+package ravendb
+
+import (
+	"context"
+	"reflect"
+)
+
+// AsyncDocumentQuery is a query executed by AsyncDocumentSession.
+// Filtering and ordering methods of DocumentQuery modify the query in place
+// so they can be called directly on AsyncDocumentQuery e.g.:
+//
+//	q := session.QueryAsync(userType)
+//	q.WhereEquals("name", "John")
+//	errCh := q.GetResultsAsync(ctx, &users)
+//
+// The query is captured when an async method is called, later changes
+// to q don't affect queries that already started.
+type AsyncDocumentQuery struct {
+	*DocumentQuery
+
+	session *AsyncDocumentSession
+}
+
+// runQuery executes a copy of q in the background and calls fn with its
+// completed operation. OnBeforeQuery listeners are invoked for the copy
+// when it starts executing
+func (q *AsyncDocumentQuery) runQuery(ctx context.Context, take int, fn func(*queryOperation) error) <-chan error {
+	query := q.clone()
+	if take != -1 && (query.pageSize == nil || *query.pageSize > take) {
+		query.take(take)
+	}
+	return q.session.run(ctx, func() error {
+		op, err := query.initializeQueryOperation()
+		if err != nil {
+			return err
+		}
+		if err := query.executeQueryOperationRequest(op); err != nil {
+			return err
+		}
+		return fn(op)
+	})
+}
+
+// errorChan returns a channel with err already sent to it
+func errorChan(err error) <-chan error {
+	res := make(chan error, 1)
+	res <- err
+	return res
+}
+
+// GetResultsAsync executes the query and sets results to returned values
+func (q *AsyncDocumentQuery) GetResultsAsync(ctx context.Context, results interface{}) <-chan error {
+	if err := checkValidGetResultsArg(results, "results"); err != nil {
+		return errorChan(err)
+	}
+	return q.runQuery(ctx, -1, func(op *queryOperation) error {
+		return op.complete(results)
+	})
+}
+
+// FirstAsync executes the query and sets result to the first returned value
+func (q *AsyncDocumentQuery) FirstAsync(ctx context.Context, result interface{}) <-chan error {
+	if err := checkValidSingleArg(result, "result"); err != nil {
+		return errorChan(err)
+	}
+	return q.runQuery(ctx, 1, func(op *queryOperation) error {
+		tp := reflect.TypeOf(result)
+		// **struct => *struct
+		if tp.Kind() == reflect.Ptr && tp.Elem().Kind() == reflect.Ptr {
+			tp = tp.Elem()
+		}
+		slicePtr := reflect.New(reflect.SliceOf(tp))
+		if err := op.complete(slicePtr.Interface()); err != nil {
+			return err
+		}
+		slice := slicePtr.Elem()
+		if slice.Len() == 0 {
+			return ErrNoResults
+		}
+		return setInterfaceToValue(result, slice.Index(0).Interface())
+	})
+}
+
+// CountAsync executes the query and sets count to the number of matching results
+func (q *AsyncDocumentQuery) CountAsync(ctx context.Context, count *int) <-chan error {
+	return q.runQuery(ctx, 0, func(op *queryOperation) error {
+		*count = op.currentQueryResults.TotalResults
+		return nil
+	})
+}
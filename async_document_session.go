@@ -0,0 +1,111 @@
+package ravendb
+
+import (
+	"context"
+	"reflect"
+	"sync"
+)
+
+// AsyncDocumentSession wraps DocumentSession with methods that don't block
+// the caller. Each operation runs in its own goroutine and its result is
+// sent to the returned channel.
+// DocumentSession is not thread-safe so operations are executed one at a time,
+// in the order they were started.
+// Context is only checked before an operation starts. Once it started
+// it runs to completion, even if ctx is cancelled.
+type AsyncDocumentSession struct {
+	session *DocumentSession
+
+	mu sync.Mutex
+	// closed when the most recently started operation finishes
+	last chan struct{}
+}
+
+func newAsyncDocumentSession(session *DocumentSession) *AsyncDocumentSession {
+	last := make(chan struct{})
+	close(last)
+	return &AsyncDocumentSession{
+		session: session,
+		last:    last,
+	}
+}
+
+// Session returns the underlying DocumentSession. It must not be used
+// while async operations are pending
+func (s *AsyncDocumentSession) Session() *DocumentSession {
+	return s.session
+}
+
+// run executes fn after all previously started operations finished
+func (s *AsyncDocumentSession) run(ctx context.Context, fn func() error) <-chan error {
+	res := make(chan error, 1)
+	done := make(chan struct{})
+
+	s.mu.Lock()
+	prev := s.last
+	s.last = done
+	s.mu.Unlock()
+
+	go func() {
+		defer close(done)
+		select {
+		case <-prev:
+		case <-ctx.Done():
+			res <- ctx.Err()
+			// we still have to wait so that operations started after us
+			// don't run concurrently with previous operations
+			<-prev
+			return
+		}
+		if err := ctx.Err(); err != nil {
+			res <- err
+			return
+		}
+		res <- fn()
+	}()
+	return res
+}
+
+// LoadAsync loads an entity with a given id into result
+func (s *AsyncDocumentSession) LoadAsync(ctx context.Context, id string, result interface{}) <-chan error {
+	return s.run(ctx, func() error {
+		return s.session.Load(result, id)
+	})
+}
+
+// StoreAsync schedules entity to be stored on next SaveChangesAsync
+func (s *AsyncDocumentSession) StoreAsync(ctx context.Context, entity interface{}) <-chan error {
+	return s.run(ctx, func() error {
+		return s.session.Store(entity)
+	})
+}
+
+// DeleteAsync schedules entity to be deleted on next SaveChangesAsync
+func (s *AsyncDocumentSession) DeleteAsync(ctx context.Context, entity interface{}) <-chan error {
+	return s.run(ctx, func() error {
+		return s.session.Delete(entity)
+	})
+}
+
+// SaveChangesAsync sends all changes made in this session to the server
+func (s *AsyncDocumentSession) SaveChangesAsync(ctx context.Context) <-chan error {
+	return s.run(ctx, s.session.SaveChanges)
+}
+
+// QueryAsync starts a query for documents of a given type
+func (s *AsyncDocumentSession) QueryAsync(clazz reflect.Type) *AsyncDocumentQuery {
+	return &AsyncDocumentQuery{
+		DocumentQuery: s.session.QueryCollectionForType(clazz),
+		session:       s,
+	}
+}
+
+// Close waits for pending operations to finish and closes the session
+func (s *AsyncDocumentSession) Close() {
+	s.mu.Lock()
+	last := s.last
+	s.mu.Unlock()
+
+	<-last
+	s.session.Close()
+}
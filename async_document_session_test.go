@@ -0,0 +1,136 @@
+package ravendb
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAsyncDocumentSessionRunsInOrder(t *testing.T) {
+	s := newAsyncDocumentSession(nil)
+	ctx := context.Background()
+
+	var order []int
+	unblock := make(chan struct{})
+	first := s.run(ctx, func() error {
+		<-unblock
+		order = append(order, 1)
+		return nil
+	})
+	second := s.run(ctx, func() error {
+		order = append(order, 2)
+		return nil
+	})
+
+	// a cancelled operation reports right away, even though it's waiting
+	// for the first one
+	cancelledCtx, cancel := context.WithCancel(ctx)
+	cancel()
+	cancelled := s.run(cancelledCtx, func() error {
+		order = append(order, 3)
+		return nil
+	})
+	select {
+	case err := <-cancelled:
+		assert.Equal(t, context.Canceled, err)
+	case <-time.After(time.Second):
+		t.Fatal("cancelled operation didn't report")
+	}
+
+	close(unblock)
+	assert.NoError(t, <-first)
+	assert.NoError(t, <-second)
+	<-s.last
+	assert.Equal(t, []int{1, 2}, order)
+}
+
+func TestAsyncDocumentQueryIsCapturedWhenStarted(t *testing.T) {
+	var queries []map[string]interface{}
+	fn := func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		queries = append(queries, body)
+		_, _ = w.Write([]byte(`{"Results":[],"Includes":{},"IndexName":"Auto/Users","TotalResults":3}`))
+	}
+	store := newFakeServerStore(t, fn)
+	beforeQueryCalls := 0
+	store.AddBeforeQueryListener(func(args *BeforeQueryEventArgs) {
+		beforeQueryCalls++
+		args.QueryCustomization.RandomOrdering()
+	})
+	session, err := store.OpenAsyncSession("")
+	require.NoError(t, err)
+	defer session.Close()
+	ctx := context.Background()
+
+	// queries below start only after this finishes
+	unblock := make(chan struct{})
+	blocked := session.run(ctx, func() error {
+		<-unblock
+		return nil
+	})
+
+	var users []*User
+	var first *User
+	var count int
+	q := session.QueryAsync(reflect.TypeOf(&User{}))
+	q.WhereEquals("Name", "John")
+	resultsCh := q.GetResultsAsync(ctx, &users)
+	q.OrElse().WhereEquals("Name", "Jane")
+	countCh := q.CountAsync(ctx, &count)
+	q.Boost(2)
+	firstCh := q.FirstAsync(ctx, &first)
+	q.OrElse().WhereEquals("Name", "Jack")
+	allCh := q.GetResultsAsync(ctx, &users)
+	assert.Equal(t, 0, beforeQueryCalls)
+	close(unblock)
+
+	assert.NoError(t, <-blocked)
+	assert.NoError(t, <-resultsCh)
+	assert.NoError(t, <-countCh)
+	assert.Equal(t, ErrNoResults, <-firstCh)
+	assert.NoError(t, <-allCh)
+	assert.Equal(t, 3, count)
+	assert.Equal(t, 4, beforeQueryCalls)
+	require.Equal(t, 4, len(queries))
+	assert.Equal(t, "from Users where Name = $p0 order by random()", queries[0]["Query"])
+	assert.Equal(t, map[string]interface{}{"p0": "John"}, queries[0]["QueryParameters"])
+	assert.Nil(t, queries[0]["PageSize"])
+	assert.Equal(t, "from Users where Name = $p0 or Name = $p1 order by random()", queries[1]["Query"])
+	assert.Equal(t, map[string]interface{}{"p0": "John", "p1": "Jane"}, queries[1]["QueryParameters"])
+	assert.Equal(t, "from Users where Name = $p0 or boost(Name = $p1, 2.000000) order by random()", queries[2]["Query"])
+	assert.Equal(t, float64(1), queries[2]["PageSize"])
+	assert.Equal(t, "from Users where Name = $p0 or boost(Name = $p1, 2.000000) or Name = $p2 order by random()", queries[3]["Query"])
+	assert.Nil(t, queries[3]["PageSize"])
+
+	// q itself is not changed by running it
+	assert.Nil(t, q.pageSize)
+	assert.Empty(t, q.orderByTokens)
+}
+
+func TestAsyncDocumentQueryInvalidArgument(t *testing.T) {
+	store := newTestStore(t, "http://127.0.0.1:1")
+	defer store.Close()
+	session, err := store.OpenAsyncSession("")
+	require.NoError(t, err)
+	defer session.Close()
+	ctx := context.Background()
+
+	q := session.QueryAsync(reflect.TypeOf(&User{}))
+	var users []*User
+	err = <-q.GetResultsAsync(ctx, users)
+	_, ok := err.(*IllegalArgumentError)
+	assert.True(t, ok, "err: %v", err)
+	var user *User
+	err = <-q.FirstAsync(ctx, user)
+	_, ok = err.(*IllegalArgumentError)
+	assert.True(t, ok, "err: %v", err)
+	// the query can still be used
+	assert.NoError(t, q.Err())
+}
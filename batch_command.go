@@ -23,12 +23,15 @@ type BatchCommand struct {
 	commands          []ICommandData
 	attachmentStreams []io.Reader
 	options           *BatchOptions
+	transactionMode   TransactionMode
 
 	Result *JSONArrayResult
+	// Commands has a result for each command in the batch, in the same order
+	Commands []*CommandResult
 }
 
 // newBatchCommand returns new BatchCommand
-func newBatchCommand(conventions *DocumentConventions, commands []ICommandData, options *BatchOptions) (*BatchCommand, error) {
+func newBatchCommand(conventions *DocumentConventions, commands []ICommandData, options *BatchOptions, transactionMode TransactionMode) (*BatchCommand, error) {
 	if conventions == nil {
 		return nil, newIllegalStateError("conventions cannot be nil")
 	}
@@ -39,9 +42,10 @@ func newBatchCommand(conventions *DocumentConventions, commands []ICommandData,
 	cmd := &BatchCommand{
 		RavenCommandBase: NewRavenCommandBase(),
 
-		commands:    commands,
-		options:     options,
-		conventions: conventions,
+		commands:        commands,
+		options:         options,
+		conventions:     conventions,
+		transactionMode: transactionMode,
 	}
 
 	for i := 0; i < len(commands); i++ {
@@ -67,6 +71,13 @@ func escapeQuotes(s string) string {
 	return quoteEscaper.Replace(s)
 }
 
+func (c *BatchCommand) requiredServerFeatures() []*serverFeature {
+	if c.transactionMode == TransactionModeClusterWide {
+		return []*serverFeature{serverFeatureClusterTransactions}
+	}
+	return nil
+}
+
 func (c *BatchCommand) CreateRequest(node *ServerNode) (*http.Request, error) {
 	url := node.URL + "/databases/" + node.Database + "/bulk_docs"
 	url = c.appendOptions(url)
@@ -82,6 +93,9 @@ func (c *BatchCommand) CreateRequest(node *ServerNode) (*http.Request, error) {
 	v := map[string]interface{}{
 		"Commands": a,
 	}
+	if c.transactionMode == TransactionModeClusterWide {
+		v["TransactionMode"] = TransactionModeClusterWide
+	}
 	js, err := jsonMarshal(v)
 	if err != nil {
 		return nil, err
@@ -137,7 +151,16 @@ func (c *BatchCommand) SetResponse(response []byte, fromCache bool) error {
 		return newIllegalStateError("Got null response from the server after doing a batch, something is very wrong. Probably a garbled response.")
 	}
 
-	return jsonUnmarshal(response, &c.Result)
+	c.Commands = nil
+	if err := jsonUnmarshal(response, &c.Result); err != nil {
+		return err
+	}
+	if c.Result == nil {
+		return nil
+	}
+	var err error
+	c.Commands, err = decodeCommandResults(c.Result.Results)
+	return err
 }
 
 func (c *BatchCommand) appendOptions(sb string) string {
@@ -175,7 +198,7 @@ func (c *BatchCommand) appendOptions(sb string) string {
 		}
 
 		for _, specificIndex := range _options.waitForSpecificIndexes {
-			sb += "&waitForSpecificIndex=" + specificIndex
+			sb += "&waitForSpecificIndex=" + urlUtilsEscapeDataString(specificIndex)
 		}
 	}
 	return sb
@@ -0,0 +1,68 @@
+package ravendb
+
+// CommandResult describes the result of a single command in a batch
+type CommandResult struct {
+	Type         CommandType
+	ID           string
+	ChangeVector string
+	// Error is set if the server reported that the command failed
+	Error string
+}
+
+func newCommandResult(js map[string]interface{}) *CommandResult {
+	res := &CommandResult{}
+	res.Type, _ = jsonGetAsText(js, "Type")
+	// PUT results use metadata names, other commands use plain names
+	if id, ok := jsonGetAsText(js, MetadataID); ok {
+		res.ID = id
+	} else {
+		res.ID, _ = jsonGetAsText(js, "Id")
+	}
+	if cv, ok := jsonGetAsText(js, MetadataChangeVector); ok {
+		res.ChangeVector = cv
+	} else {
+		res.ChangeVector, _ = jsonGetAsText(js, "ChangeVector")
+	}
+	res.Error, _ = jsonGetAsText(js, "Error")
+	return res
+}
+
+// BatchCommandError is returned when the server reports that a command
+// in a batch failed
+type BatchCommandError struct {
+	errorBase
+
+	// Index is the position of the failed command in the batch
+	Index       int
+	CommandType CommandType
+	ID          string
+	Message     string
+}
+
+func newBatchCommandError(index int, cmd *CommandResult) *BatchCommandError {
+	res := &BatchCommandError{
+		Index:       index,
+		CommandType: cmd.Type,
+		ID:          cmd.ID,
+		Message:     cmd.Error,
+	}
+	res.setErrorf("Command %d (%s '%s') in the batch failed: %s", index, cmd.Type, cmd.ID, cmd.Error)
+	return res
+}
+
+// decodeCommandResults decodes raw batch results and returns an error for
+// the first failed command
+func decodeCommandResults(results []map[string]interface{}) ([]*CommandResult, error) {
+	res := make([]*CommandResult, len(results))
+	var err error
+	for i, js := range results {
+		if js == nil {
+			continue
+		}
+		res[i] = newCommandResult(js)
+		if err == nil && res[i].Error != "" {
+			err = newBatchCommandError(i, res[i])
+		}
+	}
+	return res, err
+}
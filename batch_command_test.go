@@ -0,0 +1,161 @@
+package ravendb
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func batchCommandJSON(t *testing.T, commands ...ICommandData) (map[string]interface{}, error) {
+	return batchCommandJSONWithMode(t, TransactionModeSingleNode, commands...)
+}
+
+func batchCommandJSONWithMode(t *testing.T, mode TransactionMode, commands ...ICommandData) (map[string]interface{}, error) {
+	cmd, err := newBatchCommand(NewDocumentConventions(), commands, nil, mode)
+	require.NoError(t, err)
+	req, err := cmd.CreateRequest(newTestNode())
+	if err != nil {
+		return nil, err
+	}
+	assert.Equal(t, "/databases/db/bulk_docs", req.URL.Path)
+	d, err := ioutil.ReadAll(req.Body)
+	require.NoError(t, err)
+	var res map[string]interface{}
+	require.NoError(t, json.Unmarshal(d, &res))
+	return res, nil
+}
+
+func TestBatchCommandMixedCommands(t *testing.T) {
+	doc := map[string]interface{}{
+		"name": "John",
+		"@metadata": map[string]interface{}{
+			"@collection": "Users",
+		},
+	}
+	changeVector := "A:1-abc"
+	patch := &PatchRequest{
+		Script: "this.name = args.name",
+		Values: map[string]interface{}{"name": "Jane"},
+	}
+	js, err := batchCommandJSON(t,
+		NewPutCommandData("users/1", "", doc),
+		NewDeleteCommandData("users/2", changeVector),
+		NewPatchCommandData("users/3", nil, patch, nil),
+		NewPatchCommandDataSimple("users/4", changeVector, patch),
+	)
+	require.NoError(t, err)
+
+	expected := []interface{}{
+		map[string]interface{}{
+			"Id":           "users/1",
+			"Type":         "PUT",
+			"ChangeVector": nil,
+			"Document":     doc,
+		},
+		map[string]interface{}{
+			"Id":           "users/2",
+			"Type":         "DELETE",
+			"ChangeVector": changeVector,
+		},
+		map[string]interface{}{
+			"Id":           "users/3",
+			"Type":         "PATCH",
+			"ChangeVector": nil,
+			"Patch": map[string]interface{}{
+				"Script": "this.name = args.name",
+				"Values": map[string]interface{}{"name": "Jane"},
+			},
+		},
+		map[string]interface{}{
+			"Id":           "users/4",
+			"Type":         "PATCH",
+			"ChangeVector": changeVector,
+			"Patch": map[string]interface{}{
+				"Script": "this.name = args.name",
+				"Values": map[string]interface{}{"name": "Jane"},
+			},
+		},
+	}
+	assert.Equal(t, map[string]interface{}{"Commands": expected}, js)
+}
+
+func TestBatchCommandInvalidPatch(t *testing.T) {
+	_, err := batchCommandJSON(t, NewPatchCommandData("users/1", nil, nil, nil))
+	assert.Error(t, err)
+
+	patch := &PatchRequest{Script: "this.name = 'x'"}
+	_, err = batchCommandJSON(t, NewPatchCommandData("", nil, patch, nil))
+	assert.Error(t, err)
+}
+
+func TestBatchCommandClusterWide(t *testing.T) {
+	doc := map[string]interface{}{
+		"name": "John",
+	}
+	js, err := batchCommandJSONWithMode(t, TransactionModeClusterWide,
+		NewPutCommandData("users/1", "", doc),
+		NewPutCompareExchangeCommandData("usernames/john", "users/1", 0),
+		NewDeleteCompareExchangeCommandData("usernames/jane", 5),
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, "ClusterWide", js["TransactionMode"])
+	commands := js["Commands"].([]interface{})
+	assert.Equal(t, 3, len(commands))
+	assert.Equal(t, map[string]interface{}{
+		"Id":       "usernames/john",
+		"Type":     "CompareExchangePUT",
+		"Index":    float64(0),
+		"Document": map[string]interface{}{"Object": "users/1"},
+	}, commands[1])
+	assert.Equal(t, map[string]interface{}{
+		"Id":    "usernames/jane",
+		"Type":  "CompareExchangeDELETE",
+		"Index": float64(5),
+	}, commands[2])
+
+	js, err = batchCommandJSON(t, NewPutCommandData("users/1", "", doc))
+	require.NoError(t, err)
+	_, ok := js["TransactionMode"]
+	assert.False(t, ok)
+}
+
+func TestBatchCommandSetResponse(t *testing.T) {
+	cmd, err := newBatchCommand(NewDocumentConventions(), []ICommandData{
+		NewPutCommandData("users/1", "", map[string]interface{}{}),
+		NewDeleteCommandData("users/2", ""),
+		NewPatchCommandData("users/3", nil, &PatchRequest{Script: "this.name = 'x'"}, nil),
+	}, nil, TransactionModeSingleNode)
+	require.NoError(t, err)
+
+	js := `{"Results":[
+		{"Type":"PUT","@id":"users/1","@collection":"Users","@change-vector":"A:1-abc"},
+		{"Type":"DELETE","Id":"users/2","Deleted":true},
+		{"Type":"PATCH","Id":"users/3","ChangeVector":"A:2-abc","PatchStatus":"Patched"}
+	]}`
+	err = cmd.SetResponse([]byte(js), false)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(cmd.Commands))
+	assert.Equal(t, &CommandResult{Type: CommandPut, ID: "users/1", ChangeVector: "A:1-abc"}, cmd.Commands[0])
+	assert.Equal(t, &CommandResult{Type: CommandDelete, ID: "users/2"}, cmd.Commands[1])
+	assert.Equal(t, &CommandResult{Type: CommandPatch, ID: "users/3", ChangeVector: "A:2-abc"}, cmd.Commands[2])
+	// raw results are still available
+	assert.Equal(t, 3, len(cmd.Result.Results))
+
+	js = `{"Results":[
+		{"Type":"PUT","@id":"users/1","@change-vector":"A:1-abc"},
+		{"Type":"PUT","Id":"users/1","Error":"Document users/1 already exists"},
+		{"Type":"DELETE","Id":"users/2","Deleted":true}
+	]}`
+	err = cmd.SetResponse([]byte(js), false)
+	batchErr, ok := err.(*BatchCommandError)
+	require.True(t, ok, "expected *BatchCommandError, got %T (%v)", err, err)
+	assert.Equal(t, 1, batchErr.Index)
+	assert.Equal(t, CommandPut, batchErr.CommandType)
+	assert.Equal(t, "users/1", batchErr.ID)
+	assert.Equal(t, "Document users/1 already exists", batchErr.Message)
+	assert.Equal(t, "Command 1 (PUT 'users/1') in the batch failed: Document users/1 already exists", batchErr.Error())
+}
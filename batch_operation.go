@@ -31,7 +31,7 @@ func (b *BatchOperation) createRequest() (*BatchCommand, error) {
 
 	b.entities = result.entities
 
-	return newBatchCommand(b.session.GetConventions(), result.sessionCommands, result.options)
+	return newBatchCommand(b.session.GetConventions(), result.sessionCommands, result.options, result.transactionMode)
 }
 
 func (b *BatchOperation) setResult(result []map[string]interface{}) error {
@@ -82,6 +82,7 @@ func (b *BatchOperation) setResult(result []map[string]interface{}) error {
 		afterSaveChangesEventArgs := newAfterSaveChangesEventArgs(b.session, documentInfo.id, documentInfo.entity)
 		b.session.onAfterSaveChangesInvoke(afterSaveChangesEventArgs)
 	}
+	b.session.clusterTransaction.clear()
 	return nil
 }
 
@@ -0,0 +1,9 @@
+package ravendb
+
+// BuildNumber describes version of RavenDB server
+type BuildNumber struct {
+	ProductVersion string `json:"ProductVersion"`
+	BuildVersion   int    `json:"BuildVersion"`
+	CommitHash     string `json:"CommitHash"`
+	FullVersion    string `json:"FullVersion"`
+}
@@ -204,7 +204,10 @@ func (o *BulkInsertOperation) StoreWithID(entity interface{}, id string, metadat
 
 	documentInfo := &documentInfo{}
 	documentInfo.metadataInstance = metadata
-	jsNode := convertEntityToJSON(entity, documentInfo)
+	jsNode, err := convertEntityToJSON(o.conventions, entity, documentInfo)
+	if err != nil {
+		return err
+	}
 
 	var b bytes.Buffer
 	if o.first {
@@ -0,0 +1,107 @@
+package ravendb
+
+import "reflect"
+
+// ClusterTransactionOperations stages compare exchange operations
+// that SaveChanges executes atomically with changes to documents.
+// It requires a session opened with TransactionModeClusterWide.
+type ClusterTransactionOperations struct {
+	session *InMemoryDocumentSessionOperations
+
+	// staged commands, in order, at most one per key
+	commands []ICommandData
+}
+
+func newClusterTransactionOperations(session *InMemoryDocumentSessionOperations) *ClusterTransactionOperations {
+	return &ClusterTransactionOperations{
+		session: session,
+	}
+}
+
+// CreateCompareExchangeValue stages creation of a new compare exchange key.
+// SaveChanges fails if the key already exists
+func (o *ClusterTransactionOperations) CreateCompareExchangeValue(key string, value interface{}) error {
+	return o.stage(NewPutCompareExchangeCommandData(key, value, 0))
+}
+
+// UpdateCompareExchangeValue stages update of a compare exchange value.
+// SaveChanges fails if item.Index is not the current index of the key
+func (o *ClusterTransactionOperations) UpdateCompareExchangeValue(item *CompareExchangeValue) error {
+	if item == nil {
+		return newIllegalArgumentError("item cannot be nil")
+	}
+	if item.Index < 0 {
+		return newIllegalArgumentError("Index must be a non-negative number")
+	}
+	return o.stage(NewPutCompareExchangeCommandData(item.Key, item.Value, item.Index))
+}
+
+// DeleteCompareExchangeValue stages deletion of a compare exchange key.
+// SaveChanges fails if index is not the current index of the key
+func (o *ClusterTransactionOperations) DeleteCompareExchangeValue(key string, index int64) error {
+	if index < 0 {
+		return newIllegalArgumentError("Index must be a non-negative number")
+	}
+	return o.stage(NewDeleteCompareExchangeCommandData(key, index))
+}
+
+// GetCompareExchangeValue returns compare exchange value for a given key from
+// the server or nil if it doesn't exist. Staged changes are not visible.
+// Values included by a query (DocumentQuery.IncludeCompareExchangeValue)
+// are returned without a request to the server
+func (o *ClusterTransactionOperations) GetCompareExchangeValue(clazz reflect.Type, key string) (*CompareExchangeValue, error) {
+	if err := o.assertClusterWide(); err != nil {
+		return nil, err
+	}
+	if item, ok := o.session.includedCompareExchangeValues[key]; ok {
+		if item == nil {
+			return nil, nil
+		}
+		return compareExchangeValueResultParserGetValueFromJSON(clazz, item)
+	}
+	op, err := NewGetCompareExchangeValueOperation(clazz, key)
+	if err != nil {
+		return nil, err
+	}
+	if err = o.session.incrementRequestCount(); err != nil {
+		return nil, err
+	}
+	if err = o.session.GetOperations().Send(op, o.session.sessionInfo); err != nil {
+		return nil, err
+	}
+	return op.Command.Result, nil
+}
+
+// NumberOfStagedOperations returns number of compare exchange operations
+// that will be executed on SaveChanges
+func (o *ClusterTransactionOperations) NumberOfStagedOperations() int {
+	return len(o.commands)
+}
+
+func (o *ClusterTransactionOperations) assertClusterWide() error {
+	if o.session.transactionMode != TransactionModeClusterWide {
+		return newIllegalStateError("Performing cluster transaction operations requires TransactionMode to be set to ClusterWide")
+	}
+	return nil
+}
+
+func (o *ClusterTransactionOperations) stage(command ICommandData) error {
+	if err := o.assertClusterWide(); err != nil {
+		return err
+	}
+	key := command.getId()
+	if stringIsEmpty(key) {
+		return newIllegalArgumentError("key cannot be empty")
+	}
+	for _, cmd := range o.commands {
+		if cmd.getId() == key {
+			return newIllegalStateError("Compare exchange key '%s' already has an operation staged in this session", key)
+		}
+	}
+	o.commands = append(o.commands, command)
+	return nil
+}
+
+func (o *ClusterTransactionOperations) clear() {
+	o.commands = nil
+}
@@ -0,0 +1,42 @@
+package ravendb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClusterTransactionOperationsStaging(t *testing.T) {
+	session := newQueryTestSession()
+	ops := session.Advanced().ClusterTransaction()
+
+	// requires cluster-wide session
+	assert.Equal(t, TransactionModeSingleNode, session.Advanced().TransactionMode())
+	err := ops.CreateCompareExchangeValue("usernames/john", "users/1")
+	_, ok := err.(*IllegalStateError)
+	assert.True(t, ok)
+
+	session.transactionMode = TransactionModeClusterWide
+	require.NoError(t, ops.CreateCompareExchangeValue("usernames/john", "users/1"))
+	require.NoError(t, ops.DeleteCompareExchangeValue("usernames/jane", 3))
+	assert.Equal(t, 2, ops.NumberOfStagedOperations())
+	assert.True(t, session.Advanced().Diagnostics().PendingSessionChanges)
+
+	// only one operation per key
+	err = ops.UpdateCompareExchangeValue(NewCompareExchangeValue("usernames/john", 1, "users/2"))
+	assert.Error(t, err)
+	assert.Error(t, ops.CreateCompareExchangeValue("", "x"))
+	assert.Error(t, ops.DeleteCompareExchangeValue("usernames/x", -1))
+	assert.Equal(t, 2, ops.NumberOfStagedOperations())
+
+	result, err := session.prepareForSaveChanges()
+	require.NoError(t, err)
+	assert.Equal(t, TransactionModeClusterWide, result.transactionMode)
+	require.Equal(t, 2, len(result.sessionCommands))
+	assert.Equal(t, CommandCompareExchangePut, result.sessionCommands[0].getType())
+	assert.Equal(t, CommandCompareExchangeDelete, result.sessionCommands[1].getType())
+
+	ops.clear()
+	assert.Equal(t, 0, ops.NumberOfStagedOperations())
+}
@@ -0,0 +1,338 @@
+package ravendb
+
+import (
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestNode returns a node for checking requests created by commands
+func newTestNode() *ServerNode {
+	return &ServerNode{URL: "http://127.0.0.1:8080", Database: "db"}
+}
+
+// createTestRequest returns the request cmd creates for newTestNode()
+// and its body
+func createTestRequest(t *testing.T, cmd RavenCommand) (*http.Request, []byte) {
+	req, err := cmd.CreateRequest(newTestNode())
+	require.NoError(t, err)
+	var body []byte
+	if req.Body != nil {
+		body, err = ioutil.ReadAll(req.Body)
+		require.NoError(t, err)
+	}
+	return req, body
+}
+
+// operationCommand returns the command of an operation returned
+// by a constructor that can fail
+func operationCommand(op IMaintenanceOperation, err error) (RavenCommand, error) {
+	if err != nil {
+		return nil, err
+	}
+	return op.GetCommand(NewDocumentConventions())
+}
+
+func TestCommandRequests(t *testing.T) {
+	from := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		name    string
+		command func() (RavenCommand, error)
+		method  string
+		url     string
+		// if set, the body must be this JSON
+		body string
+		// if set, the command must expect this type of response
+		responseType RavenCommandResponseType
+	}{
+		{
+			name:         "DisableIndex",
+			command:      func() (RavenCommand, error) { return operationCommand(NewDisableIndexOperation("Users/ByName")) },
+			method:       http.MethodPost,
+			url:          "http://127.0.0.1:8080/databases/db/admin/indexes/disable?name=Users%2FByName",
+			responseType: RavenCommandResponseTypeEmpty,
+		},
+		{
+			name:         "EnableIndex",
+			command:      func() (RavenCommand, error) { return operationCommand(NewEnableIndexOperation("Users/ByName")) },
+			method:       http.MethodPost,
+			url:          "http://127.0.0.1:8080/databases/db/admin/indexes/enable?name=Users%2FByName",
+			responseType: RavenCommandResponseTypeEmpty,
+		},
+		{
+			name: "SetIndexesPriority",
+			command: func() (RavenCommand, error) {
+				return operationCommand(NewSetIndexesPriorityOperation("Orders/Totals", IndexPriorityLow))
+			},
+			method:       http.MethodPost,
+			url:          "http://127.0.0.1:8080/databases/db/indexes/set-priority",
+			body:         `{"IndexNames":["Orders/Totals"],"Priority":"Low"}`,
+			responseType: RavenCommandResponseTypeEmpty,
+		},
+		{
+			name: "SetIndexesLock",
+			command: func() (RavenCommand, error) {
+				return operationCommand(NewSetIndexesLockOperation("Orders/Totals", IndexLockModeLockedIgnore))
+			},
+			method: http.MethodPost,
+			url:    "http://127.0.0.1:8080/databases/db/indexes/set-lock",
+			body:   `{"IndexNames":["Orders/Totals"],"Mode":"LockedIgnore"}`,
+		},
+		{
+			name:    "GetIndexErrors",
+			command: func() (RavenCommand, error) { return NewGetIndexErrorsCommand(nil), nil },
+			method:  http.MethodGet,
+			url:     "http://127.0.0.1:8080/databases/db/indexes/errors",
+		},
+		{
+			name: "GetIndexErrorsForIndexes",
+			command: func() (RavenCommand, error) {
+				return NewGetIndexErrorsCommand([]string{"Orders/ByCompany", "Users"}), nil
+			},
+			method: http.MethodGet,
+			url:    "http://127.0.0.1:8080/databases/db/indexes/errors?name=Orders%2FByCompany&name=Users",
+		},
+		{
+			name:    "GetIndexStatistics",
+			command: func() (RavenCommand, error) { return NewGetIndexStatisticsCommand("UsersByName") },
+			method:  http.MethodGet,
+			url:     "http://127.0.0.1:8080/databases/db/indexes/stats?name=UsersByName",
+		},
+		{
+			name:    "GetDetailedStatistics",
+			command: func() (RavenCommand, error) { return NewGetDetailedStatisticsCommand(""), nil },
+			method:  http.MethodGet,
+			url:     "http://127.0.0.1:8080/databases/db/stats/detailed",
+		},
+		{
+			name:    "GetNextOperationID",
+			command: func() (RavenCommand, error) { return NewGetNextOperationIDCommand(), nil },
+			method:  http.MethodGet,
+			url:     "http://127.0.0.1:8080/databases/db/operations/next-operation-id",
+		},
+		{
+			name:    "GetOperationState",
+			command: func() (RavenCommand, error) { return NewGetOperationStateOperation(3).GetCommand(nil) },
+			method:  http.MethodGet,
+			url:     "http://127.0.0.1:8080/databases/db/operations/state?id=3",
+		},
+		{
+			// server-wide operations are not scoped to a database
+			name:    "GetServerWideOperationState",
+			command: func() (RavenCommand, error) { return NewGetServerWideOperationStateOperation(3).GetCommand(nil) },
+			method:  http.MethodGet,
+			url:     "http://127.0.0.1:8080/operations/state?id=3",
+		},
+		{
+			name:    "GetBuildNumber",
+			command: func() (RavenCommand, error) { return NewGetBuildNumberOperation().GetCommand(nil) },
+			method:  http.MethodGet,
+			url:     "http://127.0.0.1:8080/build/version",
+		},
+		{
+			name:    "GetNodeInfo",
+			command: func() (RavenCommand, error) { return NewGetNodeInfoOperation().GetCommand(nil) },
+			method:  http.MethodGet,
+			url:     "http://127.0.0.1:8080/cluster/node-info",
+		},
+		{
+			name:    "GetSubscriptions",
+			command: func() (RavenCommand, error) { return operationCommand(NewGetSubscriptionsOperation(10, 5)) },
+			method:  http.MethodGet,
+			url:     "http://127.0.0.1:8080/databases/db/subscriptions?start=10&pageSize=5",
+		},
+		{
+			name: "GetSubscriptionState",
+			command: func() (RavenCommand, error) {
+				return operationCommand(NewGetSubscriptionStateOperation("users subscription"))
+			},
+			method: http.MethodGet,
+			url:    "http://127.0.0.1:8080/databases/db/subscriptions/state?name=users+subscription",
+		},
+		{
+			name: "DropSubscriptionConnection",
+			command: func() (RavenCommand, error) {
+				return operationCommand(NewDropSubscriptionConnectionOperation("users subscription"))
+			},
+			method:       http.MethodPost,
+			url:          "http://127.0.0.1:8080/databases/db/subscriptions/drop?name=users+subscription",
+			responseType: RavenCommandResponseTypeEmpty,
+		},
+		{
+			name: "GetDocumentsWithIncludes",
+			command: func() (RavenCommand, error) {
+				timeSeries := []*TimeSeriesRange{{Name: "Heart Rate", From: &from}}
+				return NewGetDocumentsCommandWithIncludes([]string{"users/1"}, []string{"Friend"}, []string{"likes", "dislikes"}, timeSeries, false)
+			},
+			method: http.MethodGet,
+			url: "http://127.0.0.1:8080/databases/db/docs?&include=Friend&counter=likes&counter=dislikes" +
+				"&timeseries=Heart+Rate&from=2020-01-02T03%3A04%3A05.0000000Z&to=&id=users%2F1",
+		},
+		{
+			name:    "GetDocumentsRange",
+			command: func() (RavenCommand, error) { return NewGetDocumentsCommandRange(10, 5, true) },
+			method:  http.MethodGet,
+			url:     "http://127.0.0.1:8080/databases/db/docs?&start=10&pageSize=5&metadataOnly=true",
+		},
+		{
+			name: "RestoreBackup",
+			command: func() (RavenCommand, error) {
+				config := &RestoreBackupConfiguration{
+					DatabaseName:   "restored",
+					BackupLocation: "/backups/db",
+				}
+				return NewRestoreBackupOperation(config).GetCommand(nil)
+			},
+			method: http.MethodPost,
+			url:    "http://127.0.0.1:8080/admin/restore/database",
+			body:   `{"DatabaseName":"restored","BackupLocation":"/backups/db","DisableOngoingTasks":false,"SkipIndexes":false,"Type":"Local"}`,
+		},
+		{
+			name: "CompactDatabase",
+			command: func() (RavenCommand, error) {
+				settings := &CompactSettings{
+					DatabaseName:        "db",
+					Indexes:             []string{"Users/ByName"},
+					SkipOptimizeIndexes: true,
+				}
+				return NewCompactDatabaseOperation(settings).GetCommand(NewDocumentConventions())
+			},
+			method: http.MethodPost,
+			url:    "http://127.0.0.1:8080/admin/compact",
+			body:   `{"DatabaseName":"db","Documents":false,"Indexes":["Users/ByName"],"SkipOptimizeIndexes":true}`,
+		},
+		{
+			name: "DisableDatabases",
+			command: func() (RavenCommand, error) {
+				return NewToggleDatabasesStateOperation([]string{"db1", "db2"}, true).GetCommand(nil)
+			},
+			method: http.MethodPost,
+			url:    "http://127.0.0.1:8080/admin/databases/disable",
+			body:   `{"DatabaseNames":["db1","db2"]}`,
+		},
+		{
+			name: "EnableDatabases",
+			command: func() (RavenCommand, error) {
+				return NewToggleDatabasesStateOperation([]string{"db1"}, false).GetCommand(nil)
+			},
+			method: http.MethodPost,
+			url:    "http://127.0.0.1:8080/admin/databases/enable",
+			body:   `{"DatabaseNames":["db1"]}`,
+		},
+		{
+			name: "SetDatabasesLock",
+			command: func() (RavenCommand, error) {
+				return NewSetDatabasesLockOperation("db1", DatabaseLockModePreventDeletesError).GetCommand(nil)
+			},
+			method: http.MethodPost,
+			url:    "http://127.0.0.1:8080/admin/databases/set-lock",
+			body:   `{"DatabaseNames":["db1"],"Mode":"PreventDeletesError"}`,
+		},
+		{
+			name: "ModifyConflictSolver",
+			command: func() (RavenCommand, error) {
+				scripts := map[string]*ScriptResolver{
+					"Users": {Script: "return docs[0];"},
+				}
+				return operationCommand(NewModifyConflictSolverOperation("my db", scripts, true))
+			},
+			method: http.MethodPost,
+			url:    "http://127.0.0.1:8080/admin/replication/conflicts/solver?name=my+db",
+			body:   `{"ResolveToLatest":true,"ScriptResolvers":{"Users":{"Script":"return docs[0];","LastModifiedTime":"0001-01-01T00:00:00.0000000Z"}}}`,
+		},
+		{
+			name: "PutPullReplicationDefinition",
+			command: func() (RavenCommand, error) {
+				definition := NewPullReplicationDefinition("hub")
+				definition.DelayReplicationFor = Duration(time.Minute)
+				return NewPutPullReplicationDefinitionCommand(definition)
+			},
+			method: http.MethodPut,
+			url:    "http://127.0.0.1:8080/databases/db/admin/tasks/pull-replication/hub",
+			body:   `{"TaskId":0,"Name":"hub","Disabled":false,"DelayReplicationFor":"00:01:00"}`,
+		},
+	}
+	for _, test := range tests {
+		cmd, err := test.command()
+		require.NoError(t, err, "command: %s", test.name)
+		req, body := createTestRequest(t, cmd)
+		assert.Equal(t, test.method, req.Method, "command: %s", test.name)
+		assert.Equal(t, test.url, req.URL.String(), "command: %s", test.name)
+		if test.body != "" {
+			assert.JSONEq(t, test.body, string(body), "command: %s", test.name)
+		}
+		if test.responseType != "" {
+			assert.Equal(t, test.responseType, cmd.GetBase().ResponseType, "command: %s", test.name)
+		}
+	}
+}
+
+func TestCommandInvalidArguments(t *testing.T) {
+	tests := []struct {
+		name    string
+		command func() (RavenCommand, error)
+	}{
+		{"DisableIndex", func() (RavenCommand, error) { return operationCommand(NewDisableIndexOperation("")) }},
+		{"EnableIndex", func() (RavenCommand, error) { return operationCommand(NewEnableIndexOperation("")) }},
+		{"SetIndexesPriority", func() (RavenCommand, error) {
+			return operationCommand(NewSetIndexesPriorityOperation("", IndexPriorityLow))
+		}},
+		{"SetIndexesLockOnAutoIndex", func() (RavenCommand, error) {
+			return operationCommand(NewSetIndexesLockOperation("Auto/Orders/ByCompany", IndexLockModeLockedError))
+		}},
+		{"GetIndexStatistics", func() (RavenCommand, error) { return NewGetIndexStatisticsCommand("") }},
+		{"GetSubscriptionsNegativeStart", func() (RavenCommand, error) { return operationCommand(NewGetSubscriptionsOperation(-1, 5)) }},
+		{"GetSubscriptionsZeroPageSize", func() (RavenCommand, error) { return operationCommand(NewGetSubscriptionsOperation(0, 0)) }},
+		{"GetSubscriptionState", func() (RavenCommand, error) { return operationCommand(NewGetSubscriptionStateOperation("")) }},
+		{"DropSubscriptionConnection", func() (RavenCommand, error) { return operationCommand(NewDropSubscriptionConnectionOperation("")) }},
+		{"GetDocumentsWithEmptyTimeSeriesRange", func() (RavenCommand, error) {
+			return NewGetDocumentsCommandWithIncludes([]string{"users/1"}, nil, nil, []*TimeSeriesRange{{}}, false)
+		}},
+		{"GetDocumentsRangeNegativeStart", func() (RavenCommand, error) { return NewGetDocumentsCommandRange(-1, 5, false) }},
+		{"GetDocumentsRangeZeroPageSize", func() (RavenCommand, error) { return NewGetDocumentsCommandRange(0, 0, false) }},
+		{"RestoreBackupWithoutLocation", func() (RavenCommand, error) {
+			return NewRestoreBackupOperation(&RestoreBackupConfiguration{DatabaseName: "restored"}).GetCommand(nil)
+		}},
+		// nothing to compact
+		{"CompactDatabase", func() (RavenCommand, error) {
+			return NewCompactDatabaseOperation(&CompactSettings{DatabaseName: "db"}).GetCommand(NewDocumentConventions())
+		}},
+		{"ToggleDatabasesState", func() (RavenCommand, error) { return NewToggleDatabasesStateOperation(nil, false).GetCommand(nil) }},
+		{"SetDatabasesLock", func() (RavenCommand, error) {
+			return NewSetDatabasesLockOperation("", DatabaseLockModeUnlock).GetCommand(nil)
+		}},
+		{"ModifyConflictSolver", func() (RavenCommand, error) { return operationCommand(NewModifyConflictSolverOperation("", nil, true)) }},
+		{"PutPullReplicationDefinition", func() (RavenCommand, error) {
+			return NewPutPullReplicationDefinitionCommand(&PullReplicationDefinition{})
+		}},
+		{"GetMultiFacets", func() (RavenCommand, error) { return NewGetMultiFacetsCommand(NewDocumentConventions(), nil) }},
+	}
+	for _, test := range tests {
+		_, err := test.command()
+		_, ok := err.(*IllegalArgumentError)
+		assert.True(t, ok, "command: %s, expected *IllegalArgumentError, got %T (%v)", test.name, err, err)
+	}
+}
+
+func TestCommandEmptyResponse(t *testing.T) {
+	tests := []struct {
+		name    string
+		command func() (RavenCommand, error)
+	}{
+		{"GetBuildNumber", func() (RavenCommand, error) { return NewGetBuildNumberOperation().GetCommand(nil) }},
+		{"GetNodeInfo", func() (RavenCommand, error) { return NewGetNodeInfoOperation().GetCommand(nil) }},
+		{"GetIndexErrors", func() (RavenCommand, error) { return NewGetIndexErrorsCommand(nil), nil }},
+		{"GetNextOperationID", func() (RavenCommand, error) { return NewGetNextOperationIDCommand(), nil }},
+	}
+	for _, test := range tests {
+		cmd, err := test.command()
+		require.NoError(t, err, "command: %s", test.name)
+		err = cmd.SetResponse(nil, false)
+		_, ok := err.(*IllegalStateError)
+		assert.True(t, ok, "command: %s, expected *IllegalStateError, got %T (%v)", test.name, err, err)
+	}
+}
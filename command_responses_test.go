@@ -0,0 +1,185 @@
+package ravendb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetBuildNumberCommandSetResponse(t *testing.T) {
+	op := NewGetBuildNumberOperation()
+	cmd, err := op.GetCommand(NewDocumentConventions())
+	require.NoError(t, err)
+
+	js := `{"BuildVersion":54,"ProductVersion":"5.4","CommitHash":"a1b2c3","FullVersion":"5.4.107"}`
+	err = cmd.SetResponse([]byte(js), false)
+	require.NoError(t, err)
+	res := op.Command.Result
+	assert.Equal(t, 54, res.BuildVersion)
+	assert.Equal(t, "5.4", res.ProductVersion)
+	assert.Equal(t, "a1b2c3", res.CommitHash)
+	assert.Equal(t, "5.4.107", res.FullVersion)
+}
+
+func TestGetNodeInfoCommandSetResponse(t *testing.T) {
+	op := NewGetNodeInfoOperation()
+	cmd, err := op.GetCommand(NewDocumentConventions())
+	require.NoError(t, err)
+
+	js := `{"NodeTag":"A","TopologyId":"3c1b8e2a","ServerId":"9f3c0d52-0c6e-4a7c-9d1e-2f8b4a6e1c11","Certificate":null,` +
+		`"ClusterStatus":"Joined","CurrentState":"Leader","NumberOfCores":8,"InstalledMemoryInGb":15.5,"UsableMemoryInGb":15.5,` +
+		`"BuildInfo":{"ProductVersion":"5.4","BuildVersion":54,"CommitHash":"a1b2c3","FullVersion":"5.4.107"},` +
+		`"OsInfo":{"Type":"Linux","FullName":"Ubuntu 22.04"},"HasFixedPort":true,"ServerSchemaVersion":54000}`
+	err = cmd.SetResponse([]byte(js), false)
+	require.NoError(t, err)
+	res := op.Command.Result
+	assert.Equal(t, "A", res.NodeTag)
+	assert.Equal(t, "3c1b8e2a", res.TopologyID)
+	assert.Equal(t, "9f3c0d52-0c6e-4a7c-9d1e-2f8b4a6e1c11", res.ServerID)
+	assert.Equal(t, "Leader", res.CurrentState)
+	assert.Equal(t, "Joined", res.ClusterStatus)
+	assert.Equal(t, 8, res.NumberOfCores)
+	assert.Equal(t, 15.5, res.InstalledMemoryInGb)
+	assert.True(t, res.HasFixedPort)
+	assert.Equal(t, 54000, res.ServerSchemaVersion)
+	require.NotNil(t, res.BuildInfo)
+	assert.Equal(t, "5.4.107", res.BuildInfo.FullVersion)
+}
+
+func TestGetIndexErrorsCommandSetResponse(t *testing.T) {
+	cmd := NewGetIndexErrorsCommand([]string{"Orders/ByCompany", "Users"})
+	js := `{"Results":[
+		{"Name":"Orders/ByCompany","Errors":[{"Error":"Failed to execute reduce function","Timestamp":"2020-03-04T10:11:12.0000000Z","Document":"orders/1-A","Action":"Reduce"}]},
+		{"Name":"Users","Errors":[]}
+	]}`
+	err := cmd.SetResponse([]byte(js), false)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(cmd.Result))
+
+	res := cmd.Result[0]
+	assert.Equal(t, "Orders/ByCompany", res.Name)
+	require.Equal(t, 1, len(res.Errors))
+	indexingError := res.Errors[0]
+	assert.Equal(t, "Failed to execute reduce function", indexingError.Error)
+	assert.Equal(t, time.Date(2020, 3, 4, 10, 11, 12, 0, time.UTC), time.Time(indexingError.Timestamp))
+	assert.Equal(t, "orders/1-A", indexingError.Document)
+	assert.Equal(t, "Reduce", indexingError.Action)
+
+	assert.Equal(t, "Users", cmd.Result[1].Name)
+	assert.Equal(t, 0, len(cmd.Result[1].Errors))
+}
+
+func TestGetNextOperationIDCommandSetResponse(t *testing.T) {
+	cmd := NewGetNextOperationIDCommand()
+	err := cmd.SetResponse([]byte(`{"Id":42,"NodeTag":"A"}`), false)
+	require.NoError(t, err)
+	assert.Equal(t, int64(42), cmd.Result)
+}
+
+const indexStatsJSON = `{"Results":[{
+	"Name": "UsersByName",
+	"MapAttempts": 12,
+	"MapSuccesses": 11,
+	"MapErrors": 1,
+	"ReduceAttempts": null,
+	"ReduceSuccesses": null,
+	"ReduceErrors": null,
+	"MappedPerSecondRate": 2.5,
+	"ReducedPerSecondRate": 0,
+	"MaxNumberOfOutputsPerDocument": 1,
+	"Collections": {"Users": {"LastProcessedDocumentEtag": 12, "LastProcessedTombstoneEtag": 0, "DocumentLag": 3, "TombstoneLag": 0}},
+	"LastQueryingTime": "2020-03-04T10:11:12.0000000Z",
+	"State": "Normal",
+	"Priority": "Normal",
+	"CreatedTimestamp": "2020-03-04T10:00:00.0000000Z",
+	"LastIndexingTime": "2020-03-04T10:11:00.0000000Z",
+	"IsStale": true,
+	"LockMode": "Unlock",
+	"Type": "Map",
+	"Status": "Running",
+	"EntriesCount": 11,
+	"ErrorsCount": 1,
+	"IsTestIndex": false
+}]}`
+
+func TestGetIndexStatisticsCommandSetResponse(t *testing.T) {
+	cmd, err := NewGetIndexStatisticsCommand("UsersByName")
+	require.NoError(t, err)
+
+	err = cmd.SetResponse([]byte(indexStatsJSON), false)
+	require.NoError(t, err)
+	stats := cmd.Result
+	assert.Equal(t, "UsersByName", stats.Name)
+	assert.True(t, stats.IsStale)
+	assert.Equal(t, 11, stats.EntriesCount)
+	assert.Equal(t, 12, stats.MapAttempts)
+	assert.Equal(t, 11, stats.MapSuccesses)
+	assert.Equal(t, 1, stats.MapErrors)
+	assert.Nil(t, stats.ReduceAttempts)
+	assert.Equal(t, IndexStateNormal, stats.State)
+	assert.Equal(t, IndexRunningStatusRunning, stats.Status)
+	assert.Equal(t, IndexTypeMap, stats.Type)
+	require.NotNil(t, stats.Collections["Users"])
+	assert.Equal(t, int64(3), stats.Collections["Users"].DocumentLag)
+
+	err = cmd.SetResponse([]byte(`{"Results":[]}`), false)
+	assert.Error(t, err)
+}
+
+const subscriptionStateJSON = `{
+	"Query": "from Users",
+	"ChangeVectorForNextBatchStartingPoint": "A:12-abc",
+	"SubscriptionId": 3,
+	"SubscriptionName": "users",
+	"MentorNode": "B",
+	"NodeTag": "A",
+	"LastBatchAckTime": "2020-05-06T07:08:09.0000000Z",
+	"LastClientConnectionTime": null,
+	"Disabled": false
+}`
+
+func TestGetSubscriptionsCommandSetResponse(t *testing.T) {
+	op, err := NewGetSubscriptionsOperation(10, 5)
+	require.NoError(t, err)
+	cmd, err := op.GetCommand(nil)
+	require.NoError(t, err)
+
+	err = cmd.SetResponse([]byte(`{"Results":[`+subscriptionStateJSON+`]}`), false)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(op.Command.Result))
+	state := op.Command.Result[0]
+	assert.Equal(t, "from Users", state.Query)
+	assert.Equal(t, "A:12-abc", *state.ChangeVectorForNextBatchStartingPoint)
+	assert.Equal(t, int64(3), state.SubscriptionID)
+	assert.Equal(t, "B", state.MentorNode)
+	assert.Equal(t, time.Date(2020, 5, 6, 7, 8, 9, 0, time.UTC), time.Time(state.LastBatchAckTime))
+	assert.True(t, time.Time(state.LastClientConnectionTime).IsZero())
+}
+
+func TestGetSubscriptionStateCommandSetResponse(t *testing.T) {
+	op, err := NewGetSubscriptionStateOperation("users subscription")
+	require.NoError(t, err)
+	cmd, err := op.GetCommand(nil)
+	require.NoError(t, err)
+
+	require.NoError(t, cmd.SetResponse([]byte(subscriptionStateJSON), false))
+	assert.Equal(t, "users", op.Command.Result.SubscriptionName)
+	assert.Equal(t, "from Users", op.Command.Result.Query)
+}
+
+func TestRestoreBackupCommandSetResponse(t *testing.T) {
+	config := &RestoreBackupConfiguration{
+		DatabaseName:   "restored",
+		BackupLocation: "/backups/db",
+	}
+	cmd, err := NewRestoreBackupOperation(config).GetCommand(nil)
+	require.NoError(t, err)
+
+	err = cmd.SetResponse([]byte(`{"OperationId":5,"OperationNodeTag":"A"}`), false)
+	require.NoError(t, err)
+	res, err := getCommandOperationIDResult(cmd)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), res.OperationID)
+}
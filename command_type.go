@@ -14,4 +14,7 @@ const (
 	CommandAttachmentDelete    = "ATTACHMENT_DELETE"
 	CommandClientAnyCommand    = "CLIENT_ANY_COMMAND"
 	CommandClientNotAttachment = "CLIENT_NOT_ATTACHMENT"
+
+	CommandCompareExchangePut    = "CompareExchangePUT"
+	CommandCompareExchangeDelete = "CompareExchangeDELETE"
 )
@@ -51,6 +51,12 @@ func NewCompactDatabaseCommand(conventions *DocumentConventions, compactSettings
 	if compactSettings == nil {
 		return nil, newIllegalArgumentError("CompactSettings cannot be null")
 	}
+	if compactSettings.DatabaseName == "" {
+		return nil, newIllegalArgumentError("DatabaseName cannot be empty")
+	}
+	if !compactSettings.Documents && len(compactSettings.Indexes) == 0 {
+		return nil, newIllegalArgumentError("Either Documents or Indexes must be selected for compaction")
+	}
 
 	d, err := jsonMarshal(compactSettings)
 	if err != nil {
@@ -0,0 +1,22 @@
+package ravendb
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCompactionProgress(t *testing.T) {
+	var m map[string]interface{}
+	js := `{"TreeName":"Collection.Documents.users","TreeProgress":5,"TreeTotal":10,"GlobalProgress":12,"GlobalTotal":64,"Skipped":false}`
+	require.NoError(t, json.Unmarshal([]byte(js), &m))
+	progress, err := NewCompactionProgress(m)
+	require.NoError(t, err)
+	assert.Equal(t, "Collection.Documents.users", progress.TreeName)
+	assert.Equal(t, int64(5), progress.TreeProgress)
+	assert.Equal(t, int64(10), progress.TreeTotal)
+	assert.Equal(t, int64(12), progress.GlobalProgress)
+	assert.Equal(t, int64(64), progress.GlobalTotal)
+}
@@ -1,8 +1,39 @@
 package ravendb
 
-// CompactSettings is an argument to CompactDatabaseOperation
+// CompactSettings is an argument to CompactDatabaseOperation.
+// At least one of Documents or Indexes must be set.
 type CompactSettings struct {
 	DatabaseName string   `json:"DatabaseName"`
 	Documents    bool     `json:"Documents"`
 	Indexes      []string `json:"Indexes,omitempty"`
+	// SkipOptimizeIndexes skips optimizing compacted indexes, which is
+	// faster but leaves them larger
+	SkipOptimizeIndexes bool `json:"SkipOptimizeIndexes"`
+}
+
+// CompactionProgress is a progress of a compaction operation reported
+// through Operation.OnProgress
+type CompactionProgress struct {
+	// TreeName is a name of the tree being compacted
+	TreeName string `json:"TreeName"`
+	// TreeProgress and TreeTotal count entries of the current tree
+	TreeProgress int64 `json:"TreeProgress"`
+	TreeTotal    int64 `json:"TreeTotal"`
+	// GlobalProgress and GlobalTotal are in megabytes
+	GlobalProgress int64  `json:"GlobalProgress"`
+	GlobalTotal    int64  `json:"GlobalTotal"`
+	Processed      int64  `json:"Processed"`
+	Total          int64  `json:"Total"`
+	Skipped        bool   `json:"Skipped"`
+	Message        string `json:"Message"`
+}
+
+// NewCompactionProgress decodes progress passed to Operation.OnProgress
+// of a compaction operation
+func NewCompactionProgress(progress map[string]interface{}) (*CompactionProgress, error) {
+	var res *CompactionProgress
+	if err := structFromJSONMap(progress, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
 }
@@ -0,0 +1,69 @@
+package ravendb
+
+var (
+	_ ICommandData = &PutCompareExchangeCommandData{}
+	_ ICommandData = &DeleteCompareExchangeCommandData{}
+)
+
+// PutCompareExchangeCommandData represents a compare exchange put
+// executed as part of a cluster-wide transaction
+type PutCompareExchangeCommandData struct {
+	*CommandData
+
+	index int64
+	value interface{}
+}
+
+// NewPutCompareExchangeCommandData returns a command that sets value of a compare
+// exchange key if its current index is index. Index 0 creates a new key
+func NewPutCompareExchangeCommandData(key string, value interface{}, index int64) *PutCompareExchangeCommandData {
+	return &PutCompareExchangeCommandData{
+		CommandData: &CommandData{
+			ID:   key,
+			Type: CommandCompareExchangePut,
+		},
+		index: index,
+		value: value,
+	}
+}
+
+func (d *PutCompareExchangeCommandData) serialize(conventions *DocumentConventions) (interface{}, error) {
+	res := map[string]interface{}{
+		"Id":    d.ID,
+		"Type":  d.Type,
+		"Index": d.index,
+		"Document": map[string]interface{}{
+			"Object": d.value,
+		},
+	}
+	return res, nil
+}
+
+// DeleteCompareExchangeCommandData represents a compare exchange delete
+// executed as part of a cluster-wide transaction
+type DeleteCompareExchangeCommandData struct {
+	*CommandData
+
+	index int64
+}
+
+// NewDeleteCompareExchangeCommandData returns a command that deletes a compare
+// exchange key if its current index is index
+func NewDeleteCompareExchangeCommandData(key string, index int64) *DeleteCompareExchangeCommandData {
+	return &DeleteCompareExchangeCommandData{
+		CommandData: &CommandData{
+			ID:   key,
+			Type: CommandCompareExchangeDelete,
+		},
+		index: index,
+	}
+}
+
+func (d *DeleteCompareExchangeCommandData) serialize(conventions *DocumentConventions) (interface{}, error) {
+	res := map[string]interface{}{
+		"Id":    d.ID,
+		"Type":  d.Type,
+		"Index": d.index,
+	}
+	return res, nil
+}
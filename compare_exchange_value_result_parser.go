@@ -30,48 +30,11 @@ func compareExchangeValueResultParserGetValues(clazz reflect.Type, response []by
 		if !ok {
 			return nil, newIllegalStateError("Response is invalid. Item is null")
 		}
-		key, ok := jsonGetAsString(item, "Key")
-		if !ok {
-			return nil, newIllegalStateError("Response is invalid. Key is missing.")
-		}
-		index, ok := jsonGetAsInt64(item, "Index")
-
-		if !ok {
-			return nil, newIllegalStateError("Response is invalid. Index is missing")
-		}
-
-		raw, ok := item["Value"]
-		if !ok || raw == nil {
-			return nil, newIllegalStateError("Response is invalid. Value is missing.")
-		}
-		rawMap, ok := raw.(map[string]interface{})
-		if !ok {
-			return nil, newIllegalStateError("Response is invalid. Value is missing.")
-		}
-
-		if isTypePrimitive(clazz) {
-			var value interface{}
-			rawValue := rawMap["Object"]
-			value, err = convertValue(rawValue, clazz)
-			if err != nil {
-				return nil, err
-			}
-			cmpValue := NewCompareExchangeValue(key, index, value)
-			results[key] = cmpValue
-		} else {
-			object, ok := rawMap["Object"]
-			if !ok || object == nil {
-				v := NewCompareExchangeValue(key, index, getDefaultValueForType(clazz))
-				results[key] = v
-			} else {
-				converted, err := convertValue(object, clazz)
-				if err != nil {
-					return nil, err
-				}
-				v := NewCompareExchangeValue(key, index, converted)
-				results[key] = v
-			}
+		v, err := compareExchangeValueResultParserGetValueFromJSON(clazz, item)
+		if err != nil {
+			return nil, err
 		}
+		results[v.Key] = v
 	}
 
 	return results, nil
@@ -97,3 +60,44 @@ func compareExchangeValueResultParserGetValue(clazz reflect.Type, response []byt
 	panicIf(true, "Should never be reached")
 	return nil, nil
 }
+
+// compareExchangeValueResultParserGetValueFromJSON converts a single
+// compare exchange value sent by the server to *CompareExchangeValue
+func compareExchangeValueResultParserGetValueFromJSON(clazz reflect.Type, item map[string]interface{}) (*CompareExchangeValue, error) {
+	key, ok := jsonGetAsString(item, "Key")
+	if !ok {
+		return nil, newIllegalStateError("Response is invalid. Key is missing.")
+	}
+	index, ok := jsonGetAsInt64(item, "Index")
+
+	if !ok {
+		return nil, newIllegalStateError("Response is invalid. Index is missing")
+	}
+
+	raw, ok := item["Value"]
+	if !ok || raw == nil {
+		return nil, newIllegalStateError("Response is invalid. Value is missing.")
+	}
+	rawMap, ok := raw.(map[string]interface{})
+	if !ok {
+		return nil, newIllegalStateError("Response is invalid. Value is missing.")
+	}
+
+	if isTypePrimitive(clazz) {
+		rawValue := rawMap["Object"]
+		value, err := convertValue(rawValue, clazz)
+		if err != nil {
+			return nil, err
+		}
+		return NewCompareExchangeValue(key, index, value), nil
+	}
+	object, ok := rawMap["Object"]
+	if !ok || object == nil {
+		return NewCompareExchangeValue(key, index, getDefaultValueForType(clazz)), nil
+	}
+	converted, err := convertValue(object, clazz)
+	if err != nil {
+		return nil, err
+	}
+	return NewCompareExchangeValue(key, index, converted), nil
+}
@@ -34,6 +34,8 @@ const (
 	headersClientConfigurationEtag    = "Client-Configuration-Etag"
 	headersRefreshClientConfiguration = "Refresh-Client-Configuration"
 	headersClientVersion              = "Raven-Client-Version"
+	headersServerVersion              = "Raven-Server-Version"
+	headersKnownRaftIndex             = "Known-Raft-Index"
 	headersEtag                       = "ETag"
 	headersIfNoneMatch                = "If-None-Match"
 )
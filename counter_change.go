@@ -0,0 +1,15 @@
+package ravendb
+
+// CounterChange describes a change to a counter. Can be used as DatabaseChange.
+type CounterChange struct {
+	Type           CounterChangeTypes
+	Name           string
+	Value          int64
+	DocumentID     string `json:"DocumentId"`
+	CollectionName string
+	ChangeVector   string
+}
+
+func (c *CounterChange) String() string {
+	return c.Type + " on " + c.DocumentID + " counter " + c.Name
+}
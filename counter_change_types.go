@@ -0,0 +1,10 @@
+package ravendb
+
+type CounterChangeTypes = string
+
+const (
+	CounterChangeNone      = "None"
+	CounterChangePut       = "Put"
+	CounterChangeDelete    = "Delete"
+	CounterChangeIncrement = "Increment"
+)
@@ -0,0 +1,10 @@
+package ravendb
+
+// CounterDetail describes value of a counter of a document
+type CounterDetail struct {
+	DocumentID  string `json:"DocumentId"`
+	CounterName string `json:"CounterName"`
+	TotalValue  int64  `json:"TotalValue"`
+	// CounterValues has values per database node, if requested
+	CounterValues map[string]int64 `json:"CounterValues"`
+}
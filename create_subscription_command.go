@@ -34,7 +34,7 @@ func (c *CreateSubscriptionCommand) CreateRequest(node *ServerNode) (*http.Reque
 	uri := node.URL + "/databases/" + node.Database + "/subscriptions"
 
 	if c.id != "" {
-		uri += "?id=" + c.id
+		uri += "?id=" + urlUtilsEscapeDataString(c.id)
 	}
 
 	d, err := json.Marshal(c.options)
@@ -39,6 +39,7 @@ type changeSubscribers struct {
 	onDocumentChange        sync.Map // int -> func(*DocumentChange)
 	onIndexChange           sync.Map // int -> func(*IndexChange)
 	onOperationStatusChange sync.Map // int -> func(*OperationStatusChange)
+	onCounterChange         sync.Map // int -> func(*CounterChange)
 
 	nextID int32 // atomic
 }
@@ -78,6 +79,16 @@ func (s *changeSubscribers) unregisterOnOperationStatusChange(id int) {
 	s.onOperationStatusChange.Delete(id)
 }
 
+func (s *changeSubscribers) registerOnCounterChange(fn func(*CounterChange)) int {
+	id := s.getNextID()
+	s.onCounterChange.Store(id, fn)
+	return id
+}
+
+func (s *changeSubscribers) unregisterOnCounterChange(id int) {
+	s.onCounterChange.Delete(id)
+}
+
 func (s *changeSubscribers) sendDocumentChange(change *DocumentChange) {
 	s.onDocumentChange.Range(func(k, v interface{}) bool {
 		f := v.(func(documentChange *DocumentChange))
@@ -102,6 +113,14 @@ func (s *changeSubscribers) sendOperationStatusChange(change *OperationStatusCha
 	})
 }
 
+func (s *changeSubscribers) sendCounterChange(change *CounterChange) {
+	s.onCounterChange.Range(func(k, v interface{}) bool {
+		f := v.(func(*CounterChange))
+		f(change)
+		return true
+	})
+}
+
 func (s *changeSubscribers) hasRegisteredHandlers() bool {
 	// there is no sync.Map.Count() so we have to enumerate to see
 	// if there are any registered handlers
@@ -114,6 +133,7 @@ func (s *changeSubscribers) hasRegisteredHandlers() bool {
 	s.onDocumentChange.Range(fn)
 	s.onIndexChange.Range(fn)
 	s.onOperationStatusChange.Range(fn)
+	s.onCounterChange.Range(fn)
 	return hasHandlers
 }
 
@@ -175,14 +195,22 @@ type DatabaseChanges struct {
 	ctxCancel    context.Context
 	doWorkCancel context.CancelFunc
 
-	// will be notified if we connect or fail to connect
-	// allows waiting for connection being established
-	chIsConnected chan error
+	// closed when connected, re-created when disconnected.
+	// allows waiting for connection being established.
+	// protected by mu
+	chIsConnected chan struct{}
+	isConnected   bool
+
+	// number of times we've connected. Only accessed from doWork()
+	nConnects int
 
 	chCommands      chan *databaseChangesCommand
 	chWorkCompleted chan error
 
 	subscribers sync.Map // string => *changeSubscribers
+	// held while adding subscribers and while re-sending watch commands
+	// after connecting so that each watch command is sent only once
+	subscribersMu sync.Mutex
 
 	mu sync.Mutex
 
@@ -217,7 +245,7 @@ func newDatabaseChanges(requestExecutor *RequestExecutor, databaseName string, o
 		requestExecutor: requestExecutor,
 		conventions:     requestExecutor.GetConventions(),
 		database:        databaseName,
-		chIsConnected:   make(chan error, 1),
+		chIsConnected:   make(chan struct{}),
 		onClose:         onClose,
 		chWorkCompleted: make(chan error, 1),
 		chCommands:      make(chan *databaseChangesCommand, 32),
@@ -243,7 +271,44 @@ func newDatabaseChanges(requestExecutor *RequestExecutor, databaseName string, o
 	return res
 }
 
+// changesReconnectMinDelay and changesReconnectMaxDelay limit how long we wait
+// before trying to reconnect. The delay doubles after every failed attempt
+var (
+	changesReconnectMinDelay = time.Second
+	changesReconnectMaxDelay = time.Second * 30
+)
+
+// IsConnected returns true if there's an active connection to the server
+func (c *DatabaseChanges) IsConnected() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.isConnected
+}
+
+func (c *DatabaseChanges) setConnected(connected bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.isConnected == connected {
+		return
+	}
+	c.isConnected = connected
+	if connected {
+		close(c.chIsConnected)
+	} else {
+		c.chIsConnected = make(chan struct{})
+	}
+}
+
+func (c *DatabaseChanges) getChIsConnected() chan struct{} {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.chIsConnected
+}
+
+// EnsureConnectedNow waits until there's a connection to the server.
+// If the connection was lost, it waits for reconnection.
 func (c *DatabaseChanges) EnsureConnectedNow() error {
+	chIsConnected := c.getChIsConnected()
 	select {
 	case <-c.ctxCancel.Done():
 		dcdbg("DatabaseChanges(): EnsureConnectedNow(): is closed\n")
@@ -251,9 +316,9 @@ func (c *DatabaseChanges) EnsureConnectedNow() error {
 	case err := <-c.chWorkCompleted:
 		dcdbg("DatabaseChanges(): EnsureConnectedNow(): chnWorkCompleted notified\n")
 		return err
-	case err := <-c.chIsConnected:
+	case <-chIsConnected:
 		dcdbg("DatabaseChanges(): EnsureConnectedNow(): chanIsConnected notified\n")
-		return err
+		return nil
 	case <-time.After(time.Second * 15):
 		dcdbg("DatabaseChanges(): EnsureConnectedNow(): timed out waiting for connection\n")
 		return errors.New("timed out waiting for connection")
@@ -317,8 +382,10 @@ func (c *DatabaseChanges) ForDocument(docID string, cb func(*DocumentChange)) (C
 	}
 
 	filtered := func(change *DocumentChange) {
-		panicIf(change.ID != docID, "v.ID (%s) != docID (%s)", change.ID, docID)
-		cb(change)
+		// changes are delivered to all subscribers
+		if strings.EqualFold(change.ID, docID) {
+			cb(change)
+		}
 	}
 	idx := subscribers.registerOnDocumentChange(filtered)
 	cancel := func() {
@@ -400,6 +467,74 @@ func (c *DatabaseChanges) ForAllIndexes(cb func(*IndexChange)) (CancelFunc, erro
 	return cancel, nil
 }
 
+// ForAllCounters registers a callback that will be called for changes on all counters.
+// It returns a function to call to unregister the callback.
+func (c *DatabaseChanges) ForAllCounters(cb func(*CounterChange)) (CancelFunc, error) {
+	subscribers, err := c.getOrAddSubscribers("all-counters", "watch-counters", "unwatch-counters", "")
+	if err != nil {
+		return nil, err
+	}
+	return c.registerOnCounterChange(subscribers, cb), nil
+}
+
+// ForCounter registers a callback that will be called for changes on counters with a given name
+// in any document. It returns a function to call to unregister the callback.
+func (c *DatabaseChanges) ForCounter(counterName string, cb func(*CounterChange)) (CancelFunc, error) {
+	if counterName == "" {
+		return nil, newIllegalArgumentError("counterName cannot be empty")
+	}
+	subscribers, err := c.getOrAddSubscribers("counter/"+counterName, "watch-counter", "unwatch-counter", counterName)
+	if err != nil {
+		return nil, err
+	}
+	filtered := func(change *CounterChange) {
+		if strings.EqualFold(change.Name, counterName) {
+			cb(change)
+		}
+	}
+	return c.registerOnCounterChange(subscribers, filtered), nil
+}
+
+// ForCountersOfDocument registers a callback that will be called for changes on counters of a document
+// with a given id. It returns a function to call to unregister the callback.
+func (c *DatabaseChanges) ForCountersOfDocument(docID string, cb func(*CounterChange)) (CancelFunc, error) {
+	if docID == "" {
+		return nil, newIllegalArgumentError("docID cannot be empty")
+	}
+	subscribers, err := c.getOrAddSubscribers("document/"+docID+"/counter", "watch-document-counters", "unwatch-document-counters", docID)
+	if err != nil {
+		return nil, err
+	}
+	filtered := func(change *CounterChange) {
+		if strings.EqualFold(change.DocumentID, docID) {
+			cb(change)
+		}
+	}
+	return c.registerOnCounterChange(subscribers, filtered), nil
+}
+
+// ForCounterOfDocument registers a callback that will be called for changes on a given counter of a document
+// with a given id. It returns a function to call to unregister the callback.
+func (c *DatabaseChanges) ForCounterOfDocument(docID string, counterName string, cb func(*CounterChange)) (CancelFunc, error) {
+	if counterName == "" {
+		return nil, newIllegalArgumentError("counterName cannot be empty")
+	}
+	filtered := func(change *CounterChange) {
+		if strings.EqualFold(change.Name, counterName) {
+			cb(change)
+		}
+	}
+	return c.ForCountersOfDocument(docID, filtered)
+}
+
+func (c *DatabaseChanges) registerOnCounterChange(subscribers *changeSubscribers, cb func(*CounterChange)) CancelFunc {
+	idx := subscribers.registerOnCounterChange(cb)
+	return func() {
+		subscribers.unregisterOnCounterChange(idx)
+		c.maybeDisconnectSubscribers(subscribers)
+	}
+}
+
 // ForDocumentsStartingWith registers a callback that will be called for changes on documents whose id starts with
 // a given prefix. It returns a function to call to unregister the callback.
 func (c *DatabaseChanges) ForDocumentsStartingWith(docIDPrefix string, cb func(*DocumentChange)) (CancelFunc, error) {
@@ -531,9 +666,11 @@ func fmtDCCommand(cmd, value string) string {
 }
 
 func (c *DatabaseChanges) getOrAddSubscribers(name string, watchCommand string, unwatchCommand string, value string) (*changeSubscribers, error) {
+	c.subscribersMu.Lock()
 	subscribersI, ok := c.subscribers.Load(name)
 
 	if ok {
+		c.subscribersMu.Unlock()
 		return subscribersI.(*changeSubscribers), nil
 	}
 
@@ -544,6 +681,13 @@ func (c *DatabaseChanges) getOrAddSubscribers(name string, watchCommand string,
 		commandValue:   value,
 	}
 	c.subscribers.Store(name, subscribers)
+	isConnected := c.IsConnected()
+	c.subscribersMu.Unlock()
+
+	if !isConnected {
+		// watch command is sent when we (re)connect
+		return subscribers, nil
+	}
 	if err := c.connectSubscribers(subscribers); err != nil {
 		return nil, err
 	}
@@ -572,6 +716,18 @@ func (c *DatabaseChanges) send(command, value string, waitForConfirmation bool)
 		return errors.New("Send() called after Close()")
 	}
 
+	if !c.IsConnected() {
+		// subscribers are (re)connected when we connect and the server
+		// drops all watches when connection is lost, so there's nothing
+		// to send
+		dcdbg("DatabaseChanges: Send(): not connected, not sending '%s'\n", fmtDCCommand(command, value))
+		return nil
+	}
+	c.sendCommand(command, value, waitForConfirmation)
+	return nil
+}
+
+func (c *DatabaseChanges) sendCommand(command, value string, waitForConfirmation bool) {
 	id := c.nextCommandID()
 	cmd := newDatabaseChangesCommand(id, command, value)
 	dcdbg("DatabaseChanges: Send(): command id: %d, command: '%s', wait: %v\n", id, fmtDCCommand(command, value), waitForConfirmation)
@@ -587,7 +743,6 @@ func (c *DatabaseChanges) send(command, value string, waitForConfirmation bool)
 	if waitForConfirmation {
 		cmd.waitForConfirmation(time.Second * 15)
 	}
-	return nil
 }
 
 func startSendWorker(conn *websocket.Conn, chCommands chan *databaseChangesCommand) chan error {
@@ -657,27 +812,30 @@ func (c *DatabaseChanges) doWorkInner(ctx context.Context) (error, bool) {
 
 	if err != nil {
 		dcdbg("DatabaseChanges: dialer.DialContext failed with '%s'\n", err)
-		return err, false
+		// if we were connected before, the server might be restarting
+		return err, c.nConnects > 0
 	}
+	c.nConnects++
 
 	var chWriterFailed chan error
 	chWriterFailed = startSendWorker(client, c.chCommands)
 	var chReaderFailed chan error
 	chReaderFailed = c.startProcessMessagesWorker(ctx, client)
 
+	// subscribers added concurrently are either sent below or, after
+	// we're marked as connected, send themselves
+	c.subscribersMu.Lock()
 	connectFn := func(key, value interface{}) bool {
 		subscribers := value.(*changeSubscribers)
-		_ = c.connectSubscribers(subscribers)
+		c.sendCommand(subscribers.watchCommand, subscribers.commandValue, false)
 		return true
 	}
 	c.subscribers.Range(connectFn)
+	c.setConnected(true)
+	c.subscribersMu.Unlock()
 
 	c.invokeConnectionStatusChanged()
 
-	c.chIsConnected <- nil
-	// close so that subsequent channel reads also return immediately
-	close(c.chIsConnected)
-
 	shouldReconnect := true
 	err = nil
 	select {
@@ -694,6 +852,7 @@ func (c *DatabaseChanges) doWorkInner(ctx context.Context) (error, bool) {
 		shouldReconnect = false
 	}
 
+	c.setConnected(false)
 	c.mu.Lock()
 	chCommands := c.chCommands
 	c.chCommands = make(chan *databaseChangesCommand, 32)
@@ -706,7 +865,9 @@ func (c *DatabaseChanges) doWorkInner(ctx context.Context) (error, bool) {
 }
 
 func (c *DatabaseChanges) doWork(ctx context.Context) error {
+	delay := changesReconnectMinDelay
 	for {
+		nConnects := c.nConnects
 		err, shouldReconnect := c.doWorkInner(ctx)
 		if err != nil {
 			dcdbg("DatabaseChanges: doWorkInner() failed with '%s'\n", err)
@@ -715,8 +876,20 @@ func (c *DatabaseChanges) doWork(ctx context.Context) error {
 		if !shouldReconnect {
 			return err
 		}
+		if c.nConnects > nConnects {
+			// we were connected so this is a new failure
+			delay = changesReconnectMinDelay
+		}
 		// wait before next retry
-		time.Sleep(time.Second)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(delay):
+		}
+		delay *= 2
+		if delay > changesReconnectMaxDelay {
+			delay = changesReconnectMaxDelay
+		}
 	}
 }
 
@@ -762,9 +935,22 @@ func (c *DatabaseChanges) notifySubscribers(typ string, value interface{}) error
 			return true
 		}
 		c.subscribers.Range(fn)
+	case "CounterChange":
+		var counterChange *CounterChange
+		err := decodeJSONAsStruct(value, &counterChange)
+		if err != nil {
+			dcdbg("notifySubscribers: '%s' decodeJSONAsStruct failed with %s\n", typ, err)
+			return err
+		}
+		fn := func(key, value interface{}) bool {
+			s := value.(*changeSubscribers)
+			s.sendCounterChange(counterChange)
+			return true
+		}
+		c.subscribers.Range(fn)
 	default:
-		dcdbg("DatabnaseChanges: notifySubscribers(): unsupported type '%s'\n", typ)
-		return fmt.Errorf("notifySubscribers: unsupported type '%s'", typ)
+		// newer servers may send changes we don't know about
+		dcdbg("DatabnaseChanges: notifySubscribers(): ignoring unsupported type '%s'\n", typ)
 	}
 	return nil
 }
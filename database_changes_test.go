@@ -0,0 +1,228 @@
+package ravendb
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDatabaseChangesNotifySubscribers(t *testing.T) {
+	c := &DatabaseChanges{}
+	subscribers := &changeSubscribers{name: "all"}
+	c.subscribers.Store(subscribers.name, subscribers)
+
+	var documentChanges []*DocumentChange
+	var indexChanges []*IndexChange
+	var counterChanges []*CounterChange
+	subscribers.registerOnDocumentChange(func(change *DocumentChange) {
+		documentChanges = append(documentChanges, change)
+	})
+	subscribers.registerOnIndexChange(func(change *IndexChange) {
+		indexChanges = append(indexChanges, change)
+	})
+	id := subscribers.registerOnCounterChange(func(change *CounterChange) {
+		counterChanges = append(counterChanges, change)
+	})
+
+	err := c.notifySubscribers("DocumentChange", map[string]interface{}{
+		"Type": "Put", "Id": "users/1", "CollectionName": "Users", "ChangeVector": "A:1-abc",
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(documentChanges))
+	cv := "A:1-abc"
+	assert.Equal(t, &DocumentChange{Type: DocumentChangePut, ID: "users/1", CollectionName: "Users", ChangeVector: &cv}, documentChanges[0])
+
+	err = c.notifySubscribers("IndexChange", map[string]interface{}{
+		"Type": "BatchCompleted", "Name": "Users/ByName",
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(indexChanges))
+	assert.Equal(t, &IndexChange{Type: IndexChangeBatchCompleted, Name: "Users/ByName"}, indexChanges[0])
+
+	err = c.notifySubscribers("CounterChange", map[string]interface{}{
+		"Type": "Increment", "Name": "likes", "Value": 5, "DocumentId": "users/1", "CollectionName": "Users", "ChangeVector": "A:2-abc",
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(counterChanges))
+	exp := &CounterChange{
+		Type:           CounterChangeIncrement,
+		Name:           "likes",
+		Value:          5,
+		DocumentID:     "users/1",
+		CollectionName: "Users",
+		ChangeVector:   "A:2-abc",
+	}
+	assert.Equal(t, exp, counterChanges[0])
+
+	// changes we don't know about are dropped
+	err = c.notifySubscribers("TimeSeriesChange", map[string]interface{}{"Name": "HeartRate"})
+	assert.NoError(t, err)
+
+	assert.True(t, subscribers.hasRegisteredHandlers())
+	subscribers.unregisterOnCounterChange(id)
+	err = c.notifySubscribers("CounterChange", map[string]interface{}{"Type": "Delete", "Name": "likes"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, len(counterChanges))
+}
+
+// changesTestServer fakes the changes endpoint of the server. It confirms
+// commands and records watch commands
+type changesTestServer struct {
+	srv     *httptest.Server
+	watches chan string
+
+	mu   sync.Mutex
+	conn *websocket.Conn
+}
+
+func newChangesTestServer(t *testing.T, addr string) *changesTestServer {
+	s := &changesTestServer{
+		watches: make(chan string, 16),
+	}
+	upgrader := websocket.Upgrader{}
+	fn := func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		s.mu.Lock()
+		s.conn = conn
+		s.mu.Unlock()
+		for {
+			var cmd struct {
+				CommandId int
+				Command   string
+				Param     string
+			}
+			if err := conn.ReadJSON(&cmd); err != nil {
+				return
+			}
+			if strings.HasPrefix(cmd.Command, "watch-") {
+				s.watches <- cmd.Command + " " + cmd.Param
+			}
+			s.send([]map[string]interface{}{{"Type": "Confirm", "CommandId": cmd.CommandId}})
+		}
+	}
+	s.srv = httptest.NewUnstartedServer(http.HandlerFunc(fn))
+	if addr != "" {
+		l, err := net.Listen("tcp", addr)
+		require.NoError(t, err)
+		s.srv.Listener = l
+	}
+	s.srv.Start()
+	return s
+}
+
+func (s *changesTestServer) send(msg interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.conn != nil {
+		_ = s.conn.WriteJSON(msg)
+	}
+}
+
+func (s *changesTestServer) sendDocumentChange(id string) {
+	change := map[string]interface{}{"Type": "Put", "Id": id, "CollectionName": "Users"}
+	s.send([]map[string]interface{}{{"Type": "DocumentChange", "Value": change}})
+}
+
+func (s *changesTestServer) close() {
+	// websocket connections are hijacked so the server doesn't close them
+	s.mu.Lock()
+	if s.conn != nil {
+		_ = s.conn.Close()
+	}
+	s.mu.Unlock()
+	s.srv.Close()
+}
+
+func waitForString(t *testing.T, ch chan string, exp string) {
+	select {
+	case got := <-ch:
+		assert.Equal(t, exp, got)
+	case <-time.After(time.Second * 5):
+		t.Fatalf("timed out waiting for '%s'", exp)
+	}
+}
+
+func TestDatabaseChangesReconnects(t *testing.T) {
+	srv := newChangesTestServer(t, "")
+	defer func() {
+		srv.close()
+	}()
+	addr := srv.srv.Listener.Addr().String()
+
+	store := newTestStore(t, srv.srv.URL)
+	defer store.Close()
+	changes := store.Changes("")
+	var nStatusChanges int32
+	changes.AddConnectionStatusChanged(func() {
+		atomic.AddInt32(&nStatusChanges, 1)
+	})
+	require.NoError(t, changes.EnsureConnectedNow())
+	assert.True(t, changes.IsConnected())
+
+	ids := make(chan string, 16)
+	cb := func(change *DocumentChange) {
+		ids <- change.ID
+	}
+	_, err := changes.ForDocument("users/1", cb)
+	require.NoError(t, err)
+	waitForString(t, srv.watches, "watch-doc users/1")
+	srv.sendDocumentChange("users/1")
+	waitForString(t, ids, "users/1")
+
+	// simulate server restart
+	srv.close()
+	deadline := time.Now().Add(time.Second * 5)
+	for changes.IsConnected() {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for disconnect")
+		}
+		time.Sleep(time.Millisecond * 10)
+	}
+
+	// registered while disconnected, returns right away and is sent
+	// after reconnecting
+	start := time.Now()
+	_, err = changes.ForDocument("users/2", cb)
+	require.NoError(t, err)
+	assert.True(t, time.Since(start) < time.Second)
+
+	srv = newChangesTestServer(t, addr)
+	require.NoError(t, changes.EnsureConnectedNow())
+
+	var watches []string
+	for i := 0; i < 2; i++ {
+		select {
+		case w := <-srv.watches:
+			watches = append(watches, w)
+		case <-time.After(time.Second * 5):
+			t.Fatal("timed out waiting for watch commands")
+		}
+	}
+	assert.ElementsMatch(t, []string{"watch-doc users/1", "watch-doc users/2"}, watches)
+	select {
+	case w := <-srv.watches:
+		t.Fatalf("unexpected watch command '%s'", w)
+	case <-time.After(time.Millisecond * 100):
+	}
+
+	srv.sendDocumentChange("users/1")
+	waitForString(t, ids, "users/1")
+	srv.sendDocumentChange("users/2")
+	waitForString(t, ids, "users/2")
+
+	// connected, disconnected and connected again
+	assert.True(t, atomic.LoadInt32(&nStatusChanges) >= 3)
+}
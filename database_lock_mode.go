@@ -0,0 +1,12 @@
+package ravendb
+
+// DatabaseLockMode describes protection of a database against deletion
+type DatabaseLockMode = string
+
+const (
+	DatabaseLockModeUnlock = "Unlock"
+	// deleting the database is silently ignored
+	DatabaseLockModePreventDeletesIgnore = "PreventDeletesIgnore"
+	// deleting the database fails with an error
+	DatabaseLockModePreventDeletesError = "PreventDeletesError"
+)
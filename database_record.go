@@ -4,6 +4,7 @@ package ravendb
 type DatabaseRecord struct {
 	DatabaseName         string            `json:"DatabaseName"`
 	Disabled             bool              `json:"Disabled"`
+	LockMode             DatabaseLockMode  `json:"LockMode,omitempty"`
 	DataDirectory        string            `json:"DataDirectory,omitempty"`
 	Settings             map[string]string `json:"Settings"`
 	ConflictSolverConfig *ConflictSolver   `json:"ConflictSolverConfig"`
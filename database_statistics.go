@@ -13,6 +13,9 @@ type DatabaseStatistics struct {
 	CountOfConflicts          int64 `json:"CountOfConflicts"`
 	CountOfAttachments        int64 `json:"CountOfAttachments"`
 	CountOfUniqueAttachments  int64 `json:"CountOfUniqueAttachments"`
+	// those are only returned by 5.x servers
+	CountOfCounterEntries     int64 `json:"CountOfCounterEntries"`
+	CountOfTimeSeriesSegments int64 `json:"CountOfTimeSeriesSegments"`
 
 	Indexes []*IndexInformation `json:"Indexes"`
 
@@ -31,10 +34,13 @@ func (s *DatabaseStatistics) GetLastIndexingTime() *time.Time {
 	return s.LastIndexingTime.toTimePtr()
 }
 
-/*
-public IndexInformation[] getStaleIndexes() {
-	return Arrays.stream(indexes)
-		.filter(x -> x.isStale())
-		.toArray(IndexInformation[]::new);
+// GetStaleIndexes returns indexes that are stale
+func (s *DatabaseStatistics) GetStaleIndexes() []*IndexInformation {
+	var res []*IndexInformation
+	for _, index := range s.Indexes {
+		if index.IsStale {
+			res = append(res, index)
+		}
+	}
+	return res
 }
-*/
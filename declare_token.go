@@ -10,8 +10,6 @@ type declareToken struct {
 	body       string
 }
 
-/*
-// TODO: why is this unused? Should declareToken be publicly exposed?
 func newDeclareToken(name string, body string, parameters string) *declareToken {
 	return &declareToken{
 		name:       name,
@@ -19,7 +17,35 @@ func newDeclareToken(name string, body string, parameters string) *declareToken
 		parameters: parameters,
 	}
 }
-*/
+
+// parseDeclareToken creates a declareToken from JavaScript source of a function
+// in the form "function name(parameters) { body }"
+func parseDeclareToken(source string) (*declareToken, error) {
+	s := strings.TrimSpace(source)
+	s = strings.TrimSpace(strings.TrimPrefix(s, "declare "))
+	if !strings.HasPrefix(s, "function ") {
+		return nil, newIllegalArgumentError("'%s' is not a JavaScript function declaration", source)
+	}
+	s = s[len("function "):]
+
+	openParen := strings.Index(s, "(")
+	closeParen := strings.Index(s, ")")
+	openBrace := strings.Index(s, "{")
+	closeBrace := strings.LastIndex(s, "}")
+	if openParen == -1 || closeParen < openParen || openBrace < closeParen || closeBrace < openBrace {
+		return nil, newIllegalArgumentError("'%s' is not a JavaScript function declaration", source)
+	}
+	name := strings.TrimSpace(s[:openParen])
+	if name == "" || strings.TrimSpace(s[closeParen+1:openBrace]) != "" || strings.TrimSpace(s[closeBrace+1:]) != "" {
+		return nil, newIllegalArgumentError("'%s' is not a JavaScript function declaration", source)
+	}
+	if !queryFieldUtilIsIdentifier(name) {
+		return nil, newIllegalArgumentError("'%s' is not a valid function name", name)
+	}
+	parameters := strings.TrimSpace(s[openParen+1 : closeParen])
+	body := strings.TrimSpace(s[openBrace+1 : closeBrace])
+	return newDeclareToken(name, body, parameters), nil
+}
 
 func (t *declareToken) writeTo(writer *strings.Builder) error {
 
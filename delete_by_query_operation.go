@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 )
 
 var (
@@ -93,3 +94,29 @@ func (c *DeleteByIndexCommand) SetResponse(response []byte, fromCache bool) erro
 
 	return jsonUnmarshal(response, &c.Result)
 }
+
+// NewDeleteByJSQueryOperation returns an operation that deletes documents
+// from collectionName for which JavaScript expression jsFilter
+// (e.g. "this.age > 30 && this.active === false") is true.
+// RQL where clause can't evaluate JavaScript so the filter runs as
+// a patch script that deletes matching documents on the server.
+func NewDeleteByJSQueryOperation(collectionName string, jsFilter string, options *QueryOperationOptions) (*PatchByQueryOperation, error) {
+	if collectionName == "" {
+		return nil, newIllegalArgumentError("CollectionName cannot be empty")
+	}
+	if strings.TrimSpace(jsFilter) == "" {
+		return nil, newIllegalArgumentError("JsFilter cannot be empty")
+	}
+
+	var queryBuilder strings.Builder
+	if err := createFromToken("", collectionName, "").writeTo(&queryBuilder); err != nil {
+		return nil, err
+	}
+	queryBuilder.WriteString(" update { if (")
+	queryBuilder.WriteString(jsFilter)
+	queryBuilder.WriteString(") { del(id(this)); } }")
+
+	op := NewPatchByQueryOperation(queryBuilder.String())
+	op._options = options
+	return op, nil
+}
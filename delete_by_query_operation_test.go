@@ -0,0 +1,23 @@
+package ravendb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDeleteByJSQueryOperation(t *testing.T) {
+	op, err := NewDeleteByJSQueryOperation("Users", "this.age > 30 && this.active === false", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "from Users update { if (this.age > 30 && this.active === false) { del(id(this)); } }", op._queryToUpdate.query)
+
+	op, err = NewDeleteByJSQueryOperation("Old Users", "this.age > 30", nil)
+	require.NoError(t, err)
+	assert.Equal(t, `from "Old Users" update { if (this.age > 30) { del(id(this)); } }`, op._queryToUpdate.query)
+
+	_, err = NewDeleteByJSQueryOperation("", "this.age > 30", nil)
+	assert.Error(t, err)
+	_, err = NewDeleteByJSQueryOperation("Users", " ", nil)
+	assert.Error(t, err)
+}
@@ -25,7 +25,7 @@ func NewDeleteDocumentCommand(id string, changeVector *string) *DeleteDocumentCo
 }
 
 func (c *DeleteDocumentCommand) CreateRequest(node *ServerNode) (*http.Request, error) {
-	url := node.URL + "/databases/" + node.Database + "/docs?id=" + urlEncode(c._id)
+	url := node.URL + "/databases/" + node.Database + "/docs?id=" + urlUtilsEscapeDataString(c._id)
 
 	request, err := newHttpDelete(url, nil)
 	if err != nil {
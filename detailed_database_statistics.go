@@ -0,0 +1,11 @@
+package ravendb
+
+// DetailedDatabaseStatistics describes a result of GetDetailedStatisticsCommand
+type DetailedDatabaseStatistics struct {
+	DatabaseStatistics
+
+	CountOfIdentities      int64 `json:"CountOfIdentities"`
+	CountOfCompareExchange int64 `json:"CountOfCompareExchange"`
+	// only returned by 5.x servers
+	CountOfCompareExchangeTombstones int64 `json:"CountOfCompareExchangeTombstones"`
+}
@@ -936,11 +936,11 @@ groupBy(x => x.Country)
 		Count   int
 	}
 
-	err = query.First(&queryResult)
+	found, err := query.FirstOrDefault(&queryResult)
 	if err != nil {
 		return err
 	}
-	if queryResult != nil {
+	if found {
 		fmt.Printf("Number of employees from country '%s': %d\n", queryResult.Country, queryResult.Count)
 	}
 	return nil
@@ -958,11 +958,11 @@ func autoMapIndex(firstName string) error {
 	query = query.Where("FirstName", "==", firstName)
 
 	var employeeResult *northwind.Employee
-	err = query.First(&employeeResult)
+	found, err := query.FirstOrDefault(&employeeResult)
 	if err != nil {
 		return err
 	}
-	if employeeResult != nil {
+	if found {
 		pretty.Print(employeeResult)
 	} else {
 		fmt.Printf("No employee with first name '%s'\n", firstName)
@@ -990,11 +990,11 @@ func autoMapIndex2(country string) error {
 	query = query.Where("Address.Country", "==", country)
 
 	var employeeResult *northwind.Employee
-	err = query.First(&employeeResult)
+	found, err := query.FirstOrDefault(&employeeResult)
 	if err != nil {
 		return err
 	}
-	if employeeResult != nil {
+	if found {
 		pretty.Print(employeeResult)
 	} else {
 		fmt.Printf("No employee matching query\n")
@@ -1,10 +1,14 @@
 package ravendb
 
-// DocumentConflictError represents document conflict error from the server
+// DocumentConflictError represents document conflict error from the server.
+// When returned by session's Load, Conflicts has conflicting versions
+// of the document. Storing a document with DocID (e.g. one of the versions
+// or a merge of them) resolves the conflict.
 type DocumentConflictError struct {
 	ConflictError
 	DocID       string
 	LargestEtag int64
+	Conflicts   []*Conflict
 }
 
 func newDocumentConflictError(message string, docID string, etag int64) *DocumentConflictError {
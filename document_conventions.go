@@ -1,6 +1,8 @@
 package ravendb
 
 import (
+	"log"
+	"net/http"
 	"reflect"
 	"strings"
 	"sync"
@@ -22,10 +24,12 @@ type DocumentConventions struct {
 	originalConfiguration *ClientConfiguration
 
 	MaxNumberOfRequestsPerSession int
-	// timeout for wait to server
+	// timeout for requests to the server. If 0, http client's timeout
+	// is used. Can be overridden per command with RavenCommandBase.Timeout
 	Timeout                  time.Duration
 	UseOptimisticConcurrency bool
 	// JsonDefaultMethod = DocumentConventions.json_default
+	// Note: queries are always sent with POST so this doesn't limit them
 	MaxLengthOfQueryUsingGetURL int
 	IdentityPartsSeparator      string
 	disableTopologyUpdates      bool
@@ -46,6 +50,61 @@ type DocumentConventions struct {
 
 	maxHttpCacheSize int
 
+	// DisableAutoIndexCreation, if true, makes dynamic queries fail with
+	// IndexDoesNotExistError instead of creating an auto index when no
+	// existing index can answer them. Can be overridden per query with
+	// DocumentQueryCustomization.DisableAutoIndexCreation
+	DisableAutoIndexCreation bool
+
+	// HTTPClientFactory, if set, is used to create http client for sending
+	// requests to a given node. It's called once per node url and the
+	// client is re-used for all subsequent requests to that node
+	HTTPClientFactory func(*ServerNode) *http.Client
+
+	// CloseTimeout is how long DocumentStore.Close() waits for requests
+	// that are still being executed
+	CloseTimeout time.Duration
+
+	// TopologyUpdateInterval is how often the request executor re-fetches
+	// the database topology from the server to pick up nodes that were
+	// added to or removed from the cluster. The topology is only re-fetched
+	// if no response was received during that time because every response
+	// tells the client if the topology changed
+	TopologyUpdateInterval time.Duration
+
+	// MaxNumberOfLazyOperationsPerRequest limits how many lazy operations
+	// are sent to the server in a single request. If 0, all pending
+	// lazy operations are sent in one request
+	MaxNumberOfLazyOperationsPerRequest int
+
+	// MaxNumberOfValuesInWhereIn splits WhereIn with more values than that
+	// into several in() clauses, or-ed together in a sub-clause. Defaults
+	// to 1024. If 0, all values are sent in a single in() clause
+	MaxNumberOfValuesInWhereIn int
+
+	// RetryPolicy, if set, makes requests that failed because of
+	// transient network errors be retried on the same node before
+	// failing over. By default failed requests are not retried
+	RetryPolicy *RetryPolicy
+
+	// JSONSerializer, if set, is used to convert entities to and from JSON.
+	// By default encoding/json is used
+	JSONSerializer JSONSerializer
+
+	// ShouldIgnoreEntityChanges, if set, is called for every entity tracked
+	// by a session when looking for changes. Returning true excludes the
+	// entity from WhatChanged, HasChanges and SaveChanges while still
+	// keeping it in the session
+	ShouldIgnoreEntityChanges func(sessionOperations *InMemoryDocumentSessionOperations, entity interface{}, id string) bool
+
+	// Logger, if set, receives warnings about suspicious use of the client,
+	// e.g. negating a query clause twice. By default nothing is logged
+	Logger *log.Logger
+
+	// entityTypes maps Raven-Go-Type metadata to types registered with
+	// RegisterEntityType
+	entityTypes map[string]reflect.Type
+
 	// a pointer to silence go vet when copying DocumentConventions wholesale
 	mu *sync.Mutex
 }
@@ -68,6 +127,9 @@ func NewDocumentConventions() *DocumentConventions {
 		RaiseIfQueryPageSizeIsNotSet:                   false,
 		transformClassCollectionNameToDocumentIDPrefix: getDefaultTransformCollectionNameToDocumentIdPrefix,
 		MaxNumberOfRequestsPerSession:                  32,
+		CloseTimeout:                                   time.Second * 5,
+		TopologyUpdateInterval:                         time.Minute,
+		MaxNumberOfValuesInWhereIn:                     1024,
 		maxHttpCacheSize:                               128 * 1024 * 1024,
 		mu:                                             &sync.Mutex{},
 	}
@@ -153,12 +215,50 @@ func getDefaultTransformCollectionNameToDocumentIdPrefix(collectionName string)
 }
 
 func (c *DocumentConventions) Clone() *DocumentConventions {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	res := *c
 	// mutex carries its locking state so we need to re-initialize it
 	res.mu = &sync.Mutex{}
+	res.entityTypes = make(map[string]reflect.Type, len(c.entityTypes))
+	for name, typ := range c.entityTypes {
+		res.entityTypes[name] = typ
+	}
 	return &res
 }
 
+// RegisterEntityType registers the type of entity (e.g. &Dog{}) so that
+// documents storing it can be deserialized into an interface type it
+// implements e.g. when querying with results of type *[]Animal.
+// Documents are matched with registered types by their Raven-Go-Type metadata
+func (c *DocumentConventions) RegisterEntityType(entity interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.entityTypes == nil {
+		c.entityTypes = map[string]reflect.Type{}
+	}
+	c.entityTypes[c.getGoTypeName(entity)] = reflect.TypeOf(entity)
+}
+
+// getEntityTypeForInterface returns a type registered with RegisterEntityType
+// for a document with a given metadata. The type must implement iface
+func (c *DocumentConventions) getEntityTypeForInterface(iface reflect.Type, metadata map[string]interface{}) (reflect.Type, error) {
+	goType, _ := jsonGetAsText(metadata, MetadataRavenGoType)
+	c.mu.Lock()
+	typ := c.entityTypes[goType]
+	c.mu.Unlock()
+	if typ == nil {
+		id, _ := jsonGetAsText(metadata, MetadataID)
+		return nil, newIllegalStateError("Cannot deserialize document '%s' into %s. Type '%s' is not registered with DocumentConventions.RegisterEntityType", id, iface, goType)
+	}
+	if !typ.Implements(iface) {
+		return nil, newIllegalStateError("Type %s registered for '%s' doesn't implement %s", typ, goType, iface)
+	}
+	return typ, nil
+}
+
 func (c *DocumentConventions) getGoTypeName(entity interface{}) string {
 	return getFullTypeName(entity)
 }
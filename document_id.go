@@ -0,0 +1,30 @@
+package ravendb
+
+import "strings"
+
+// validateDocumentID returns an error if id can't be used to refer to
+// a document. Ids ending with '|' ask the server to assign the next identity
+// value so they're only valid when storing a new document (allowIdentity)
+func validateDocumentID(id string, allowIdentity bool) error {
+	if id == "" {
+		return newIllegalArgumentError("id cannot be empty string")
+	}
+	if stringIsWhitespace(id) {
+		return newIllegalArgumentError("id cannot consist only of whitespace")
+	}
+	if !allowIdentity && strings.HasSuffix(id, "|") {
+		return newIllegalArgumentError("Document id '%s' ends with '|' which is reserved for identities", id)
+	}
+	return nil
+}
+
+// isServerGeneratedDocumentID returns true if id is a prefix for which
+// the server generates the full id i.e. it ends with '|' or with
+// the identity parts separator
+func isServerGeneratedDocumentID(id string, conventions *DocumentConventions) bool {
+	if strings.HasSuffix(id, "|") {
+		return true
+	}
+	separator := conventions.GetIdentityPartsSeparator()
+	return separator != "" && strings.HasSuffix(id, separator)
+}
@@ -0,0 +1,54 @@
+package ravendb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateDocumentID(t *testing.T) {
+	assert.NoError(t, validateDocumentID("people/Łukasz Müller/№5", false))
+	assert.NoError(t, validateDocumentID("users/", false))
+	assert.NoError(t, validateDocumentID("users|", true))
+
+	assert.Error(t, validateDocumentID("", true))
+	assert.Error(t, validateDocumentID(" \t", true))
+	assert.Error(t, validateDocumentID("users|", false))
+}
+
+func TestSessionUsesIdentityPartsSeparator(t *testing.T) {
+	store := newTestStore(t, "http://127.0.0.1:1")
+	defer store.Close()
+	store.GetConventions().IdentityPartsSeparator = "-"
+
+	session, err := store.OpenSession("")
+	require.NoError(t, err)
+	defer session.Close()
+
+	// the server assigns ids to documents stored with a prefix so
+	// several entities can share it
+	err = session.StoreWithID(&User{}, "users-")
+	assert.NoError(t, err)
+	err = session.StoreWithID(&User{}, "users-")
+	assert.NoError(t, err)
+	err = session.StoreWithID(&User{}, "users|")
+	assert.NoError(t, err)
+	err = session.StoreWithID(&User{}, "users|")
+	assert.NoError(t, err)
+
+	// with a custom separator, '/' is just part of the id
+	err = session.StoreWithID(&User{}, "users/")
+	assert.NoError(t, err)
+	err = session.StoreWithID(&User{}, "users/")
+	_, ok := err.(*NonUniqueObjectError)
+	assert.True(t, ok, "expected *NonUniqueObjectError, got %T (%v)", err, err)
+
+	err = session.StoreWithID(&User{}, "  ")
+	assert.Error(t, err)
+	err = session.DeleteByID("users|", "")
+	assert.Error(t, err)
+	var u *User
+	err = session.Load(&u, "users|")
+	assert.Error(t, err)
+}
@@ -29,6 +29,9 @@ type documentInfo struct {
 	entity               interface{}
 	newDocument          bool
 	collection           string
+	// set by SetRawEntityByID, sent instead of entity's JSON
+	// on the next SaveChanges
+	rawDocument map[string]interface{}
 }
 
 // we want to route assignments to entity through this functions
@@ -2,6 +2,7 @@ package ravendb
 
 import (
 	"reflect"
+	"strings"
 	"time"
 )
 
@@ -33,17 +34,24 @@ type DocumentQueryOptions struct {
 	// rawQuery is mutually exclusive with IndexName and CollectionName/Type
 	rawQuery string
 
-	session      *InMemoryDocumentSessionOperations
-	isGroupBy    bool
-	declareToken *declareToken
-	loadTokens   []*loadToken
-	fromAlias    string
+	session       *InMemoryDocumentSessionOperations
+	isGroupBy     bool
+	declareTokens []*declareToken
+	loadTokens    []*loadToken
+	fromAlias     string
+	// querying an interface type without index or collection
+	collectionNotInferred bool
 }
 
 func newDocumentQuery(opts *DocumentQueryOptions) *DocumentQuery {
 
 	var err error
 	opts.IndexName, opts.CollectionName, err = processQueryParameters(opts.Type, opts.IndexName, opts.CollectionName, opts.conventions)
+	if err == ErrCannotInferCollectionForInterface {
+		// FromIndex or FromCollection can still provide it
+		opts.collectionNotInferred = true
+		err = nil
+	}
 	aq := newAbstractDocumentQuery(opts)
 	if err != nil {
 		aq.err = err
@@ -60,9 +68,9 @@ func (q *DocumentQuery) SelectFields(projectionType reflect.Type, fieldsIn ...st
 	}
 	var fields []string
 	if len(fieldsIn) == 0 {
-		fields = FieldsFor(projectionType)
+		fields = fieldsForType(projectionType)
 		if len(fields) == 0 {
-			q.err = newIllegalArgumentError("type %T has no exported fields to select", projectionType)
+			q.err = newIllegalArgumentError("type %s has no exported fields to select", projectionType)
 			return q
 		}
 	} else {
@@ -102,6 +110,70 @@ func (q *DocumentQuery) SelectFieldsWithQueryData(projectionType reflect.Type, q
 	return res
 }
 
+// SelectJS projects query results with a JavaScript expression evaluated
+// by the server, e.g. `{ FullName: x.FirstName + " " + x.LastName }`.
+// The queried document is available under the query alias, x by default.
+// declaredFunctions are JavaScript functions in the form
+// "function name(args) { body }" that can be called from jsBody.
+func (q *DocumentQuery) SelectJS(projectionType reflect.Type, jsBody string, declaredFunctions ...string) *DocumentQuery {
+	if q.err != nil {
+		return q
+	}
+	res, err := q.selectJS(projectionType, jsBody, declaredFunctions)
+	if err != nil {
+		q.err = err
+		return q
+	}
+	return res
+}
+
+// ProjectUsing projects query results with the body of a JavaScript function
+// that receives the queried document as doc and returns the projection, e.g.
+// `return { FullName: doc.FirstName + " " + doc.LastName }`.
+// It's a shortcut for SelectJS with a declared function.
+func (q *DocumentQuery) ProjectUsing(projectionType reflect.Type, jsFunctionBody string) *DocumentQuery {
+	if q.err != nil {
+		return q
+	}
+	res, err := q.projectUsing(projectionType, jsFunctionBody)
+	if err != nil {
+		q.err = err
+		return q
+	}
+	return res
+}
+
+// SelectWithLoad loads a document referenced by loadPath of the queried document
+// (e.g. "company" of an order) under alias and projects fields of it, e.g.
+// SelectWithLoad(reflect.TypeOf(""), "company", "c", "c.Name") generates
+// "load x.company as c select c.Name".
+// If no fields are given, fields of projectionType are selected from the
+// loaded document.
+func (q *DocumentQuery) SelectWithLoad(projectionType reflect.Type, loadPath string, alias string, fields ...string) *DocumentQuery {
+	if q.err != nil {
+		return q
+	}
+	res, err := q.selectWithLoad(projectionType, loadPath, alias, fields)
+	if err != nil {
+		q.err = err
+		return q
+	}
+	return res
+}
+
+// SelectTimeSeries projects entries of a time series of queried documents
+// between from and to, inclusive. The entries are returned with query
+// results, without separate requests, as TimeSeries field of type
+// *TimeSeriesRawResult. Zero from or to leaves the range open on that
+// side, zero from and to select all entries.
+func (q *DocumentQuery) SelectTimeSeries(timeSeriesName string, from time.Time, to time.Time) *DocumentQuery {
+	if q.err != nil {
+		return q
+	}
+	q.err = q.selectTimeSeries(timeSeriesName, from, to)
+	return q
+}
+
 // Distinct marks query as distinct
 func (q *DocumentQuery) Distinct() *DocumentQuery {
 	if q.err != nil {
@@ -230,6 +302,11 @@ func (q *DocumentQuery) SearchWithOperator(fieldName string, searchTerms string,
 
 //TBD expr  IDocumentQuery<T> Search<TValue>(Expression<Func<T, TValue>> propertySelector, string searchTerms, SearchOperator @operator)
 
+// Intersect makes the query return only documents matched by both the
+// conditions before and after it, e.g.
+// q.WhereExists("field1").Intersect().WhereEquals("field2", "val").
+// It can be called several times to intersect more than 2 sub-queries.
+// It must follow a where clause
 func (q *DocumentQuery) Intersect() *DocumentQuery {
 	if q.err != nil {
 		return q
@@ -238,7 +315,11 @@ func (q *DocumentQuery) Intersect() *DocumentQuery {
 	return q
 }
 
-func (q *DocumentQuery) ContainsAny(fieldName string, values []interface{}) *DocumentQuery {
+// ContainsAny matches documents where array field fieldName contains at least
+// one of the values. values can be a slice or an array of any type, e.g.
+// []string or []int. Nested slices in values are flattened.
+// Empty (or nil) values matches no documents.
+func (q *DocumentQuery) ContainsAny(fieldName string, values interface{}) *DocumentQuery {
 	if q.err != nil {
 		return q
 	}
@@ -248,7 +329,11 @@ func (q *DocumentQuery) ContainsAny(fieldName string, values []interface{}) *Doc
 
 //TBD expr  IDocumentQuery<T> ContainsAny<TValue>(Expression<Func<T, TValue>> propertySelector, IEnumerable<TValue> values)
 
-func (q *DocumentQuery) ContainsAll(fieldName string, values []interface{}) *DocumentQuery {
+// ContainsAll matches documents where array field fieldName contains all
+// of the values. values can be a slice or an array of any type.
+// Nested slices in values are flattened.
+// Empty (or nil) values matches all documents.
+func (q *DocumentQuery) ContainsAll(fieldName string, values interface{}) *DocumentQuery {
 	if q.err != nil {
 		return q
 	}
@@ -263,6 +348,10 @@ func (q *DocumentQuery) Statistics(stats **QueryStatistics) *DocumentQuery {
 	return q
 }
 
+// UsingDefaultOperator sets operator used between where clauses that are not
+// explicitly joined with AndAlso or OrElse. Must be called before adding any
+// where clause or right after OpenSubclause, in which case it only applies
+// to that sub-clause.
 func (q *DocumentQuery) UsingDefaultOperator(queryOperator QueryOperator) *DocumentQuery {
 	if q.err != nil {
 		return q
@@ -281,20 +370,104 @@ func (q *DocumentQuery) NoCaching() *DocumentQuery {
 	return q
 }
 
+// Customize calls action with DocumentQueryCustomization which allows
+// changing options of this query (e.g. projection behavior or disabling
+// auto index creation)
+func (q *DocumentQuery) Customize(action func(*DocumentQueryCustomization)) *DocumentQuery {
+	if q.err != nil {
+		return q
+	}
+	action(&DocumentQueryCustomization{query: q.abstractDocumentQuery})
+	return q
+}
+
 //TBD 4.1  IDocumentQuery<T> showTimings()
 
+// FromIndex makes the query use index indexName instead of the index or
+// the collection it was created with. It must be called before any other
+// clause of the query
+func (q *DocumentQuery) FromIndex(indexName string) *DocumentQuery {
+	if q.err != nil {
+		return q
+	}
+	if stringIsBlank(indexName) {
+		q.err = newIllegalArgumentError("indexName cannot be empty")
+		return q
+	}
+	q.err = q.setFrom(indexName, "")
+	return q
+}
+
+// FromCollection makes the query use collection collectionName instead of
+// the index or the collection it was created with. It must be called before
+// any other clause of the query
+func (q *DocumentQuery) FromCollection(collectionName string) *DocumentQuery {
+	if q.err != nil {
+		return q
+	}
+	if collectionName == "" {
+		q.err = newIllegalArgumentError("collectionName cannot be empty")
+		return q
+	}
+	if q.err = throwIfInvalidCollectionName(collectionName); q.err != nil {
+		return q
+	}
+	q.err = q.setFrom("", collectionName)
+	return q
+}
+
 func (q *DocumentQuery) Include(path string) *DocumentQuery {
 	q.include(path)
 	return q
 }
 
+// IncludeCounters includes values of counters of the resulting documents.
+// They can be read with GetIncludedCountersFor without a request
+// to the server
+func (q *DocumentQuery) IncludeCounters(names ...string) *DocumentQuery {
+	q.includeCounters(names)
+	return q
+}
+
+// IncludeCompareExchangeValue includes compare exchange values whose keys
+// are stored in path of the resulting documents. They can be read with
+// ClusterTransaction().GetCompareExchangeValue without a request
+// to the server
+func (q *DocumentQuery) IncludeCompareExchangeValue(path string) *DocumentQuery {
+	q.includeCompareExchangeValue(path)
+	return q
+}
+
 //TBD expr IDocumentQuery<T> IDocumentQueryBase<T, IDocumentQuery<T>>.Include(Expression<Func<T, object>> path)
 
+// Not negates the next clause. When followed by OpenSubclause it negates
+// the whole sub-clause
 func (q *DocumentQuery) Not() *DocumentQuery {
 	q.negateNext()
 	return q
 }
 
+// WhereNot adds a negated sub-clause built by fn i.e. "not (...)".
+// fn should add clauses to the query it's given.
+// A negated sub-clause at the start of a query (or of another sub-clause)
+// is written as "true and not (...)" as the server can't evaluate a purely
+// negative clause.
+func (q *DocumentQuery) WhereNot(fn func(*DocumentQuery)) *DocumentQuery {
+	if q.err != nil {
+		return q
+	}
+	q.negateNext()
+	if q.err = q.openSubclause(); q.err != nil {
+		return q
+	}
+	fn(q)
+	if q.err != nil {
+		return q
+	}
+	q.err = q.closeSubclause()
+	return q
+}
+
 func (q *DocumentQuery) Take(count int) *DocumentQuery {
 	q.take(count)
 	return q
@@ -329,6 +502,17 @@ func (q *DocumentQuery) WhereEquals(fieldName string, value interface{}) *Docume
 	return q
 }
 
+// WhereEqualsNested matches documents where a field of a nested object
+// (e.g. "Address.ZipCode") is equal to value.
+// Unlike WhereEquals, path is never interpreted as document id.
+func (q *DocumentQuery) WhereEqualsNested(path string, value interface{}) *DocumentQuery {
+	if q.err != nil {
+		return q
+	}
+	q.err = q.whereEqualsNested(path, value)
+	return q
+}
+
 // Exact marks previous Where statement (e.g. WhereEquals or WhereLucene) as exact
 func (q *DocumentQuery) Exact() *DocumentQuery {
 	if q.err != nil {
@@ -384,7 +568,10 @@ func (q *DocumentQuery) WhereNotEqualsWithParams(whereParams *whereParams) *Docu
 	return q
 }
 
-func (q *DocumentQuery) WhereIn(fieldName string, values []interface{}) *DocumentQuery {
+// WhereIn matches documents where fieldName is equal to one of the values.
+// values can be a slice or an array of any type, e.g. []string or []int.
+// Nested slices in values are flattened
+func (q *DocumentQuery) WhereIn(fieldName string, values interface{}) *DocumentQuery {
 	if q.err != nil {
 		return q
 	}
@@ -392,6 +579,25 @@ func (q *DocumentQuery) WhereIn(fieldName string, values []interface{}) *Documen
 	return q
 }
 
+// WhereIDEquals filters by document id. It works regardless of the name
+// of the id property of the queried type
+func (q *DocumentQuery) WhereIDEquals(id string) *DocumentQuery {
+	if q.err != nil {
+		return q
+	}
+	q.err = q.whereIDEquals(id)
+	return q
+}
+
+// WhereIDIn filters documents whose id is one of ids
+func (q *DocumentQuery) WhereIDIn(ids []string) *DocumentQuery {
+	if q.err != nil {
+		return q
+	}
+	q.err = q.whereIDIn(ids)
+	return q
+}
+
 //TBD expr  IDocumentQuery<T> WhereIn<TValue>(Expression<Func<T, TValue>> propertySelector, IEnumerable<TValue> values, bool exact = false)
 
 func (q *DocumentQuery) WhereStartsWith(fieldName string, value interface{}) *DocumentQuery {
@@ -412,6 +618,9 @@ func (q *DocumentQuery) WhereEndsWith(fieldName string, value interface{}) *Docu
 
 //TBD expr  IDocumentQuery<T> WhereEndsWith<TValue>(Expression<Func<T, TValue>> propertySelector, TValue value)
 
+// WhereBetween matches documents where fieldName is between start and end
+// (inclusive). Either start or end can be nil, which makes the range unbounded
+// on that end
 func (q *DocumentQuery) WhereBetween(fieldName string, start interface{}, end interface{}) *DocumentQuery {
 	if q.err != nil {
 		return q
@@ -420,6 +629,24 @@ func (q *DocumentQuery) WhereBetween(fieldName string, start interface{}, end in
 	return q
 }
 
+// Between matches documents where fieldName is between from and to
+// (inclusive). from and to are values or pointers to values of the same
+// type. A nil bound, including a nil pointer, makes the range unbounded
+// on that end
+func (q *DocumentQuery) Between(fieldName string, from interface{}, to interface{}) *DocumentQuery {
+	if q.err != nil {
+		return q
+	}
+	from = rangeBoundValue(from)
+	to = rangeBoundValue(to)
+	if from != nil && to != nil && reflect.TypeOf(from) != reflect.TypeOf(to) {
+		q.err = newIllegalArgumentError("from and to must be of the same type, got %T and %T", from, to)
+		return q
+	}
+	q.err = q.whereBetween(fieldName, from, to)
+	return q
+}
+
 //TBD expr  IDocumentQuery<T> WhereBetween<TValue>(Expression<Func<T, TValue>> propertySelector, TValue start, TValue end, bool exact = false)
 
 func (q *DocumentQuery) WhereGreaterThan(fieldName string, value interface{}) *DocumentQuery {
@@ -484,7 +711,19 @@ func (q *DocumentQuery) AndAlso() *DocumentQuery {
 	if q.err != nil {
 		return q
 	}
-	q.err = q.andAlso()
+	q.err = q.andAlso(false)
+	return q
+}
+
+// AndAlsoWrapPrevious wraps clauses added so far (in the current sub-clause)
+// in parentheses and adds AND after them e.g.
+// WhereEquals("a", 1).OrElse().WhereEquals("b", 2).AndAlsoWrapPrevious().WhereEquals("c", 3)
+// is "(a = $p0 or b = $p1) and c = $p2"
+func (q *DocumentQuery) AndAlsoWrapPrevious() *DocumentQuery {
+	if q.err != nil {
+		return q
+	}
+	q.err = q.andAlso(true)
 	return q
 }
 
@@ -492,7 +731,17 @@ func (q *DocumentQuery) OrElse() *DocumentQuery {
 	if q.err != nil {
 		return q
 	}
-	q.err = q.orElse()
+	q.err = q.orElse(false)
+	return q
+}
+
+// OrElseWrapPrevious wraps clauses added so far (in the current sub-clause)
+// in parentheses and adds OR after them
+func (q *DocumentQuery) OrElseWrapPrevious() *DocumentQuery {
+	if q.err != nil {
+		return q
+	}
+	q.err = q.orElse(true)
 	return q
 }
 
@@ -520,6 +769,7 @@ func (q *DocumentQuery) Proximity(proximity int) *DocumentQuery {
 	return q
 }
 
+// RandomOrdering orders results randomly. Results of such query are not cached.
 func (q *DocumentQuery) RandomOrdering() *DocumentQuery {
 	if q.err != nil {
 		return q
@@ -528,6 +778,9 @@ func (q *DocumentQuery) RandomOrdering() *DocumentQuery {
 	return q
 }
 
+// RandomOrderingWithSeed orders results randomly, in the same order
+// for the same seed as long as the data doesn't change.
+// An empty seed is the same as RandomOrdering.
 func (q *DocumentQuery) RandomOrderingWithSeed(seed string) *DocumentQuery {
 	if q.err != nil {
 		return q
@@ -536,8 +789,6 @@ func (q *DocumentQuery) RandomOrderingWithSeed(seed string) *DocumentQuery {
 	return q
 }
 
-//TBD 4.1  IDocumentQuery<T> customSortUsing(string typeName, bool descending)
-
 // GroupBy makes a query grouped by fields
 func (q *DocumentQuery) GroupBy(fieldName string, fieldNames ...string) *GroupByDocumentQuery {
 	res := newGroupByDocumentQuery(q)
@@ -560,6 +811,68 @@ func (q *DocumentQuery) GroupByFieldWithMethod(field *GroupBy, fields ...*GroupB
 	return res
 }
 
+// WhereHaving filters the results of a group by query to groups where
+// fieldName (a grouping key or an aggregation like count) is equal to value.
+// RQL applies a where clause that follows group by to the grouped results
+// so this is written as a regular where clause.
+func (q *DocumentQuery) WhereHaving(fieldName string, value interface{}) *DocumentQuery {
+	if q.err != nil {
+		return q
+	}
+	if q.err = q.assertIsGroupBy("WhereHaving"); q.err == nil {
+		q.err = q.whereEquals(fieldName, value)
+	}
+	return q
+}
+
+// HavingGreaterThan filters the results of a group by query to groups where
+// fieldName is greater than value
+func (q *DocumentQuery) HavingGreaterThan(fieldName string, value interface{}) *DocumentQuery {
+	if q.err != nil {
+		return q
+	}
+	if q.err = q.assertIsGroupBy("HavingGreaterThan"); q.err == nil {
+		q.err = q.whereGreaterThan(fieldName, value)
+	}
+	return q
+}
+
+// HavingGreaterThanOrEqual filters the results of a group by query to groups
+// where fieldName is greater than or equal to value
+func (q *DocumentQuery) HavingGreaterThanOrEqual(fieldName string, value interface{}) *DocumentQuery {
+	if q.err != nil {
+		return q
+	}
+	if q.err = q.assertIsGroupBy("HavingGreaterThanOrEqual"); q.err == nil {
+		q.err = q.whereGreaterThanOrEqual(fieldName, value)
+	}
+	return q
+}
+
+// HavingLessThan filters the results of a group by query to groups where
+// fieldName is less than value
+func (q *DocumentQuery) HavingLessThan(fieldName string, value interface{}) *DocumentQuery {
+	if q.err != nil {
+		return q
+	}
+	if q.err = q.assertIsGroupBy("HavingLessThan"); q.err == nil {
+		q.err = q.whereLessThan(fieldName, value)
+	}
+	return q
+}
+
+// HavingLessThanOrEqual filters the results of a group by query to groups
+// where fieldName is less than or equal to value
+func (q *DocumentQuery) HavingLessThanOrEqual(fieldName string, value interface{}) *DocumentQuery {
+	if q.err != nil {
+		return q
+	}
+	if q.err = q.assertIsGroupBy("HavingLessThanOrEqual"); q.err == nil {
+		q.err = q.whereLessThanOrEqual(fieldName, value)
+	}
+	return q
+}
+
 // OrderBy orders query results by a field
 func (q *DocumentQuery) OrderBy(field string) *DocumentQuery {
 	return q.OrderByWithOrdering(field, OrderingTypeString)
@@ -576,6 +889,26 @@ func (q *DocumentQuery) OrderByWithOrdering(field string, ordering OrderingType)
 
 //TBD expr  IDocumentQuery<T> OrderBy<TValue>(params Expression<Func<T, TValue>>[] propertySelectors)
 
+// OrderByWithSorter orders query results by a field using a custom sorter
+// deployed to the database with PutSortersOperation
+func (q *DocumentQuery) OrderByWithSorter(field string, sorterName string) *DocumentQuery {
+	if q.err != nil {
+		return q
+	}
+	q.err = q.orderByWithSorter(field, sorterName, false)
+	return q
+}
+
+// OrderByDescendingWithSorter orders query results by a field in descending order
+// using a custom sorter deployed to the database with PutSortersOperation
+func (q *DocumentQuery) OrderByDescendingWithSorter(field string, sorterName string) *DocumentQuery {
+	if q.err != nil {
+		return q
+	}
+	q.err = q.orderByWithSorter(field, sorterName, true)
+	return q
+}
+
 // OrderByDescending orders query by a field in descending order
 func (q *DocumentQuery) OrderByDescending(field string) *DocumentQuery {
 	return q.OrderByDescendingWithOrdering(field, OrderingTypeString)
@@ -604,6 +937,120 @@ func (q *DocumentQuery) RemoveBeforeQueryExecutedListener(idx int) *DocumentQuer
 	return q
 }
 
+// defaultQueryAlias is the alias given to the queried document by projections
+// that need to refer to it (SelectJS, SelectWithLoad) if query doesn't have one
+const defaultQueryAlias = "x"
+
+func (q *abstractDocumentQuery) fromAliasOrDefault() string {
+	if q.fromToken != nil && q.fromToken.alias != "" {
+		return q.fromToken.alias
+	}
+	return defaultQueryAlias
+}
+
+func (q *abstractDocumentQuery) selectJS(projectionType reflect.Type, jsBody string, declaredFunctions []string) (*DocumentQuery, error) {
+	if err := q.assertNoRawQuery(); err != nil {
+		return nil, err
+	}
+	if stringIsBlank(jsBody) {
+		return nil, newIllegalArgumentError("jsBody cannot be empty")
+	}
+	if q.fieldsToFetchToken != nil {
+		return nil, newIllegalStateError("Query already has a projection")
+	}
+
+	declareTokens := append([]*declareToken{}, q.declareTokens...)
+	for _, fn := range declaredFunctions {
+		tok, err := parseDeclareToken(fn)
+		if err != nil {
+			return nil, err
+		}
+		declareTokens = append(declareTokens, tok)
+	}
+
+	queryData := &QueryData{
+		fromAlias:        q.fromAliasOrDefault(),
+		declareTokens:    declareTokens,
+		loadTokens:       q.loadTokens,
+		isCustomFunction: true,
+	}
+	res, err := q.createDocumentQueryInternal(projectionType, queryData)
+	if err != nil {
+		return nil, err
+	}
+	// make a copy so that we don't modify select tokens of q
+	res.selectTokens = append(append([]queryToken{}, res.selectTokens...), singleStringToken(jsBody))
+	return res, nil
+}
+
+// customProjectionFunctionName is the name of the function declared by ProjectUsing
+const customProjectionFunctionName = "customProjection"
+
+func (q *abstractDocumentQuery) projectUsing(projectionType reflect.Type, jsFunctionBody string) (*DocumentQuery, error) {
+	if stringIsBlank(jsFunctionBody) {
+		return nil, newIllegalArgumentError("jsFunctionBody cannot be empty")
+	}
+	fn := "function " + customProjectionFunctionName + "(doc) { " + jsFunctionBody + " }"
+	jsBody := customProjectionFunctionName + "(" + q.fromAliasOrDefault() + ")"
+	return q.selectJS(projectionType, jsBody, []string{fn})
+}
+
+func (q *abstractDocumentQuery) selectWithLoad(projectionType reflect.Type, loadPath string, alias string, fields []string) (*DocumentQuery, error) {
+	if err := q.assertNoRawQuery(); err != nil {
+		return nil, err
+	}
+	if stringIsBlank(loadPath) {
+		return nil, newIllegalArgumentError("loadPath cannot be empty")
+	}
+	fromAlias := q.fromAliasOrDefault()
+	if !queryFieldUtilIsIdentifier(alias) || isRqlTokenKeyword(alias) {
+		return nil, newIllegalArgumentError("'%s' is not a valid alias", alias)
+	}
+	if alias == fromAlias {
+		return nil, newIllegalArgumentError("alias '%s' is already used by the queried document", alias)
+	}
+	for _, tok := range q.loadTokens {
+		if tok.alias == alias {
+			return nil, newIllegalArgumentError("alias '%s' is already used by another load", alias)
+		}
+	}
+
+	// only the path is quoted if needed, alias must stay outside the quotes
+	path, err := queryFieldUtilEscapeIfNecessary(strings.TrimPrefix(loadPath, fromAlias+"."))
+	if err != nil {
+		return nil, err
+	}
+	argument := fromAlias + "." + path
+
+	if len(fields) == 0 {
+		for _, f := range fieldsForType(projectionType) {
+			fields = append(fields, alias+"."+f)
+		}
+		if len(fields) == 0 {
+			return nil, newIllegalArgumentError("type %s has no exported fields to select", projectionType)
+		}
+	}
+
+	projections := fields
+	isSingleValue := projectionType.Kind() == reflect.String || isPrimitiveOrWrapper(projectionType)
+	if !isSingleValue {
+		// "c.Name" is projected into Name field
+		projections = make([]string, len(fields))
+		for i, f := range fields {
+			projections[i] = strings.TrimPrefix(f, alias+".")
+		}
+	}
+
+	queryData := &QueryData{
+		Fields:        fields,
+		Projections:   projections,
+		fromAlias:     fromAlias,
+		declareTokens: q.declareTokens,
+		loadTokens:    append(append([]*loadToken{}, q.loadTokens...), &loadToken{argument: argument, alias: alias}),
+	}
+	return q.createDocumentQueryInternal(projectionType, queryData)
+}
+
 // Note: compared to Java, had to move it down to abstractDocumentQuery
 func (q *abstractDocumentQuery) createDocumentQueryInternal(resultClass reflect.Type, queryData *QueryData) (*DocumentQuery, error) {
 
@@ -633,11 +1080,11 @@ func (q *abstractDocumentQuery) createDocumentQueryInternal(resultClass reflect.
 		q.updateFieldsToFetchToken(newFieldsToFetch)
 	}
 
-	var declareToken *declareToken
+	var declareTokens []*declareToken
 	var loadTokens []*loadToken
 	var fromAlias string
 	if queryData != nil {
-		declareToken = queryData.declareToken
+		declareTokens = queryData.declareTokens
 		loadTokens = queryData.loadTokens
 		fromAlias = queryData.fromAlias
 	}
@@ -648,7 +1095,7 @@ func (q *abstractDocumentQuery) createDocumentQueryInternal(resultClass reflect.
 		IndexName:      q.indexName,
 		CollectionName: q.collectionName,
 		isGroupBy:      q.isGroupBy,
-		declareToken:   declareToken,
+		declareTokens:  declareTokens,
 		loadTokens:     loadTokens,
 		fromAlias:      fromAlias,
 	}
@@ -672,6 +1119,8 @@ func (q *abstractDocumentQuery) createDocumentQueryInternal(resultClass reflect.
 	query.negate = q.negate
 	//noinspection unchecked
 	query.includes = stringArrayCopy(q.includes)
+	query.counterIncludes = stringArrayCopy(q.counterIncludes)
+	query.compareExchangeIncludes = stringArrayCopy(q.compareExchangeIncludes)
 	// TODO: should this be deep copy so that adding/removing in one
 	// doesn't affect the other?
 	query.beforeQueryExecutedCallback = q.beforeQueryExecutedCallback
@@ -679,10 +1128,14 @@ func (q *abstractDocumentQuery) createDocumentQueryInternal(resultClass reflect.
 	query.afterStreamExecutedCallback = q.afterStreamExecutedCallback
 	query.disableEntitiesTracking = q.disableEntitiesTracking
 	query.disableCaching = q.disableCaching
+	query.skipDuplicateChecking = q.skipDuplicateChecking
+	query.projectionBehavior = q.projectionBehavior
+	query.disableAutoIndexCreation = q.disableAutoIndexCreation
 	//TBD 4.1 ShowQueryTimings = ShowQueryTimings,
 	//TBD 4.1 query.shouldExplainScores = shouldExplainScores;
 	query.isIntersect = q.isIntersect
 	query.defaultOperator = q.defaultOperator
+	query.collectionNotInferred = q.collectionNotInferred
 
 	return query, nil
 }
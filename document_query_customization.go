@@ -57,13 +57,19 @@ func (d *DocumentQueryCustomization) NoTracking() {
 
 // RandomOrdering orders search results randomly.
 func (d *DocumentQueryCustomization) RandomOrdering() {
-	d.query.randomOrdering()
+	if d.query.err != nil {
+		return
+	}
+	d.query.err = d.query.randomOrdering()
 }
 
-// RandomOrdering orders search results randomly with a given seed.
+// RandomOrderingWithSeed orders search results randomly with a given seed.
 // This is useful for repeatable random queries
 func (d *DocumentQueryCustomization) RandomOrderingWithSeed(seed string) {
-	d.query.randomOrderingWithSeed(seed)
+	if d.query.err != nil {
+		return
+	}
+	d.query.err = d.query.randomOrderingWithSeed(seed)
 }
 
 // WaitForNonStaleResults instructs the query to wait for non results.
@@ -72,3 +78,25 @@ func (d *DocumentQueryCustomization) RandomOrderingWithSeed(seed string) {
 func (d *DocumentQueryCustomization) WaitForNonStaleResults(waitTimeout time.Duration) {
 	d.query.waitForNonStaleResults(waitTimeout)
 }
+
+// ProjectionBehavior sets where values of projected fields are taken from
+func (d *DocumentQueryCustomization) ProjectionBehavior(behavior ProjectionBehavior) {
+	if d.query.err != nil {
+		return
+	}
+	d.query.err = d.query.setProjectionBehavior(behavior)
+}
+
+// SkipDuplicateChecking tells the server to not remove duplicate results
+// from the query. This is faster but for queries on fanout indexes
+// the same document might be returned more than once
+func (d *DocumentQueryCustomization) SkipDuplicateChecking() {
+	d.query.skipDuplicateChecking = true
+}
+
+// DisableAutoIndexCreation prevents the server from creating an auto index
+// for this dynamic query. If no existing index can answer it, the query
+// fails with IndexDoesNotExistError
+func (d *DocumentQueryCustomization) DisableAutoIndexCreation(disable bool) {
+	d.query.disableAutoIndexCreation = disable
+}
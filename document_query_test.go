@@ -0,0 +1,1199 @@
+package ravendb
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newQueryTestSession returns a session that is never connected to a server.
+// It's good enough for building queries
+func newQueryTestSession() *DocumentSession {
+	re := NewRequestExecutor("test", nil, nil, nil, nil)
+	return NewDocumentSession("test", nil, "", re)
+}
+
+func queryString(t *testing.T, q *DocumentQuery) (string, Parameters) {
+	iq, err := q.GetIndexQuery()
+	require.NoError(t, err)
+	return iq.GetQuery(), iq.GetQueryParameters()
+}
+
+func TestDocumentQueryWhereID(t *testing.T) {
+	session := newQueryTestSession()
+
+	q := session.QueryCollection("Users").WhereIDEquals("users/1")
+	rql, params := queryString(t, q)
+	assert.Equal(t, "from Users where id() = $p0", rql)
+	assert.Equal(t, "users/1", params["p0"])
+
+	q = session.QueryCollection("Users").WhereEquals("name", "John").WhereIDIn([]string{"users/1", "users/2"})
+	rql, params = queryString(t, q)
+	assert.Equal(t, "from Users where name = $p0 and id() in ($p1)", rql)
+	assert.Equal(t, []interface{}{"users/1", "users/2"}, params["p1"])
+}
+
+func TestDocumentQueryContainsAnyAll(t *testing.T) {
+	session := newQueryTestSession()
+
+	tests := []struct {
+		values   []interface{}
+		anyRQL   string
+		allRQL   string
+		expParam []interface{}
+	}{
+		{nil, "from Users where (true and not true) and name = $p0", "from Users where true and name = $p0", nil},
+		{[]interface{}{}, "from Users where (true and not true) and name = $p0", "from Users where true and name = $p0", nil},
+		{[]interface{}{"a"}, "from Users where tags in ($p0) and name = $p1", "from Users where tags all in ($p0) and name = $p1", []interface{}{"a"}},
+		{[]interface{}{"a", []interface{}{"b", []interface{}{"c"}}}, "from Users where tags in ($p0) and name = $p1", "from Users where tags all in ($p0) and name = $p1", []interface{}{"a", "b", "c"}},
+	}
+	for _, test := range tests {
+		q := session.QueryCollection("Users").ContainsAny("tags", test.values).WhereEquals("name", "John")
+		rql, params := queryString(t, q)
+		assert.Equal(t, test.anyRQL, rql)
+		if test.expParam != nil {
+			assert.Equal(t, test.expParam, params["p0"])
+		}
+
+		q = session.QueryCollection("Users").ContainsAll("tags", test.values).WhereEquals("name", "John")
+		rql, params = queryString(t, q)
+		assert.Equal(t, test.allRQL, rql)
+		if test.expParam != nil {
+			assert.Equal(t, test.expParam, params["p0"])
+		}
+	}
+}
+
+func TestDocumentQueryWhereBetweenOpenEnded(t *testing.T) {
+	session := newQueryTestSession()
+	var nilInt *int
+	age := 65
+
+	tests := []struct {
+		start    interface{}
+		end      interface{}
+		expStart interface{}
+		expEnd   interface{}
+	}{
+		{18, 65, 18, 65},
+		{nil, 65, "*", 65},
+		{18, nil, 18, "NULL"},
+		{nil, nil, "*", "NULL"},
+		{"a", nil, "a", "NULL"},
+		{nilInt, &age, "*", &age},
+		{18, nilInt, 18, "NULL"},
+	}
+	for _, test := range tests {
+		q := session.QueryCollection("Users").WhereBetween("age", test.start, test.end)
+		rql, params := queryString(t, q)
+		assert.Equal(t, "from Users where age between $p0 and $p1", rql)
+		assert.Equal(t, test.expStart, params["p0"], "test: %#v", test)
+		assert.Equal(t, test.expEnd, params["p1"], "test: %#v", test)
+	}
+
+	q := session.QueryCollection("Users").WhereGreaterThan("age", nilInt)
+	_, params := queryString(t, q)
+	assert.Equal(t, "*", params["p0"])
+}
+
+func TestDocumentQueryBetween(t *testing.T) {
+	session := newQueryTestSession()
+	var nilInt *int
+	from := 18
+	to := 65
+	var nilString *string
+	name := "b"
+
+	tests := []struct {
+		from     interface{}
+		to       interface{}
+		expStart interface{}
+		expEnd   interface{}
+	}{
+		{18, 65, 18, 65},
+		{nil, 65, "*", 65},
+		{18, nil, 18, "NULL"},
+		{nil, nil, "*", "NULL"},
+		{nilInt, &to, "*", 65},
+		{&from, nilInt, 18, "NULL"},
+		{nilInt, nilInt, "*", "NULL"},
+		{&from, 65, 18, 65},
+		{"a", nilString, "a", "NULL"},
+		{nilString, &name, "*", "b"},
+	}
+	for _, test := range tests {
+		q := session.QueryCollection("Users").Between("age", test.from, test.to)
+		rql, params := queryString(t, q)
+		assert.Equal(t, "from Users where age between $p0 and $p1", rql)
+		assert.Equal(t, test.expStart, params["p0"], "test: %#v", test)
+		assert.Equal(t, test.expEnd, params["p1"], "test: %#v", test)
+	}
+
+	q := session.QueryCollection("Users").Between("age", 18, "65")
+	_, ok := q.Err().(*IllegalArgumentError)
+	assert.True(t, ok, "err: %v", q.Err())
+}
+
+// rqlQuotesBalanced returns true if every quoted string in s is terminated
+func rqlQuotesBalanced(s string) bool {
+	var quote rune
+	escaped := false
+	for _, c := range s {
+		if escaped {
+			escaped = false
+			continue
+		}
+		switch {
+		case c == '\\' && quote != 0:
+			escaped = true
+		case quote == 0 && (c == '\'' || c == '"'):
+			quote = c
+		case c == quote:
+			quote = 0
+		}
+	}
+	return quote == 0 && !escaped
+}
+
+func TestDocumentQueryHostileFieldNames(t *testing.T) {
+	session := newQueryTestSession()
+
+	tests := []struct {
+		name string
+		exp  string // "" means we expect an error
+	}{
+		{"name", "name"},
+		{"Address.City", "Address.City"},
+		{"Tags[].Name", "Tags[].Name"},
+		{"@metadata.@collection", "@metadata.@collection"},
+		{"Address.'Zip Code'", "Address.'Zip Code'"},
+		{"first name", "'first name'"},
+		{"a' or true or 'b", `'a\' or true or \'b'`},
+		{"'a' = 'a' or 'b'", `'\'a\' = \'a\' or \'b\''`},
+		{`'a\' or true`, `'\'a\\\' or true'`},
+		{"name = 1 or 1", "'name = 1 or 1'"},
+		{"a\"b", `'a"b'`},
+		{"'unterminated", `'\'unterminated'`},
+		{"1abc", "'1abc'"},
+		{"name\nor true", ""},
+		{"name\r", ""},
+		{"name // comment", ""},
+		{"name /* comment", ""},
+		{"name */", ""},
+	}
+
+	for _, test := range tests {
+		q := session.QueryCollection("Users").WhereEquals(test.name, "x")
+		iq, err := q.GetIndexQuery()
+		if test.exp == "" {
+			assert.Error(t, err, "name: %q", test.name)
+			continue
+		}
+		assert.NoError(t, err, "name: %q", test.name)
+		assert.Equal(t, "from Users where "+test.exp+" = $p0", iq.GetQuery())
+		assert.True(t, rqlQuotesBalanced(iq.GetQuery()), "rql: %s", iq.GetQuery())
+
+		q = session.QueryCollection("Users").OrderBy(test.name)
+		iq, err = q.GetIndexQuery()
+		assert.NoError(t, err)
+		assert.Equal(t, "from Users order by "+test.exp, iq.GetQuery())
+
+		q = session.QueryCollection("Users").GroupBy(test.name).SelectKey().SelectCount()
+		iq, err = q.GetIndexQuery()
+		assert.NoError(t, err)
+		assert.True(t, rqlQuotesBalanced(iq.GetQuery()), "rql: %s", iq.GetQuery())
+	}
+
+	// every combination of hostile fragments must either be rejected
+	// or produce RQL where the field name can't terminate a quoted string early
+	fragments := []string{"a", " ", "'", "\"", "\\", ".", "/", "*", "=", "\n", "or", "[", "]"}
+	for _, f1 := range fragments {
+		for _, f2 := range fragments {
+			for _, f3 := range fragments {
+				name := f1 + f2 + f3
+				q := session.QueryCollection("Users").WhereEquals(name, "x").OrderBy(name)
+				iq, err := q.GetIndexQuery()
+				if err != nil {
+					continue
+				}
+				assert.True(t, rqlQuotesBalanced(iq.GetQuery()), "name: %q, rql: %s", name, iq.GetQuery())
+			}
+		}
+	}
+}
+
+func TestDocumentQueryHostileInclude(t *testing.T) {
+	session := newQueryTestSession()
+
+	q := session.QueryCollection("Users").Include(`a' b\`)
+	rql, _ := queryString(t, q)
+	assert.Equal(t, `from Users include 'a\' b\\'`, rql)
+
+	q = session.QueryCollection("Users").Include("a\nb")
+	_, err := q.GetIndexQuery()
+	assert.Error(t, err)
+}
+
+func TestDocumentQueryOrderByWithSorter(t *testing.T) {
+	session := newQueryTestSession()
+
+	q := session.QueryCollection("Files").OrderByWithOrdering("name", OrderingTypeAlphaNumeric)
+	rql, _ := queryString(t, q)
+	assert.Equal(t, "from Files order by name as alphaNumeric", rql)
+
+	q = session.QueryCollection("Files").OrderByWithSorter("name", "MySorter").OrderByDescendingWithSorter("size", "My'Sorter")
+	rql, _ = queryString(t, q)
+	assert.Equal(t, `from Files order by custom(name, 'MySorter'), custom(size, 'My\'Sorter') desc`, rql)
+
+	q = session.QueryCollection("Files").OrderByWithSorter("name", "")
+	_, err := q.GetIndexQuery()
+	assert.Error(t, err)
+}
+
+func TestDocumentQuerySelectJS(t *testing.T) {
+	session := newQueryTestSession()
+	type fullName struct {
+		FullName string
+	}
+	fullNameType := reflect.TypeOf(&fullName{})
+
+	q := session.QueryCollection("Users").WhereEquals("age", 3).SelectJS(fullNameType, `{ FullName: x.firstName + " " + x.lastName }`)
+	rql, _ := queryString(t, q)
+	assert.Equal(t, `from Users as x where age = $p0 select { FullName: x.firstName + " " + x.lastName }`, rql)
+
+	q = session.QueryCollection("Users").SelectJS(fullNameType, "{ FullName: fullName(x) }", `function fullName(u) { return u.firstName + " " + u.lastName; }`)
+	rql, _ = queryString(t, q)
+	assert.Equal(t, "declare function fullName(u) {\nreturn u.firstName + \" \" + u.lastName;\n}\nfrom Users as x select { FullName: fullName(x) }", rql)
+
+	invalid := []string{"", "fullName(u) { }", "function (u) { }", "function fullName(u) { } x", "function a.b(u) { }"}
+	for _, fn := range invalid {
+		q = session.QueryCollection("Users").SelectJS(fullNameType, "{ FullName: x.firstName }", fn)
+		_, err := q.GetIndexQuery()
+		assert.Error(t, err, "fn: %q", fn)
+	}
+}
+
+func TestDocumentQueryProjectUsing(t *testing.T) {
+	session := newQueryTestSession()
+	type fullName struct {
+		FullName string
+	}
+	fullNameType := reflect.TypeOf(&fullName{})
+
+	q := session.QueryCollection("Users").WhereEquals("age", 3).ProjectUsing(fullNameType, `return { FullName: doc.firstName + ' ' + doc.lastName };`)
+	rql, _ := queryString(t, q)
+	assert.Equal(t, "declare function customProjection(doc) {\nreturn { FullName: doc.firstName + ' ' + doc.lastName };\n}\nfrom Users as x where age = $p0 select customProjection(x)", rql)
+
+	q = session.QueryCollection("Users").ProjectUsing(fullNameType, " ")
+	_, err := q.GetIndexQuery()
+	assert.Error(t, err)
+}
+
+func TestDocumentQuerySelectWithLoad(t *testing.T) {
+	session := newQueryTestSession()
+	type companyInfo struct {
+		Name  string
+		Phone int
+	}
+
+	q := session.QueryCollection("Orders").WhereGreaterThan("freight", 10).SelectWithLoad(reflect.TypeOf(""), "company", "c", "c.Name")
+	rql, _ := queryString(t, q)
+	assert.Equal(t, "from Orders as x where freight > $p0 load x.company as c select c.Name", rql)
+
+	q = session.QueryCollection("Orders").SelectWithLoad(reflect.TypeOf(&companyInfo{}), "company", "c")
+	rql, _ = queryString(t, q)
+	assert.Equal(t, "from Orders as x load x.company as c select c.Name as Name, c.Phone as Phone", rql)
+
+	q = session.QueryCollection("Orders").SelectWithLoad(reflect.TypeOf(""), "ship to", "c", "c.Name")
+	rql, _ = queryString(t, q)
+	assert.Equal(t, "from Orders as x load x.'ship to' as c select c.Name", rql)
+
+	q = session.QueryCollection("Orders").SelectWithLoad(reflect.TypeOf(""), "x.ship to", "c", "c.Name")
+	rql, _ = queryString(t, q)
+	assert.Equal(t, "from Orders as x load x.'ship to' as c select c.Name", rql)
+
+	q = session.QueryCollection("Orders").SelectWithLoad(reflect.TypeOf(""), "x.company", "c", "c.Name")
+	rql, _ = queryString(t, q)
+	assert.Equal(t, "from Orders as x load x.company as c select c.Name", rql)
+
+	for _, alias := range []string{"", "x", "c.d", "select", "c d"} {
+		q = session.QueryCollection("Orders").SelectWithLoad(reflect.TypeOf(""), "company", alias, "c.Name")
+		_, err := q.GetIndexQuery()
+		assert.Error(t, err, "alias: %q", alias)
+	}
+}
+
+func TestDocumentQueryNegatedSubclause(t *testing.T) {
+	session := newQueryTestSession()
+
+	// at the start of the query
+	q := session.QueryCollection("Users").WhereNot(func(q *DocumentQuery) {
+		q.WhereEquals("name", "John").OrElse().WhereEquals("name", "Tarzan")
+	})
+	rql, params := queryString(t, q)
+	assert.Equal(t, "from Users where true and not (name = $p0 or name = $p1)", rql)
+	assert.Equal(t, "John", params["p0"])
+	assert.Equal(t, "Tarzan", params["p1"])
+
+	// same using Not() and explicit sub-clause
+	q = session.QueryCollection("Users").Not().OpenSubclause().
+		WhereEquals("name", "John").OrElse().WhereEquals("name", "Tarzan").
+		CloseSubclause()
+	rql, _ = queryString(t, q)
+	assert.Equal(t, "from Users where true and not (name = $p0 or name = $p1)", rql)
+
+	// after AND
+	q = session.QueryCollection("Users").WhereEquals("age", 3).AndAlso().WhereNot(func(q *DocumentQuery) {
+		q.WhereEquals("name", "John").OrElse().WhereEquals("name", "Tarzan")
+	})
+	rql, params = queryString(t, q)
+	assert.Equal(t, "from Users where age = $p0 and not (name = $p1 or name = $p2)", rql)
+	assert.Equal(t, 3, params["p0"])
+
+	// nested
+	q = session.QueryCollection("Users").WhereEquals("age", 3).WhereNot(func(q *DocumentQuery) {
+		q.WhereNot(func(q *DocumentQuery) {
+			q.WhereEquals("name", "John").OrElse().WhereEquals("name", "Tarzan")
+		}).WhereEquals("lastName", "Doe")
+	})
+	rql, _ = queryString(t, q)
+	assert.Equal(t, "from Users where age = $p0 and not (true and not (name = $p1 or name = $p2) and lastName = $p3)", rql)
+
+	// a negated field at the start of a sub-clause
+	q = session.QueryCollection("Users").OpenSubclause().Not().WhereStartsWith("name", "J").CloseSubclause()
+	rql, _ = queryString(t, q)
+	assert.Equal(t, "from Users where (exists(name) and not startsWith(name, $p0))", rql)
+
+	// errors from the builder are returned
+	q = session.QueryCollection("Users").WhereNot(func(q *DocumentQuery) {
+		q.Where("name", "~", "John")
+	})
+	_, err := q.GetIndexQuery()
+	assert.Error(t, err)
+}
+
+func TestDocumentQueryWrapPrevious(t *testing.T) {
+	session := newQueryTestSession()
+
+	q := session.QueryCollection("Users").
+		WhereEquals("name", "John").OrElse().WhereEquals("name", "Tarzan").
+		AndAlsoWrapPrevious().WhereEquals("age", 3)
+	rql, params := queryString(t, q)
+	assert.Equal(t, "from Users where (name = $p0 or name = $p1) and age = $p2", rql)
+	assert.Equal(t, 3, params["p2"])
+
+	q = session.QueryCollection("Users").
+		WhereEquals("name", "John").WhereEquals("age", 3).
+		OrElseWrapPrevious().WhereEquals("name", "Tarzan")
+	rql, _ = queryString(t, q)
+	assert.Equal(t, "from Users where (name = $p0 and age = $p1) or name = $p2", rql)
+
+	// only the innermost open sub-clause is wrapped
+	q = session.QueryCollection("Users").
+		WhereEquals("age", 3).
+		OpenSubclause().
+		WhereEquals("name", "John").OrElse().WhereEquals("name", "Tarzan").
+		AndAlsoWrapPrevious().WhereExists("lastName").
+		CloseSubclause()
+	rql, _ = queryString(t, q)
+	assert.Equal(t, "from Users where age = $p0 and ((name = $p1 or name = $p2) and exists(lastName))", rql)
+
+	// closed sub-clauses are wrapped together with the rest
+	q = session.QueryCollection("Users").
+		OpenSubclause().WhereEquals("age", 3).CloseSubclause().
+		OrElse().WhereEquals("name", "John").
+		AndAlsoWrapPrevious().WhereExists("lastName")
+	rql, _ = queryString(t, q)
+	assert.Equal(t, "from Users where ((age = $p0) or name = $p1) and exists(lastName)", rql)
+
+	// no-op on empty query
+	q = session.QueryCollection("Users").AndAlsoWrapPrevious().WhereEquals("age", 3)
+	rql, _ = queryString(t, q)
+	assert.Equal(t, "from Users where age = $p0", rql)
+
+	q = session.QueryCollection("Users").WhereEquals("age", 3).OrElse().AndAlsoWrapPrevious()
+	_, err := q.GetIndexQuery()
+	assert.Error(t, err)
+}
+
+func TestDocumentQueryDefaultOperator(t *testing.T) {
+	session := newQueryTestSession()
+
+	q := session.QueryCollection("Users").UsingDefaultOperator(QueryOperatorOr).
+		WhereEquals("name", "John").WhereEquals("name", "Tarzan")
+	rql, _ := queryString(t, q)
+	assert.Equal(t, "from Users where name = $p0 or name = $p1", rql)
+
+	// applies only to the sub-clause
+	q = session.QueryCollection("Users").
+		WhereEquals("age", 3).
+		OpenSubclause().UsingDefaultOperator(QueryOperatorOr).
+		WhereEquals("name", "John").WhereEquals("name", "Tarzan").
+		CloseSubclause().
+		WhereExists("lastName")
+	rql, _ = queryString(t, q)
+	assert.Equal(t, "from Users where age = $p0 and (name = $p1 or name = $p2) and exists(lastName)", rql)
+
+	// nested
+	q = session.QueryCollection("Users").UsingDefaultOperator(QueryOperatorOr).
+		WhereEquals("age", 3).
+		OpenSubclause().UsingDefaultOperator(QueryOperatorAnd).
+		WhereEquals("name", "John").
+		OpenSubclause().WhereEquals("age", 4).WhereEquals("age", 5).CloseSubclause().
+		CloseSubclause().
+		WhereEquals("age", 6)
+	rql, _ = queryString(t, q)
+	assert.Equal(t, "from Users where age = $p0 or (name = $p1 and (age = $p2 and age = $p3)) or age = $p4", rql)
+
+	q = session.QueryCollection("Users").WhereEquals("age", 3).UsingDefaultOperator(QueryOperatorOr)
+	_, err := q.GetIndexQuery()
+	_, ok := err.(*IllegalStateError)
+	assert.True(t, ok)
+
+	q = session.QueryCollection("Users").UsingDefaultOperator("Xor")
+	_, err = q.GetIndexQuery()
+	_, ok = err.(*IllegalArgumentError)
+	assert.True(t, ok)
+}
+
+func TestDocumentQueryRandomOrdering(t *testing.T) {
+	session := newQueryTestSession()
+
+	q := session.QueryCollection("Users").RandomOrdering()
+	rql, _ := queryString(t, q)
+	assert.Equal(t, "from Users order by random()", rql)
+	iq, err := q.GetIndexQuery()
+	require.NoError(t, err)
+	assert.True(t, iq.disableCaching)
+
+	q = session.QueryCollection("Users").RandomOrderingWithSeed("bucket-a")
+	rql, _ = queryString(t, q)
+	assert.Equal(t, "from Users order by random('bucket-a')", rql)
+
+	// blank seed falls back to unseeded random ordering
+	q = session.QueryCollection("Users").RandomOrderingWithSeed(" ")
+	rql, _ = queryString(t, q)
+	assert.Equal(t, "from Users order by random()", rql)
+}
+
+func TestDocumentQueryStaticIndex(t *testing.T) {
+	session := newQueryTestSession()
+
+	q := session.QueryIndex("UsersByName")
+	assert.False(t, q.fromToken.isDynamic)
+	rql, _ := queryString(t, q.WhereEquals("name", "John"))
+	assert.Equal(t, "from index 'UsersByName' where name = $p0", rql)
+
+	f := NewFacetBuilder()
+	f.ByField("name")
+	iq, err := session.QueryIndex("UsersByName").AggregateByFacet(f.GetFacet()).GetIndexQuery()
+	require.NoError(t, err)
+	assert.Equal(t, "from index 'UsersByName' select facet(name)", iq.GetQuery())
+
+	s := NewSuggestionBuilder().ByField("name", "Jon")
+	iq, err = session.QueryIndex("UsersByName").SuggestUsing(s.GetSuggestion()).getIndexQuery()
+	require.NoError(t, err)
+	assert.Equal(t, "from index 'UsersByName' select suggest(name, $p0)", iq.GetQuery())
+
+	// dynamic spatial fields can't be used with static indexes
+	criteria := func(f *SpatialCriteriaFactory) SpatialCriteria {
+		return f.WithinRadius(10, 10, 20)
+	}
+	q = session.QueryIndex("Spatial").Spatial2(NewPointField("lat", "lng"), criteria)
+	_, err = q.GetIndexQuery()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Spatial2")
+	assert.Contains(t, err.Error(), "use Spatial3")
+
+	q = session.QueryIndex("Spatial").OrderByDistanceLatLongDynamic(NewPointField("lat", "lng"), 10, 20)
+	_, err = q.GetIndexQuery()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "use OrderByDistanceLatLong ")
+
+	// index fields work
+	q = session.QueryIndex("Spatial").Spatial3("coordinates", criteria).OrderByDistanceLatLong("coordinates", 10, 20)
+	rql, _ = queryString(t, q)
+	assert.Equal(t, "from index 'Spatial' where spatial.within(coordinates, spatial.circle($p0, $p1, $p2)) order by spatial.distance(coordinates, spatial.point($p3, $p4))", rql)
+}
+
+func TestDocumentQueryCustomizeIndexQueryOptions(t *testing.T) {
+	session := newQueryTestSession()
+
+	q := session.QueryCollection("Users").Customize(func(c *DocumentQueryCustomization) {
+		c.ProjectionBehavior(ProjectionBehaviorFromDocument)
+		c.SkipDuplicateChecking()
+		c.DisableAutoIndexCreation(true)
+	})
+	iq, err := q.GetIndexQuery()
+	require.NoError(t, err)
+	js := jsonExtensionsWriteIndexQuery(session.GetConventions(), iq)
+	assert.Equal(t, "FromDocument", js["ProjectionBehavior"])
+	assert.Equal(t, true, js["SkipDuplicateChecking"])
+	assert.Equal(t, true, js["DisableAutoIndexCreation"])
+
+	// options are not sent when not set
+	iq, err = session.QueryCollection("Users").GetIndexQuery()
+	require.NoError(t, err)
+	js = jsonExtensionsWriteIndexQuery(session.GetConventions(), iq)
+	for _, name := range []string{"ProjectionBehavior", "SkipDuplicateChecking", "DisableAutoIndexCreation"} {
+		_, ok := js[name]
+		assert.False(t, ok, name)
+	}
+
+	q = session.QueryCollection("Users").Customize(func(c *DocumentQueryCustomization) {
+		c.ProjectionBehavior("FromNowhere")
+	})
+	_, err = q.GetIndexQuery()
+	_, ok := err.(*IllegalArgumentError)
+	assert.True(t, ok)
+}
+
+func TestDocumentQueryDisableAutoIndexCreationConvention(t *testing.T) {
+	session := newQueryTestSession()
+	session.GetConventions().DisableAutoIndexCreation = true
+
+	iq, err := session.QueryCollection("Users").GetIndexQuery()
+	require.NoError(t, err)
+	assert.True(t, iq.disableAutoIndexCreation)
+
+	// can be overridden per query
+	q := session.QueryCollection("Users").Customize(func(c *DocumentQueryCustomization) {
+		c.DisableAutoIndexCreation(false)
+	})
+	iq, err = q.GetIndexQuery()
+	require.NoError(t, err)
+	assert.False(t, iq.disableAutoIndexCreation)
+}
+
+func TestDocumentQueryWhereEqualsNested(t *testing.T) {
+	session := newQueryTestSession()
+
+	q := session.QueryCollection("Users").WhereEqualsNested("Address.ZipCode", 98052)
+	rql, params := queryString(t, q)
+	assert.Equal(t, "from Users where Address.ZipCode = $p0", rql)
+	assert.Equal(t, 98052, params["p0"])
+
+	// nested paths are not treated as document id
+	q = session.QueryCollection("Users").WhereEqualsNested("ID", "users/1")
+	rql, _ = queryString(t, q)
+	assert.Equal(t, "from Users where ID = $p0", rql)
+
+	q = session.QueryCollection("Users").WhereEqualsNested("Address.'Zip Code'", 1)
+	rql, _ = queryString(t, q)
+	assert.Equal(t, "from Users where Address.'Zip Code' = $p0", rql)
+}
+
+func TestDocumentQueryBeforeQueryCustomization(t *testing.T) {
+	session := newQueryTestSession()
+
+	var queries []*IndexQuery
+	session.AddBeforeQueryListener(func(event *BeforeQueryEventArgs) {
+		c := event.QueryCustomization
+		c.WaitForNonStaleResults(0)
+		c.NoCaching()
+		c.NoTracking()
+		c.AddBeforeQueryExecutedListener(func(iq *IndexQuery) {
+			queries = append(queries, iq)
+		})
+	})
+
+	q := session.QueryCollection("Users").WhereEquals("name", "John")
+	op, err := q.initializeQueryOperation()
+	require.NoError(t, err)
+
+	// the customization is applied before the index query is generated
+	assert.True(t, op.indexQuery.waitForNonStaleResults)
+	assert.True(t, op.indexQuery.disableCaching)
+	assert.True(t, op.disableEntitiesTracking)
+	require.Equal(t, 1, len(queries))
+	assert.Equal(t, op.indexQuery, queries[0])
+}
+
+func TestDocumentQueryIntersect(t *testing.T) {
+	session := newQueryTestSession()
+
+	q := session.QueryIndex("Products").WhereExists("name").Intersect().WhereEquals("category", "food")
+	rql, params := queryString(t, q)
+	assert.Equal(t, "from index 'Products' where intersect(exists(name), category = $p0)", rql)
+	assert.Equal(t, "food", params["p0"])
+
+	q = session.QueryIndex("Products").WhereEquals("name", "Bar").Intersect().Search("description", "Hello").Intersect().WhereExists("category")
+	rql, _ = queryString(t, q)
+	assert.Equal(t, "from index 'Products' where intersect(name = $p0, search(description, $p1), exists(category))", rql)
+
+	q = session.QueryIndex("Products").WhereExists("name").Intersect().WhereEquals("category", "food").OrderBy("name")
+	rql, _ = queryString(t, q)
+	assert.Equal(t, "from index 'Products' where intersect(exists(name), category = $p0) order by name", rql)
+
+	// must follow a where clause
+	q = session.QueryIndex("Products").Intersect()
+	_, err := q.GetIndexQuery()
+	_, ok := err.(*IllegalStateError)
+	assert.True(t, ok, "expected *IllegalStateError, got %T (%v)", err, err)
+
+	q = session.QueryIndex("Products").WhereEquals("name", "Bar").Intersect().Intersect()
+	_, err = q.GetIndexQuery()
+	assert.Error(t, err)
+}
+
+func TestDocumentQueryIncludeCountersAndCompareExchangeValues(t *testing.T) {
+	session := newQueryTestSession()
+
+	q := session.QueryCollection("Users").Include("Friend").IncludeCounters("likes", "dislikes", "likes").IncludeCompareExchangeValue("Username")
+	rql, params := queryString(t, q)
+	assert.Equal(t, "from Users include Friend,counters('dislikes'),counters('likes'),cmpxchg('Username')", rql)
+	assert.Empty(t, params)
+
+	q = session.QueryCollection("Users").IncludeCounters("it's")
+	rql, _ = queryString(t, q)
+	assert.Equal(t, `from Users include counters('it\'s')`, rql)
+
+	q = session.QueryCollection("Users").IncludeCompareExchangeValue("Username")
+	rql, _ = queryString(t, q)
+	assert.Equal(t, "from Users include cmpxchg('Username')", rql)
+
+	q = session.QueryCollection("Users").IncludeCounters("a/*b")
+	_, err := q.GetIndexQuery()
+	assert.Error(t, err)
+}
+
+const queryWithCountersAndCompareExchangeJSON = `{
+	"Results": [{"Name": "John", "@metadata": {"@id": "users/1", "@collection": "Users", "@change-vector": "A:1"}}],
+	"Includes": {},
+	"CounterIncludes": {"users/1": [{"DocumentId": "users/1", "CounterName": "likes", "TotalValue": 3}]},
+	"CompareExchangeValueIncludes": {"usernames/john": {"Key": "usernames/john", "Index": 5, "Value": {"Object": "users/1"}}},
+	"IndexName": "Auto/Users",
+	"TotalResults": 1
+}`
+
+func TestDocumentQueryIncludesAreRegisteredInSession(t *testing.T) {
+	var nRequests int32
+	fn := func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&nRequests, 1)
+		if r.URL.Path != "/databases/db/queries" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(queryWithCountersAndCompareExchangeJSON))
+	}
+	store := newFakeServerStore(t, fn)
+	session, err := store.OpenSessionWithOptions(&SessionOptions{
+		TransactionMode: TransactionModeClusterWide,
+	})
+	require.NoError(t, err)
+	defer session.Close()
+
+	var users []*User
+	q := session.QueryCollectionForType(reflect.TypeOf(&User{})).IncludeCounters("likes").IncludeCompareExchangeValue("Name")
+	err = q.GetResults(&users)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(users))
+
+	counters, err := session.Advanced().GetIncludedCountersFor(users[0])
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int64{"likes": 3}, counters)
+
+	v, err := session.Advanced().ClusterTransaction().GetCompareExchangeValue(reflect.TypeOf(""), "usernames/john")
+	require.NoError(t, err)
+	require.NotNil(t, v)
+	assert.Equal(t, int64(5), v.Index)
+	assert.Equal(t, "users/1", v.Value)
+
+	assert.Equal(t, 1, session.GetNumberOfRequests())
+	assert.Equal(t, int32(1), atomic.LoadInt32(&nRequests))
+}
+
+func TestDocumentQueryFromIndexAndCollection(t *testing.T) {
+	session := newQueryTestSession()
+
+	q := session.Query(nil).FromIndex("Orders/ByCompany").WhereEquals("company", "ACME")
+	rql, params := queryString(t, q)
+	assert.Equal(t, "from index 'Orders/ByCompany' where company = $p0", rql)
+	assert.Equal(t, "ACME", params["p0"])
+	assert.Equal(t, "Orders/ByCompany", q.indexName)
+
+	q = session.QueryIndex("Orders/ByCompany").FromCollection("Orders").WhereEquals("company", "ACME")
+	rql, _ = queryString(t, q)
+	assert.Equal(t, "from Orders where company = $p0", rql)
+	assert.Equal(t, "", q.indexName)
+
+	// without a type and an explicit origin the query is over all documents
+	rql, _ = queryString(t, session.Query(nil))
+	assert.Equal(t, "from @all_docs", rql)
+
+	q = session.Query(nil).FromIndex(" ")
+	_, err := q.GetIndexQuery()
+	assert.Error(t, err)
+
+	q = session.Query(nil).FromCollection("")
+	_, err = q.GetIndexQuery()
+	assert.Error(t, err)
+
+	// must come before other clauses
+	q = session.QueryCollection("Orders").WhereEquals("company", "ACME").FromIndex("Orders/ByCompany")
+	_, err = q.GetIndexQuery()
+	_, ok := err.(*IllegalStateError)
+	assert.True(t, ok)
+}
+
+func TestDocumentQueryHaving(t *testing.T) {
+	session := newQueryTestSession()
+
+	q := session.QueryCollection("Users").GroupBy("country").SelectKey().SelectCount().
+		HavingGreaterThan("count", 2).
+		HavingLessThanOrEqual("count", 10)
+	rql, params := queryString(t, q)
+	assert.Equal(t, "from Users group by country where count > $p0 and count <= $p1 select key(), count() as count", rql)
+	assert.Equal(t, 2, params["p0"])
+	assert.Equal(t, 10, params["p1"])
+
+	q = session.QueryCollection("Users").GroupBy("country").SelectKey().SelectCount().
+		WhereHaving("country", "Poland")
+	rql, _ = queryString(t, q)
+	assert.Equal(t, "from Users group by country where country = $p0 select key(), count() as count", rql)
+
+	q = session.QueryCollection("Users").HavingGreaterThan("count", 2)
+	_, err := q.GetIndexQuery()
+	_, ok := err.(*IllegalStateError)
+	assert.True(t, ok, "expected *IllegalStateError, got %T (%v)", err, err)
+}
+
+type testAnimal interface {
+	Sound() string
+}
+
+type testDog struct {
+	ID   string
+	Name string
+}
+
+func (d *testDog) Sound() string {
+	return d.Name + " barks"
+}
+
+type testCat struct {
+	ID    string
+	Name  string
+	Lives int
+}
+
+func (c *testCat) Sound() string {
+	return c.Name + " meows"
+}
+
+func TestDocumentQueryForInterface(t *testing.T) {
+	session := newQueryTestSession()
+	animalType := reflect.TypeOf((*testAnimal)(nil)).Elem()
+
+	// not an error until the query is executed without index or collection
+	q := session.QueryCollectionForType(animalType)
+	assert.NoError(t, q.Err())
+	_, err := q.GetIndexQuery()
+	assert.Equal(t, ErrCannotInferCollectionForInterface, err)
+
+	q = session.QueryCollectionForType(animalType).WhereEquals("Name", "Rex")
+	_, err = q.GetIndexQuery()
+	assert.Equal(t, ErrCannotInferCollectionForInterface, err)
+
+	q = session.QueryCollectionForType(animalType).FromIndex("Animals/ByName")
+	rql, _ := queryString(t, q)
+	assert.Equal(t, "from index 'Animals/ByName'", rql)
+
+	q = session.QueryCollectionForType(animalType).FromCollection("Animals").WhereEquals("Name", "Rex")
+	rql, _ = queryString(t, q)
+	assert.Equal(t, "from Animals where Name = $p0", rql)
+
+	q = session.QueryInterface((*testAnimal)(nil), "Animals", false)
+	rql, _ = queryString(t, q)
+	assert.Equal(t, "from Animals", rql)
+
+	q = session.QueryInterface((*testAnimal)(nil), "Animals/ByName", true)
+	rql, _ = queryString(t, q)
+	assert.Equal(t, "from index 'Animals/ByName'", rql)
+
+	q = session.QueryInterface(&testDog{}, "Animals", false)
+	_, err = q.GetIndexQuery()
+	assert.Error(t, err)
+}
+
+func TestDocumentQueryDeserializesInterfaceResults(t *testing.T) {
+	fn := func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"Results": [
+			{"Name": "Rex", "@metadata": {"@id": "animals/1", "@collection": "Animals", "@change-vector": "A:1", "Raven-Go-Type": "ravendb.testDog"}},
+			{"Name": "Tom", "Lives": 9, "@metadata": {"@id": "animals/2", "@collection": "Animals", "@change-vector": "A:2", "Raven-Go-Type": "ravendb.testCat"}}
+		], "Includes": {}, "IndexName": "Animals", "TotalResults": 2}`))
+	}
+	srv := httptest.NewServer(http.HandlerFunc(fn))
+	defer srv.Close()
+
+	// concrete types must be registered
+	store := newTestStore(t, srv.URL)
+	defer store.Close()
+	session, err := store.OpenSession("")
+	require.NoError(t, err)
+	defer session.Close()
+	var results []testAnimal
+	err = session.QueryInterface((*testAnimal)(nil), "Animals", false).GetResults(&results)
+	assert.Error(t, err)
+
+	// like other conventions, types must be registered before the store
+	// is used
+	store2 := NewDocumentStore([]string{srv.URL}, "db")
+	store2.GetConventions().SetDisableTopologyUpdates(true)
+	store2.GetConventions().RegisterEntityType(&testDog{})
+	store2.GetConventions().RegisterEntityType(&testCat{})
+	require.NoError(t, store2.Initialize())
+	defer store2.Close()
+	session2, err := store2.OpenSession("")
+	require.NoError(t, err)
+	defer session2.Close()
+
+	results = nil
+	err = session2.QueryInterface((*testAnimal)(nil), "Animals", false).GetResults(&results)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(results))
+	assert.Equal(t, "Rex barks", results[0].Sound())
+	cat, ok := results[1].(*testCat)
+	require.True(t, ok, "expected *testCat, got %T", results[1])
+	assert.Equal(t, "animals/2", cat.ID)
+	assert.Equal(t, 9, cat.Lives)
+}
+
+func TestDocumentQueryForInterfaceFromCollection(t *testing.T) {
+	var queries []string
+	fn := func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Query string
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		queries = append(queries, body.Query)
+		_, _ = w.Write([]byte(`{"Results": [
+			{"Name": "Rex", "@metadata": {"@id": "animals/1", "@collection": "Animals", "@change-vector": "A:1", "Raven-Go-Type": "ravendb.testDog"}}
+		], "Includes": {}, "IndexName": "Auto/Animals/ByName", "TotalResults": 1}`))
+	}
+	srv := httptest.NewServer(http.HandlerFunc(fn))
+	defer srv.Close()
+
+	store := NewDocumentStore([]string{srv.URL}, "db")
+	store.GetConventions().SetDisableTopologyUpdates(true)
+	store.GetConventions().RegisterEntityType(&testDog{})
+	require.NoError(t, store.Initialize())
+	defer store.Close()
+	session, err := store.OpenSessionWithOptions(&SessionOptions{
+		NoTracking: true,
+	})
+	require.NoError(t, err)
+	defer session.Close()
+
+	// query created without collection is completed by FromCollection
+	var stats *QueryStatistics
+	var results []testAnimal
+	animalType := reflect.TypeOf((*testAnimal)(nil)).Elem()
+	q := session.QueryCollectionForType(animalType).FromCollection("Animals").
+		Statistics(&stats).WhereIn("Name", []interface{}{"Rex", "Tom"})
+	err = q.GetResults(&results)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(results))
+	assert.Equal(t, "Rex barks", results[0].Sound())
+	assert.Equal(t, []string{"from Animals where Name in ($p0)"}, queries)
+	assert.Equal(t, 1, stats.TotalResults)
+	assert.Equal(t, "Auto/Animals/ByName", stats.IndexName)
+	assert.Equal(t, 0, session.GetNumberOfEntitiesInUnitOfWork())
+}
+
+func TestDocumentQueryTypedSliceValues(t *testing.T) {
+	session := newQueryTestSession()
+
+	tests := []struct {
+		values   interface{}
+		expParam []interface{}
+	}{
+		{[]string{"a", "b"}, []interface{}{"a", "b"}},
+		{[]int{1, 2}, []interface{}{1, 2}},
+		{[]int64{1, 2}, []interface{}{int64(1), int64(2)}},
+		{[2]float64{1.5, 2.5}, []interface{}{1.5, 2.5}},
+		{[]interface{}{"a", []string{"b", "c"}, [][]int{{1}, {2}}}, []interface{}{"a", "b", "c", 1, 2}},
+		{[][]byte{[]byte("ab")}, []interface{}{[]byte("ab")}},
+	}
+	for _, test := range tests {
+		q := session.QueryCollection("Users").WhereIn("name", test.values)
+		rql, params := queryString(t, q)
+		assert.Equal(t, "from Users where name in ($p0)", rql)
+		assert.Equal(t, test.expParam, params["p0"])
+
+		q = session.QueryCollection("Users").ContainsAny("tags", test.values)
+		rql, params = queryString(t, q)
+		assert.Equal(t, "from Users where tags in ($p0)", rql)
+		assert.Equal(t, test.expParam, params["p0"])
+
+		q = session.QueryCollection("Users").ContainsAll("tags", test.values)
+		rql, params = queryString(t, q)
+		assert.Equal(t, "from Users where tags all in ($p0)", rql)
+		assert.Equal(t, test.expParam, params["p0"])
+	}
+
+	q := session.QueryCollection("Users").ContainsAny("tags", []string{})
+	rql, _ := queryString(t, q)
+	assert.Equal(t, "from Users where (true and not true)", rql)
+
+	for _, values := range []interface{}{"a", 5, map[string]int{"a": 1}} {
+		_, err := session.QueryCollection("Users").WhereIn("name", values).GetIndexQuery()
+		_, ok := err.(*IllegalArgumentError)
+		assert.True(t, ok, "values: %#v, err: %v", values, err)
+	}
+}
+
+func benchmarkWhereIn(b *testing.B, values interface{}) {
+	session := newQueryTestSession()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		q := session.QueryCollection("Users").WhereIn("id", values)
+		if _, err := q.GetIndexQuery(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDocumentQueryWhereIn(b *testing.B) {
+	const n = 10000
+	ids := make([]string, n)
+	nums := make([]int, n)
+	iface := make([]interface{}, n)
+	for i := range ids {
+		ids[i] = "users/" + strconv.Itoa(i)
+		nums[i] = i
+		iface[i] = ids[i]
+	}
+	b.Run("interfaces", func(b *testing.B) { benchmarkWhereIn(b, iface) })
+	b.Run("strings", func(b *testing.B) { benchmarkWhereIn(b, ids) })
+	b.Run("ints", func(b *testing.B) { benchmarkWhereIn(b, nums) })
+}
+
+func TestDocumentQueryWhereInSplitting(t *testing.T) {
+	session := newQueryTestSession()
+	session.GetConventions().MaxNumberOfValuesInWhereIn = 2
+
+	// under the limit
+	q := session.QueryCollection("Users").WhereIn("name", []string{"a", "b"})
+	rql, _ := queryString(t, q)
+	assert.Equal(t, "from Users where name in ($p0)", rql)
+
+	q = session.QueryCollection("Users").WhereIn("name", []string{"a", "b", "c", "d", "e"})
+	rql, params := queryString(t, q)
+	assert.Equal(t, "from Users where (name in ($p0) or name in ($p1) or name in ($p2))", rql)
+	assert.Equal(t, []interface{}{"a", "b"}, params["p0"])
+	assert.Equal(t, []interface{}{"c", "d"}, params["p1"])
+	assert.Equal(t, []interface{}{"e"}, params["p2"])
+
+	// respects surrounding operators
+	q = session.QueryCollection("Users").WhereEquals("age", 3).WhereIn("name", []string{"a", "b", "c"}).WhereEquals("lastName", "Doe")
+	rql, _ = queryString(t, q)
+	assert.Equal(t, "from Users where age = $p0 and (name in ($p1) or name in ($p2)) and lastName = $p3", rql)
+
+	q = session.QueryCollection("Users").WhereEquals("age", 3).OrElse().WhereIn("name", []string{"a", "b", "c"})
+	rql, _ = queryString(t, q)
+	assert.Equal(t, "from Users where age = $p0 or (name in ($p1) or name in ($p2))", rql)
+
+	// negation applies to the whole split clause
+	q = session.QueryCollection("Users").Not().WhereIn("name", []string{"a", "b", "c"})
+	rql, _ = queryString(t, q)
+	assert.Equal(t, "from Users where exists(name) and not (name in ($p0) or name in ($p1))", rql)
+
+	q = session.QueryCollection("Users").WhereEquals("age", 3).Not().WhereIn("name", []string{"a", "b", "c"})
+	rql, _ = queryString(t, q)
+	assert.Equal(t, "from Users where age = $p0 and not (name in ($p1) or name in ($p2))", rql)
+
+	// 0 disables splitting
+	session.GetConventions().MaxNumberOfValuesInWhereIn = 0
+	q = session.QueryCollection("Users").WhereIn("name", []string{"a", "b", "c"})
+	rql, _ = queryString(t, q)
+	assert.Equal(t, "from Users where name in ($p0)", rql)
+
+	// 50k ids are split by default
+	session = newQueryTestSession()
+	assert.Equal(t, 1024, session.GetConventions().MaxNumberOfValuesInWhereIn)
+	ids := make([]string, 50000)
+	for i := range ids {
+		ids[i] = "users/" + strconv.Itoa(i)
+	}
+	q = session.QueryCollection("Users").WhereIDIn(ids)
+	rql, params = queryString(t, q)
+	assert.Equal(t, 49, len(params))
+	assert.Equal(t, 49, strings.Count(rql, " in ("))
+	var all []interface{}
+	for i := 0; i < len(params); i++ {
+		all = append(all, params["p"+strconv.Itoa(i)].([]interface{})...)
+	}
+	require.Equal(t, len(ids), len(all))
+	for i, id := range ids {
+		if all[i] != id {
+			t.Fatalf("expected %s at %d, got %v", id, i, all[i])
+		}
+	}
+}
+
+func TestDocumentQueryGroupByArray(t *testing.T) {
+	session := newQueryTestSession()
+
+	q := session.QueryCollection("Orders").GroupByFieldWithMethod(NewGroupByArrayValues("tags")).SelectKey().SelectCount()
+	rql, _ := queryString(t, q)
+	assert.Equal(t, "from Orders group by tags[] select key(), count() as count", rql)
+
+	q = session.QueryCollection("Orders").GroupByFieldWithMethod(NewGroupByArrayValues("lines[].product")).SelectKeyWithNameAndProjectedName("", "product").SelectCount()
+	rql, _ = queryString(t, q)
+	assert.Equal(t, "from Orders group by lines[].product select key() as product, count() as count", rql)
+
+	q = session.QueryCollection("Orders").GroupByFieldWithMethod(NewGroupByArrayContent("lines[].product"), NewGroupByField("shipTo.country")).SelectCount()
+	rql, _ = queryString(t, q)
+	assert.Equal(t, "from Orders group by array(lines[].product), shipTo.country select count() as count", rql)
+}
+
+type testUserHeartRate struct {
+	ID         string
+	TimeSeries *TimeSeriesRawResult
+}
+
+func TestDocumentQuerySelectTimeSeries(t *testing.T) {
+	session := newQueryTestSession()
+	from := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC)
+
+	q := session.QueryCollection("Users").WhereEquals("Name", "John").SelectTimeSeries("HeartRate", from, to)
+	rql, params := queryString(t, q)
+	assert.Equal(t, "from Users where Name = $p0 select timeseries(from HeartRate between $p1 and $p2) as TimeSeries", rql)
+	assert.Equal(t, from, params["p1"])
+	assert.Equal(t, to, params["p2"])
+
+	// open on one side
+	q = session.QueryCollection("Users").SelectTimeSeries("HeartRate", time.Time{}, to)
+	rql, params = queryString(t, q)
+	assert.Equal(t, "from Users select timeseries(from HeartRate between $p0 and $p1) as TimeSeries", rql)
+	assert.Equal(t, time.Date(1, 1, 1, 0, 0, 0, 0, time.UTC), params["p0"])
+	assert.Equal(t, to, params["p1"])
+
+	q = session.QueryCollection("Users").SelectTimeSeries("HeartRate", from, time.Time{})
+	rql, params = queryString(t, q)
+	assert.Equal(t, "from Users select timeseries(from HeartRate between $p0 and $p1) as TimeSeries", rql)
+	assert.Equal(t, from, params["p0"])
+	assert.Equal(t, time.Date(9999, 12, 31, 23, 59, 59, 999999900, time.UTC), params["p1"])
+
+	q = session.QueryCollection("Users").SelectTimeSeries("Heart Rate", time.Time{}, time.Time{})
+	rql, _ = queryString(t, q)
+	assert.Equal(t, "from Users select timeseries(from 'Heart Rate') as TimeSeries", rql)
+
+	q = session.QueryCollection("Users").SelectTimeSeries("HeartRate", from, to).SelectTimeSeries("Temperature", from, to)
+	_, err := q.GetIndexQuery()
+	assert.Error(t, err)
+
+	q = session.QueryCollection("Users").SelectTimeSeries("", from, to)
+	_, err = q.GetIndexQuery()
+	assert.Error(t, err)
+}
+
+func TestDocumentQuerySelectTimeSeriesResults(t *testing.T) {
+	var nRequests int32
+	fn := func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&nRequests, 1)
+		_, _ = w.Write([]byte(`{"Results": [
+			{"TimeSeries": {"Count": 2, "Results": [
+				{"Timestamp": "2023-01-01T10:00:00.0000000Z", "Tag": "watch", "Values": [70], "IsRollup": false},
+				{"Timestamp": "2023-01-01T11:00:00.0000000Z", "Tag": "watch", "Values": [82.5], "IsRollup": false}
+			]}, "@metadata": {"@id": "users/1", "@projection": true}}
+		], "Includes": {}, "IndexName": "Users", "TotalResults": 1}`))
+	}
+	store := newFakeServerStore(t, fn)
+	session, err := store.OpenSession("")
+	require.NoError(t, err)
+	defer session.Close()
+
+	from := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC)
+	var results []*testUserHeartRate
+	err = session.QueryCollection("Users").SelectTimeSeries("HeartRate", from, to).GetResults(&results)
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&nRequests))
+
+	require.Equal(t, 1, len(results))
+	assert.Equal(t, "users/1", results[0].ID)
+	ts := results[0].TimeSeries
+	require.NotNil(t, ts)
+	assert.Equal(t, int64(2), ts.Count)
+	require.Equal(t, 2, len(ts.Results))
+	assert.Equal(t, time.Date(2023, 1, 1, 11, 0, 0, 0, time.UTC), ts.Results[1].GetTimestamp())
+	assert.Equal(t, []float64{82.5}, ts.Results[1].Values)
+	assert.Equal(t, "watch", ts.Results[1].Tag)
+}
+
+func TestDocumentQueryNot(t *testing.T) {
+	session := newQueryTestSession()
+	var logged bytes.Buffer
+	session.GetConventions().Logger = log.New(&logged, "", 0)
+
+	q := session.QueryCollection("Users").Not().WhereExists("lastName")
+	rql, _ := queryString(t, q)
+	assert.Equal(t, "from Users where true and not exists(lastName)", rql)
+	assert.Equal(t, 0, logged.Len())
+
+	// negation only applies to the next clause
+	q = session.QueryCollection("Users").Not().WhereExists("lastName").AndAlso().WhereExists("name")
+	rql, _ = queryString(t, q)
+	assert.Equal(t, "from Users where true and not exists(lastName) and exists(name)", rql)
+	assert.Equal(t, 0, logged.Len())
+
+	// two negations cancel each other out
+	q = session.QueryCollection("Users").Not().Not().WhereExists("lastName")
+	rql, _ = queryString(t, q)
+	assert.Equal(t, "from Users where exists(lastName)", rql)
+	assert.Contains(t, logged.String(), "negated twice")
+}
+
+func TestDocumentQueryOnEntityMaterialized(t *testing.T) {
+	fn := func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"Results": [
+			{"Name": "John", "@metadata": {"@id": "users/1", "@projection": true}}
+		], "Includes": {}, "IndexName": "Auto/Users", "TotalResults": 1}`))
+	}
+	store := newFakeServerStore(t, fn)
+	session, err := store.OpenSession("")
+	require.NoError(t, err)
+	defer session.Close()
+
+	var entities []interface{}
+	session.Advanced().OnEntityMaterialized(func(entity interface{}, metadata map[string]interface{}) {
+		entities = append(entities, entity)
+		switch v := entity.(type) {
+		case *User:
+			v.Name = strings.ToUpper(v.Name)
+		case *string:
+			*v = strings.ToUpper(*v)
+		}
+	})
+
+	var users []*User
+	err = session.QueryCollection("Users").GetResults(&users)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(users))
+	assert.Equal(t, "JOHN", users[0].Name)
+
+	// slice of values, the handler still gets a pointer
+	var names []string
+	err = session.QueryCollection("Users").SelectFields(reflect.TypeOf(""), "Name").GetResults(&names)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"JOHN"}, names)
+
+	require.Equal(t, 2, len(entities))
+	assert.IsType(t, &User{}, entities[0])
+	assert.IsType(t, new(string), entities[1])
+}
@@ -40,6 +40,9 @@ type EagerSessionOperations struct {
 	s *DocumentSession
 }
 
+// ExecuteAllPendingLazyOperations executes all lazy operations queued in
+// the session, sending at most conventions.MaxNumberOfLazyOperationsPerRequest
+// operations in a single request. Returns how long the requests took
 func (s *EagerSessionOperations) ExecuteAllPendingLazyOperations() (*ResponseTimeInformation, error) {
 	return s.s.executeAllPendingLazyOperations()
 }
@@ -82,6 +85,9 @@ func NewDocumentSession(dbName string, documentStore *DocumentStore, id string,
 
 // SaveChanges saves changes queued in memory to the database
 func (s *DocumentSession) SaveChanges() error {
+	if s.noTracking {
+		return newIllegalStateError("Cannot execute SaveChanges when entity tracking is disabled in session.")
+	}
 	saveChangeOperation := newBatchOperation(s.InMemoryDocumentSessionOperations)
 
 	command, err := saveChangeOperation.createRequest()
@@ -99,13 +105,17 @@ func (s *DocumentSession) SaveChanges() error {
 		return err
 	}
 	result := command.Result
-	return saveChangeOperation.setResult(result.Results)
+	if err = saveChangeOperation.setResult(result.Results); err != nil {
+		return err
+	}
+	s.updateSessionAfterSaveChanges(result)
+	return nil
 }
 
 // Exists returns true if an entity with a given id exists in the database
 func (s *DocumentSession) Exists(id string) (bool, error) {
-	if id == "" {
-		return false, newIllegalArgumentError("id cannot be empty string")
+	if err := validateDocumentID(id, false); err != nil {
+		return false, err
 	}
 
 	if stringArrayContainsNoCase(s.knownMissingIds, id) {
@@ -125,6 +135,47 @@ func (s *DocumentSession) Exists(id string) (bool, error) {
 	return ok, nil
 }
 
+// LoadMetadataOnly returns metadata of documents with given ids, keyed by
+// document id as stored in the database. Documents that don't exist are
+// not in the result. Neither the documents nor their metadata are tracked
+// by the session
+func (s *DocumentSession) LoadMetadataOnly(ids []string) (map[string]*MetadataAsDictionary, error) {
+	if len(ids) == 0 {
+		return nil, newIllegalArgumentError("ids cannot be empty array")
+	}
+	command, err := NewGetDocumentsCommand(ids, nil, true)
+	if err != nil {
+		return nil, err
+	}
+	if err = s.incrementRequestCount(); err != nil {
+		return nil, err
+	}
+	if err = s.requestExecutor.ExecuteCommand(command, s.sessionInfo); err != nil {
+		return nil, err
+	}
+
+	res := map[string]*MetadataAsDictionary{}
+	if command.Result == nil {
+		return res, nil
+	}
+	for _, document := range command.Result.Results {
+		// missing documents are null
+		if document == nil {
+			continue
+		}
+		metadata, ok := document[MetadataKey].(map[string]interface{})
+		if !ok {
+			return nil, newIllegalStateError("Document must have a metadata")
+		}
+		id, ok := jsonGetAsString(metadata, MetadataID)
+		if !ok {
+			return nil, newIllegalStateError("Document must have an id")
+		}
+		res[id] = NewMetadataAsDictionaryWithSource(metadata)
+	}
+	return res, nil
+}
+
 // Refresh reloads information about a given entity in the session from the database
 func (s *DocumentSession) Refresh(entity interface{}) error {
 	if err := checkValidEntityIn(entity, "entity"); err != nil {
@@ -168,22 +219,31 @@ func (s *DocumentSession) executeAllPendingLazyOperations() (*ResponseTimeInform
 	}
 
 	sw := time.Now()
-	if err := s.incrementRequestCount(); err != nil {
-		return nil, err
-	}
-
 	defer func() { s.pendingLazyOperations = nil }()
 
+	batchSize := s.GetConventions().MaxNumberOfLazyOperationsPerRequest
+	if batchSize <= 0 {
+		batchSize = len(requests)
+	}
 	responseTimeDuration := &ResponseTimeInformation{}
-	for {
-		shouldRetry, err := s.executeLazyOperationsSingleStep(responseTimeDuration, requests)
-		if err != nil {
+	for start := 0; start < len(requests); start += batchSize {
+		end := start + batchSize
+		if end > len(requests) {
+			end = len(requests)
+		}
+		if err := s.incrementRequestCount(); err != nil {
 			return nil, err
 		}
-		if !shouldRetry {
-			break
+		for {
+			shouldRetry, err := s.executeLazyOperationsSingleStep(responseTimeDuration, s.pendingLazyOperations[start:end], requests[start:end])
+			if err != nil {
+				return nil, err
+			}
+			if !shouldRetry {
+				break
+			}
+			time.Sleep(time.Millisecond * 100)
 		}
-		time.Sleep(time.Millisecond * 100)
 	}
 	responseTimeDuration.computeServerTotal()
 
@@ -200,11 +260,11 @@ func (s *DocumentSession) executeAllPendingLazyOperations() (*ResponseTimeInform
 
 	dur := time.Since(sw)
 
-	responseTimeDuration.totalClientDuration = dur
+	responseTimeDuration.TotalClientDuration = dur
 	return responseTimeDuration, nil
 }
 
-func (s *DocumentSession) executeLazyOperationsSingleStep(responseTimeInformation *ResponseTimeInformation, requests []*getRequest) (bool, error) {
+func (s *DocumentSession) executeLazyOperationsSingleStep(responseTimeInformation *ResponseTimeInformation, operations []ILazyOperation, requests []*getRequest) (bool, error) {
 	multiGetOperation := &MultiGetOperation{
 		session: s.InMemoryDocumentSessionOperations,
 	}
@@ -215,7 +275,7 @@ func (s *DocumentSession) executeLazyOperationsSingleStep(responseTimeInformatio
 		return false, err
 	}
 	responses := multiGetCommand.Result
-	for i, op := range s.pendingLazyOperations {
+	for i, op := range operations {
 		response := responses[i]
 		tempReqTime := response.Headers[headersRequestTime]
 		totalTime, _ := strconv.Atoi(tempReqTime)
@@ -225,7 +285,7 @@ func (s *DocumentSession) executeLazyOperationsSingleStep(responseTimeInformatio
 			URL:      uri,
 			Duration: dur,
 		}
-		responseTimeInformation.durationBreakdown = append(responseTimeInformation.durationBreakdown, timeItem)
+		responseTimeInformation.DurationBreakdown = append(responseTimeInformation.DurationBreakdown, timeItem)
 		if response.requestHasErrors() {
 			return false, newIllegalStateError("Got an error from server, status code: %d\n%s", response.StatusCode, response.Result)
 		}
@@ -333,8 +393,8 @@ func checkValidLoadArg(v interface{}, argName string) error {
 // Load loads an entity with a given id and sets result to it.
 // result should be of type **<struct> or *map[string]interface{}
 func (s *DocumentSession) Load(result interface{}, id string) error {
-	if id == "" {
-		return newIllegalArgumentError("id cannot be empty string")
+	if err := validateDocumentID(id, false); err != nil {
+		return err
 	}
 	if err := checkValidLoadArg(result, "result"); err != nil {
 		return err
@@ -351,7 +411,7 @@ func (s *DocumentSession) Load(result interface{}, id string) error {
 	if command != nil {
 		err := s.requestExecutor.ExecuteCommand(command, s.sessionInfo)
 		if err != nil {
-			return err
+			return s.addConflicts(err)
 		}
 		result := command.Result
 		loadOperation.setResult(result)
@@ -389,6 +449,25 @@ func checkValidLoadMultiArg(v interface{}, argName string) error {
 	return nil
 }
 
+// addConflicts attaches conflicting versions of a document to
+// DocumentConflictError returned when loading it, so that the caller can
+// resolve the conflict by storing one of them (or a merge of them).
+// If conflicts can't be retrieved, the original error is returned as is
+func (s *DocumentSession) addConflicts(err error) error {
+	conflictErr, ok := err.(*DocumentConflictError)
+	if !ok || conflictErr.DocID == "" {
+		return err
+	}
+	if s.incrementRequestCount() != nil {
+		return err
+	}
+	command := NewGetConflictsCommand(conflictErr.DocID)
+	if s.requestExecutor.ExecuteCommand(command, s.sessionInfo) == nil && command.Result != nil {
+		conflictErr.Conflicts = command.Result.Results
+	}
+	return err
+}
+
 // LoadMulti loads multiple values with given ids into results, which should
 // be a map from string (id) to pointer to struct
 func (s *DocumentSession) LoadMulti(results interface{}, ids []string) error {
@@ -416,7 +495,7 @@ func (s *DocumentSession) loadInternalWithOperation(ids []string, operation *Loa
 	if command != nil {
 		err := s.requestExecutor.ExecuteCommand(command, s.sessionInfo)
 		if err != nil {
-			return err
+			return s.addConflicts(err)
 		}
 
 		if stream != nil {
@@ -433,13 +512,19 @@ func (s *DocumentSession) loadInternalWithOperation(ids []string, operation *Loa
 
 // results should be map[string]*struct
 func (s *DocumentSession) loadInternalMulti(results interface{}, ids []string, includes []string) error {
+	loadOperation := NewLoadOperation(s.InMemoryDocumentSessionOperations)
+	loadOperation.withIncludes(includes)
+	return s.loadInternalMultiWithOperation(results, ids, loadOperation)
+}
+
+// loadInternalMultiWithOperation loads ids with loadOperation configured
+// with includes
+func (s *DocumentSession) loadInternalMultiWithOperation(results interface{}, ids []string, loadOperation *LoadOperation) error {
 	if len(ids) == 0 {
 		return newIllegalArgumentError("ids cannot be empty array")
 	}
 
-	loadOperation := NewLoadOperation(s.InMemoryDocumentSessionOperations)
 	loadOperation.byIds(ids)
-	loadOperation.withIncludes(includes)
 
 	command, err := loadOperation.createRequest()
 	if err != nil {
@@ -448,7 +533,7 @@ func (s *DocumentSession) loadInternalMulti(results interface{}, ids []string, i
 	if command != nil {
 		err := s.requestExecutor.ExecuteCommand(command, s.sessionInfo)
 		if err != nil {
-			return err
+			return s.addConflicts(err)
 		}
 		loadOperation.setResult(command.Result)
 	}
@@ -841,6 +926,40 @@ func (s *DocumentSession) QueryCollectionForType(typ reflect.Type) *DocumentQuer
 	return res
 }
 
+// QueryInterface creates a new query over a collection (or an index,
+// if isIndex is true) whose documents are deserialized into interface type
+// iface, given as a nil pointer to it e.g. (*Animal)(nil).
+// Concrete types of documents must be registered with
+// DocumentConventions.RegisterEntityType
+func (s *DocumentSession) QueryInterface(iface interface{}, collectionOrIndex string, isIndex bool) *DocumentQuery {
+	typ := reflect.TypeOf(iface)
+	if !isInterfaceType(typ) {
+		res := s.QueryCollection(collectionOrIndex)
+		if res.err == nil {
+			res.err = newIllegalArgumentError("iface must be a pointer to an interface, is %T", iface)
+		}
+		return res
+	}
+	opts := &DocumentQueryOptions{
+		Type:        typ.Elem(),
+		session:     s.InMemoryDocumentSessionOperations,
+		conventions: s.GetConventions(),
+	}
+	if isIndex {
+		opts.IndexName = collectionOrIndex
+	} else {
+		opts.CollectionName = collectionOrIndex
+	}
+	res := newDocumentQuery(opts)
+	if res.err == nil && collectionOrIndex == "" {
+		res.err = newIllegalArgumentError("collectionOrIndex cannot be empty")
+	}
+	if res.err == nil && !isIndex {
+		res.err = throwIfInvalidCollectionName(collectionOrIndex)
+	}
+	return res
+}
+
 // QueryIndex creates a new query in a index with a given name
 func (s *DocumentSession) QueryIndex(indexName string) *DocumentQuery {
 	opts := &DocumentQueryOptions{
@@ -913,43 +1032,60 @@ func (s *DocumentSession) StreamRawQuery(query *RawDocumentQuery, streamQuerySta
 // StreamRawQueryInto starts a raw streaming query that will write the results
 // (in JSON format) to output
 func (s *DocumentSession) StreamRawQueryInto(query *RawDocumentQuery, output io.Writer) error {
-	streamOperation := NewStreamOperation(s.InMemoryDocumentSessionOperations, nil)
 	q, err := query.GetIndexQuery()
 	if err != nil {
 		return err
 	}
-	command, err := streamOperation.createRequestForIndexQuery(q)
+	return s.streamIndexQueryInto(q, output, "", nil)
+}
+
+// StreamQueryInto starts a streaming query that will write the results
+// (in JSON format) to output
+func (s *DocumentSession) StreamQueryInto(query *DocumentQuery, output io.Writer) error {
+	q, err := query.GetIndexQuery()
 	if err != nil {
 		return err
 	}
-	err = s.GetRequestExecutor().ExecuteCommand(command, s.sessionInfo)
+	return s.streamIndexQueryInto(q, output, "", nil)
+}
+
+// StreamQueryAsCSV starts a streaming query that will write the results
+// in CSV format to output. The first row is a header with field names.
+// If fields is empty, all fields of the results are written.
+func (s *DocumentSession) StreamQueryAsCSV(query *DocumentQuery, output io.Writer, fields []string) error {
+	q, err := query.GetIndexQuery()
 	if err != nil {
 		return err
 	}
-	stream := command.Result.Stream
-	_, err = io.Copy(output, stream)
-	return err
+	return s.streamIndexQueryInto(q, output, "csv", fields)
 }
 
-// StreamQueryInto starts a streaming query that will write the results
-// (in JSON format) to output
-func (s *DocumentSession) StreamQueryInto(query *DocumentQuery, output io.Writer) error {
-	streamOperation := NewStreamOperation(s.InMemoryDocumentSessionOperations, nil)
+// StreamRawQueryAsCSV is like StreamQueryAsCSV but for a raw query
+func (s *DocumentSession) StreamRawQueryAsCSV(query *RawDocumentQuery, output io.Writer, fields []string) error {
 	q, err := query.GetIndexQuery()
 	if err != nil {
 		return err
 	}
+	return s.streamIndexQueryInto(q, output, "csv", fields)
+}
 
+// streamIndexQueryInto copies raw server response to output without
+// decoding it
+func (s *DocumentSession) streamIndexQueryInto(q *IndexQuery, output io.Writer, format string, fields []string) error {
+	streamOperation := NewStreamOperation(s.InMemoryDocumentSessionOperations, nil)
 	command, err := streamOperation.createRequestForIndexQuery(q)
 	if err != nil {
 		return err
 	}
+	command.format = format
+	command.fields = fields
 	err = s.GetRequestExecutor().ExecuteCommand(command, s.sessionInfo)
 	if err != nil {
 		return err
 	}
-	stream := command.Result.Stream
-	_, err = io.Copy(output, stream)
+	body := command.Result.Response.Body
+	defer body.Close()
+	_, err = io.Copy(output, body)
 	return err
 }
 
@@ -5,6 +5,7 @@ import (
 	"crypto/x509"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -23,10 +24,10 @@ type DocumentStore struct {
 	onSessionCreated []func(*SessionCreatedEventArgs)
 	subscriptions    *DocumentSubscriptions
 
-	disposed    bool
+	disposed    int32 // atomic
 	conventions *DocumentConventions
 	urls        []string // urls for HTTP endopoints of server nodes
-	initialized bool
+	initialized int32    // atomic
 	Certificate *tls.Certificate
 	TrustStore  *x509.Certificate
 	database    string // name of the database
@@ -50,10 +51,18 @@ type DocumentStore struct {
 	identifier                   string
 	aggressiveCachingUsed        bool
 
+	// access must be protected with mu
 	afterClose  []func(*DocumentStore)
 	beforeClose []func(*DocumentStore)
 
+	// maps lower-cased database name to the index of the last cluster-wide
+	// transaction. Must be protected with mu
+	lastTransactionIndexPerDatabase map[string]int64
+
 	mu sync.Mutex
+
+	// serializes Initialize and Close
+	lifecycleMu sync.Mutex
 }
 
 // methods from DocumentStoreBase
@@ -77,7 +86,9 @@ func (s *DocumentStore) Subscriptions() *DocumentSubscriptions {
 	return s.subscriptions
 }
 
-// GetUrls returns urls of all RavenDB nodes
+// GetUrls returns urls of all RavenDB nodes. After Initialize they're
+// normalized: without trailing slashes, with lower-cased scheme and host
+// and without duplicates
 func (s *DocumentStore) GetUrls() []string {
 	return s.urls
 }
@@ -92,9 +103,17 @@ func (s *DocumentStore) SetUrls(urls []string) {
 	s.urls = urls
 }
 
+func (s *DocumentStore) isDisposed() bool {
+	return atomic.LoadInt32(&s.disposed) > 0
+}
+
+func (s *DocumentStore) isInitialized() bool {
+	return atomic.LoadInt32(&s.initialized) > 0
+}
+
 func (s *DocumentStore) ensureNotClosed() error {
-	if s.disposed {
-		return newIllegalStateError("The document store has already been disposed and cannot be used")
+	if s.isDisposed() {
+		return newStoreDisposedError("The document store has already been disposed and cannot be used")
 	}
 	return nil
 }
@@ -197,14 +216,14 @@ func (s *DocumentStore) afterSessionCreated(session *InMemoryDocumentSessionOper
 }
 
 func (s *DocumentStore) assertInitialized() error {
-	if !s.initialized {
+	if !s.isInitialized() {
 		return newIllegalStateError("DocumentStore must be initialized")
 	}
 	return nil
 }
 
 func (s *DocumentStore) assertNotInitialized(property string) {
-	panicIf(s.initialized, "You cannot set '%s' after the document store has been initialized.", property)
+	panicIf(s.isInitialized(), "You cannot set '%s' after the document store has been initialized.", property)
 }
 
 func (s *DocumentStore) GetDatabase() string {
@@ -263,45 +282,101 @@ func (s *DocumentStore) SetIdentifier(identifier string) {
 	s.identifier = identifier
 }
 
-// Close closes the Store
-func (s *DocumentStore) Close() {
-	if s.disposed {
+// Close closes the Store and releases its resources.
+// Changes connections are closed first, then we wait up to
+// conventions.CloseTimeout for requests that are still executing,
+// return unused HiLo ranges to the server and call AfterClose listeners.
+// Calling Close on a closed store is a no-op.
+// After Close, operations on the store return StoreDisposedError.
+func (s *DocumentStore) Close() error {
+	s.lifecycleMu.Lock()
+	defer s.lifecycleMu.Unlock()
+
+	if s.isDisposed() {
 		redbg("DocumentStore.Close: already disposed\n")
-		return
+		return nil
 	}
 	redbg("DocumentStore.Close\n")
 
-	for _, fn := range s.beforeClose {
-		fn(s)
-	}
+	s.mu.Lock()
+	beforeClose := s.beforeClose
 	s.beforeClose = nil
+	s.mu.Unlock()
+	for _, fn := range beforeClose {
+		if fn != nil {
+			fn(s)
+		}
+	}
 
+	// closing DatabaseChanges removes it from s.databaseChanges so we
+	// can't iterate the map directly
+	s.mu.Lock()
+	var allChanges []*DatabaseChanges
+	for _, changes := range s.databaseChanges {
+		allChanges = append(allChanges, changes)
+	}
+	var evicts []*evictItemsFromCacheBasedOnChanges
 	for _, evict := range s.aggressiveCacheChanges {
-		evict.Close()
+		evicts = append(evicts, evict)
 	}
+	s.aggressiveCacheChanges = map[string]*evictItemsFromCacheBasedOnChanges{}
+	s.mu.Unlock()
 
-	for _, changes := range s.databaseChanges {
+	for _, changes := range allChanges {
 		changes.Close()
 	}
 
+	for _, evict := range evicts {
+		evict.Close()
+	}
+
+	var err error
+	timeout := s.GetConventions().CloseTimeout
+	for _, re := range s.getRequestExecutors() {
+		if !re.waitForInFlightRequests(timeout) && err == nil {
+			err = NewTimeoutError("DocumentStore.Close() timed out after %s waiting for requests to database '%s' to finish", timeout, re.databaseName)
+		}
+	}
+
 	if s.multiDbHiLo != nil {
-		s.multiDbHiLo.ReturnUnusedRange()
+		if err2 := s.multiDbHiLo.ReturnUnusedRange(); err2 != nil && err == nil {
+			err = err2
+		}
 	}
 
 	if s.Subscriptions() != nil {
-		_ = s.Subscriptions().Close()
+		if err2 := s.Subscriptions().Close(); err2 != nil && err == nil {
+			err = err2
+		}
 	}
 
-	s.disposed = true
+	atomic.StoreInt32(&s.disposed, 1)
 
-	for _, fn := range s.afterClose {
-		fn(s)
-	}
+	// listeners are called in the order they were added
+	s.mu.Lock()
+	afterClose := s.afterClose
 	s.afterClose = nil
+	s.mu.Unlock()
+	for _, fn := range afterClose {
+		if fn != nil {
+			fn(s)
+		}
+	}
 
-	for _, re := range s.requestsExecutors {
+	for _, re := range s.getRequestExecutors() {
 		re.Close()
 	}
+	return err
+}
+
+func (s *DocumentStore) getRequestExecutors() []*RequestExecutor {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var res []*RequestExecutor
+	for _, re := range s.requestsExecutors {
+		res = append(res, re)
+	}
+	return res
 }
 
 // OpenSession opens a new session to document Store.
@@ -321,6 +396,12 @@ func (s *DocumentStore) OpenSessionWithOptions(options *SessionOptions) (*Docume
 		return nil, err
 	}
 
+	switch options.TransactionMode {
+	case "", TransactionModeSingleNode, TransactionModeClusterWide:
+	default:
+		return nil, newIllegalArgumentError("unknown TransactionMode '%s'", options.TransactionMode)
+	}
+
 	sessionID := NewUUID().String()
 	databaseName := options.Database
 	if databaseName == "" {
@@ -331,15 +412,33 @@ func (s *DocumentStore) OpenSessionWithOptions(options *SessionOptions) (*Docume
 		requestExecutor = s.GetRequestExecutor(databaseName)
 	}
 	session := NewDocumentSession(databaseName, s, sessionID, requestExecutor)
+	if options.TransactionMode != "" {
+		session.transactionMode = options.TransactionMode
+	}
+	session.noTracking = options.NoTracking
+	session.sessionInfo.NoCaching = options.NoCaching
 	s.registerEvents(session.InMemoryDocumentSessionOperations)
 	s.afterSessionCreated(session.InMemoryDocumentSessionOperations)
 	return session, nil
 }
 
+// OpenAsyncSession opens a session whose operations run in background
+// goroutines, see AsyncDocumentSession
+func (s *DocumentStore) OpenAsyncSession(database string) (*AsyncDocumentSession, error) {
+	session, err := s.OpenSession(database)
+	if err != nil {
+		return nil, err
+	}
+	return newAsyncDocumentSession(session), nil
+}
+
 func (s *DocumentStore) ExecuteIndex(task *IndexCreationTask, database string) error {
 	if err := s.assertInitialized(); err != nil {
 		return err
 	}
+	if err := s.ensureNotClosed(); err != nil {
+		return err
+	}
 	return task.Execute(s, s.conventions, database)
 }
 
@@ -347,6 +446,17 @@ func (s *DocumentStore) ExecuteIndexes(tasks []*IndexCreationTask, database stri
 	if err := s.assertInitialized(); err != nil {
 		return err
 	}
+	if err := s.ensureNotClosed(); err != nil {
+		return err
+	}
+	for _, task := range tasks {
+		if err := indexCreationValidateName(task.IndexName); err != nil {
+			return err
+		}
+	}
+	if len(tasks) == 0 {
+		return nil
+	}
 	indexesToAdd := indexCreationCreateIndexesToAdd(tasks, s.conventions)
 
 	op := NewPutIndexesOperation(indexesToAdd...)
@@ -356,8 +466,66 @@ func (s *DocumentStore) ExecuteIndexes(tasks []*IndexCreationTask, database stri
 	return s.Maintenance().ForDatabase(database).Send(op)
 }
 
+// GetLastTransactionIndex returns the index of the last cluster-wide
+// transaction in a given database made through this store or 0 if there
+// were none. database is optional
+func (s *DocumentStore) GetLastTransactionIndex(database string) int64 {
+	if database == "" {
+		database = s.GetDatabase()
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.lastTransactionIndexPerDatabase[strings.ToLower(database)]
+}
+
+// SetLastTransactionIndex records the index of a cluster-wide transaction
+// in a given database. Indexes older than already known are ignored
+func (s *DocumentStore) SetLastTransactionIndex(database string, index int64) {
+	if index <= 0 {
+		return
+	}
+	if database == "" {
+		database = s.GetDatabase()
+	}
+	database = strings.ToLower(database)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.lastTransactionIndexPerDatabase == nil {
+		s.lastTransactionIndexPerDatabase = map[string]int64{}
+	}
+	if index > s.lastTransactionIndexPerDatabase[database] {
+		s.lastTransactionIndexPerDatabase[database] = index
+	}
+}
+
+// GetServerVersion returns version of the server (e.g. "5.4.107").
+// The version is taken from responses to previous requests. If there were
+// none yet, it's retrieved from the server
+func (s *DocumentStore) GetServerVersion() (string, error) {
+	if err := s.assertInitialized(); err != nil {
+		return "", err
+	}
+	if err := s.ensureNotClosed(); err != nil {
+		return "", err
+	}
+	re := s.GetRequestExecutor("")
+	if v := re.GetServerVersion(); v != "" {
+		return v, nil
+	}
+	cmd := NewGetBuildNumberCommand()
+	if err := re.ExecuteCommand(cmd, nil); err != nil {
+		return "", err
+	}
+	if v := re.GetServerVersion(); v != "" {
+		return v, nil
+	}
+	return cmd.Result.FullVersion, nil
+}
+
 // GetRequestExecutor gets a request executor.
-// database is optional
+// database is optional.
+// After the store is closed, the executor is closed as well and executing
+// commands with it returns StoreDisposedError
 func (s *DocumentStore) GetRequestExecutor(database string) *RequestExecutor {
 	must(s.assertInitialized())
 	if database == "" {
@@ -373,6 +541,12 @@ func (s *DocumentStore) GetRequestExecutor(database string) *RequestExecutor {
 		return executor
 	}
 
+	if s.isDisposed() {
+		executor = NewRequestExecutor(database, s.Certificate, s.TrustStore, s.GetConventions(), s.GetUrls())
+		executor.Close()
+		return executor
+	}
+
 	if !s.GetConventions().IsDisableTopologyUpdates() {
 		executor = RequestExecutorCreate(s.GetUrls(), database, s.Certificate, s.TrustStore, s.GetConventions())
 	} else {
@@ -380,16 +554,38 @@ func (s *DocumentStore) GetRequestExecutor(database string) *RequestExecutor {
 	}
 
 	s.mu.Lock()
-	s.requestsExecutors[database] = executor
+	existing, ok := s.requestsExecutors[database]
+	// Close sets disposed before collecting executors to close under mu,
+	// so checking it here guarantees we don't add an executor that is
+	// never closed
+	disposed := s.isDisposed()
+	if !ok && !disposed {
+		s.requestsExecutors[database] = executor
+	}
 	s.mu.Unlock()
 
+	if ok {
+		// another goroutine created it first, don't leak ours
+		executor.Close()
+		return existing
+	}
+	if disposed {
+		executor.Close()
+	}
 	return executor
 }
 
 // Initialize initializes document Store,
 // Must be called before executing any operation.
+// It's safe to call Initialize more than once and from multiple goroutines.
 func (s *DocumentStore) Initialize() error {
-	if s.initialized {
+	s.lifecycleMu.Lock()
+	defer s.lifecycleMu.Unlock()
+
+	if err := s.ensureNotClosed(); err != nil {
+		return err
+	}
+	if s.isInitialized() {
 		return nil
 	}
 	err := s.assertValidConfiguration()
@@ -406,14 +602,20 @@ func (s *DocumentStore) Initialize() error {
 		}
 		conventions.SetDocumentIDGenerator(genID)
 	}
-	s.initialized = true
+	atomic.StoreInt32(&s.initialized, 1)
 	return nil
 }
 
+// assertValidConfiguration validates and normalizes urls of the store
 func (s *DocumentStore) assertValidConfiguration() error {
 	if len(s.urls) == 0 {
 		return newIllegalArgumentError("Must provide urls to NewDocumentStore")
 	}
+	urls, err := requestExecutorValidateUrls(s.urls, s.Certificate)
+	if err != nil {
+		return err
+	}
+	s.urls = urls
 	return nil
 }
 
@@ -449,30 +651,44 @@ func (s *DocumentStore) Changes(database string) *DatabaseChanges {
 		database = s.GetDatabase()
 	}
 
+	key := strings.ToLower(database)
+
 	s.mu.Lock()
-	changes, ok := s.databaseChanges[database]
+	changes, ok := s.databaseChanges[key]
 	s.mu.Unlock()
+	if ok {
+		return changes
+	}
 
-	if !ok {
-		changes = s.createDatabaseChanges(database)
-
-		s.mu.Lock()
-		s.databaseChanges[database] = changes
-		s.mu.Unlock()
+	re := s.GetRequestExecutor(database)
+	if s.isDisposed() {
+		// re is closed so the connection fails with StoreDisposedError
+		return s.createDatabaseChanges(re, database)
+	}
 
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if changes, ok = s.databaseChanges[key]; ok {
+		return changes
 	}
+	changes = s.createDatabaseChanges(re, database)
+	s.databaseChanges[key] = changes
 	return changes
 }
 
-func (s *DocumentStore) createDatabaseChanges(database string) *DatabaseChanges {
+func (s *DocumentStore) createDatabaseChanges(re *RequestExecutor, database string) *DatabaseChanges {
 	panicIf(database == "", "database can't be empty string")
+	key := strings.ToLower(database)
+	var changes *DatabaseChanges
 	onDispose := func() {
 		s.mu.Lock()
-		delete(s.databaseChanges, database)
+		if s.databaseChanges[key] == changes {
+			delete(s.databaseChanges, key)
+		}
 		s.mu.Unlock()
 	}
-	re := s.GetRequestExecutor(database)
-	return newDatabaseChanges(re, database, onDispose)
+	changes = newDatabaseChanges(re, database, onDispose)
+	return changes
 }
 
 func (s *DocumentStore) GetLastDatabaseChangesStateError(database string) error {
@@ -481,7 +697,7 @@ func (s *DocumentStore) GetLastDatabaseChangesStateError(database string) error
 	}
 
 	s.mu.Lock()
-	databaseChanges, ok := s.databaseChanges[database]
+	databaseChanges, ok := s.databaseChanges[strings.ToLower(database)]
 	s.mu.Unlock()
 
 	if !ok {
@@ -546,22 +762,41 @@ func (s *DocumentStore) listenToChangesAndUpdateTheCache(database string) error
 	return nil
 }
 
+// AddBeforeCloseListener registers a function called by Close before
+// the store releases its resources.
+// Returns listener id that can be passed to RemoveBeforeCloseListener
 func (s *DocumentStore) AddBeforeCloseListener(fn func(*DocumentStore)) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	s.beforeClose = append(s.beforeClose, fn)
 	return len(s.beforeClose) - 1
 }
 
 func (s *DocumentStore) RemoveBeforeCloseListener(idx int) {
-	s.beforeClose[idx] = nil
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if idx >= 0 && idx < len(s.beforeClose) {
+		s.beforeClose[idx] = nil
+	}
 }
 
+// AddAfterCloseListener registers a function called by Close after
+// the store is closed. Listeners are called in the order they were added
+// and can be used to release resources that depend on the store.
+// Returns listener id that can be passed to RemoveAfterCloseListener
 func (s *DocumentStore) AddAfterCloseListener(fn func(*DocumentStore)) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	s.afterClose = append(s.afterClose, fn)
 	return len(s.afterClose) - 1
 }
 
 func (s *DocumentStore) RemoveAfterCloseListener(idx int) {
-	s.afterClose[idx] = nil
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if idx >= 0 && idx < len(s.afterClose) {
+		s.afterClose[idx] = nil
+	}
 }
 
 func (s *DocumentStore) Maintenance() *MaintenanceOperationExecutor {
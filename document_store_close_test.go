@@ -0,0 +1,229 @@
+package ravendb
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newSlowQueryServer returns a server that answers queries after delay.
+// requestStarted is signaled when a query request arrives
+func newSlowQueryServer(delay time.Duration, requestStarted chan struct{}) *httptest.Server {
+	fn := func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/databases/db/queries" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		requestStarted <- struct{}{}
+		time.Sleep(delay)
+		_, _ = w.Write([]byte(`{"Results":[],"Includes":{},"IndexName":"Users","TotalResults":0}`))
+	}
+	return httptest.NewServer(http.HandlerFunc(fn))
+}
+
+func startSlowQuery(t *testing.T, store *DocumentStore) (*int32, chan error) {
+	session, err := store.OpenSession("")
+	require.NoError(t, err)
+
+	var finished int32
+	chErr := make(chan error, 1)
+	go func() {
+		var results []*User
+		err := session.QueryIndex("Users").GetResults(&results)
+		atomic.StoreInt32(&finished, 1)
+		chErr <- err
+	}()
+	return &finished, chErr
+}
+
+func TestDocumentStoreCloseWaitsForInFlightRequests(t *testing.T) {
+	requestStarted := make(chan struct{}, 1)
+	srv := newSlowQueryServer(time.Millisecond*300, requestStarted)
+	defer srv.Close()
+
+	store := newTestStore(t, srv.URL)
+	afterCloseCalled := false
+	store.AddAfterCloseListener(func(*DocumentStore) {
+		afterCloseCalled = true
+	})
+
+	finished, chErr := startSlowQuery(t, store)
+	<-requestStarted
+
+	err := store.Close()
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(finished))
+	assert.NoError(t, <-chErr)
+	assert.True(t, afterCloseCalled)
+
+	// closing again is a no-op
+	assert.NoError(t, store.Close())
+
+	_, err = store.OpenSession("")
+	assert.Error(t, err)
+}
+
+func TestDocumentStoreCloseTimesOut(t *testing.T) {
+	requestStarted := make(chan struct{}, 1)
+	srv := newSlowQueryServer(time.Millisecond*500, requestStarted)
+	defer srv.Close()
+
+	store := newTestStore(t, srv.URL)
+	store.GetConventions().CloseTimeout = time.Millisecond * 50
+
+	_, chErr := startSlowQuery(t, store)
+	<-requestStarted
+
+	err := store.Close()
+	_, ok := err.(*TimeoutError)
+	assert.True(t, ok, "expected *TimeoutError, got %T (%v)", err, err)
+	<-chErr
+}
+
+// waitForGoroutines waits until the number of goroutines drops to n
+func waitForGoroutines(n int, timeout time.Duration) int {
+	deadline := time.Now().Add(timeout)
+	for {
+		current := runtime.NumGoroutine()
+		if current <= n || time.Now().After(deadline) {
+			return current
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestDocumentStoreCloseReleasesRequestExecutors(t *testing.T) {
+	fn := func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"Results": [], "Includes": {}}`))
+	}
+	srv := httptest.NewServer(http.HandlerFunc(fn))
+	defer srv.Close()
+
+	before := runtime.NumGoroutine()
+	store := newTestStore(t, srv.URL)
+
+	// executors are cached by case-insensitive database name, also
+	// when requested concurrently
+	var wg sync.WaitGroup
+	executors := make([]*RequestExecutor, 10)
+	for i := range executors {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			executors[i] = store.GetRequestExecutor("DB1")
+		}(i)
+	}
+	wg.Wait()
+	for _, re := range executors {
+		assert.Equal(t, executors[0], re)
+	}
+	assert.Equal(t, executors[0], store.GetRequestExecutor("db1"))
+
+	for _, database := range []string{"db1", "db2", "db3"} {
+		session, err := store.OpenSession(database)
+		require.NoError(t, err)
+		assert.Equal(t, database, session.GetDatabaseName())
+		assert.Equal(t, store, session.GetDocumentStore())
+		var user *User
+		require.NoError(t, session.Load(&user, "users/1"))
+		session.Close()
+	}
+	assert.Equal(t, 3, len(store.getRequestExecutors()))
+
+	require.NoError(t, store.Close())
+	require.NoError(t, store.Close())
+
+	after := waitForGoroutines(before, time.Second*5)
+	assert.True(t, after <= before, "goroutines before: %d, after: %d", before, after)
+}
+
+func TestDocumentStoreConcurrentInitializeAndClose(t *testing.T) {
+	fn := func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"Results": [], "Includes": {}}`))
+	}
+	srv := httptest.NewServer(http.HandlerFunc(fn))
+	defer srv.Close()
+
+	for i := 0; i < 20; i++ {
+		store := NewDocumentStore([]string{srv.URL}, "db")
+		store.GetConventions().SetDisableTopologyUpdates(true)
+
+		var wg sync.WaitGroup
+		for j := 0; j < 8; j++ {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				if err := store.Initialize(); err != nil {
+					assert.IsType(t, &StoreDisposedError{}, err)
+					return
+				}
+				if session, err := store.OpenSession(""); err == nil {
+					var user *User
+					_ = session.Load(&user, "users/1")
+					session.Close()
+				}
+			}()
+			go func() {
+				defer wg.Done()
+				assert.NoError(t, store.Close())
+			}()
+		}
+		wg.Wait()
+		assert.True(t, store.isDisposed())
+	}
+}
+
+func TestDocumentStoreOperationsAfterClose(t *testing.T) {
+	fn := func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"Results": [], "Includes": {}}`))
+	}
+	srv := httptest.NewServer(http.HandlerFunc(fn))
+	defer srv.Close()
+
+	store := newTestStore(t, srv.URL)
+	var calls []string
+	store.AddAfterCloseListener(func(*DocumentStore) {
+		calls = append(calls, "first")
+	})
+	idx := store.AddAfterCloseListener(func(*DocumentStore) {
+		calls = append(calls, "removed")
+	})
+	store.RemoveAfterCloseListener(idx)
+	store.AddAfterCloseListener(func(*DocumentStore) {
+		calls = append(calls, "last")
+	})
+
+	session, err := store.OpenSession("")
+	require.NoError(t, err)
+	require.NoError(t, store.Close())
+	require.NoError(t, store.Close())
+	assert.Equal(t, []string{"first", "last"}, calls)
+
+	var user *User
+	err = session.Load(&user, "users/1")
+	assert.IsType(t, &StoreDisposedError{}, err)
+
+	_, err = store.OpenSession("")
+	assert.IsType(t, &StoreDisposedError{}, err)
+
+	err = store.Initialize()
+	assert.IsType(t, &StoreDisposedError{}, err)
+
+	_, err = store.GetServerVersion()
+	assert.IsType(t, &StoreDisposedError{}, err)
+
+	// executors for other databases are not created after Close
+	err = store.Maintenance().ForDatabase("other").Send(NewGetStatisticsOperation(""))
+	assert.IsType(t, &StoreDisposedError{}, err)
+	assert.Equal(t, 1, len(store.getRequestExecutors()))
+
+	err = store.Changes("").EnsureConnectedNow()
+	assert.IsType(t, &StoreDisposedError{}, err)
+}
@@ -188,7 +188,7 @@ func (s *DocumentSubscriptions) GetSubscriptionState(subscriptionName string, da
 	}
 	requestExecutor := s.store.GetRequestExecutor(database)
 
-	command := newGetSubscriptionStateCommand(subscriptionName)
+	command := NewGetSubscriptionStateCommand(subscriptionName)
 	if err := requestExecutor.ExecuteCommand(command, nil); err != nil {
 		return nil, err
 	}
@@ -213,11 +213,15 @@ func (s *DocumentSubscriptions) Close() error {
 
 // DropConnection forces server to close current client subscription connection to the server
 func (s *DocumentSubscriptions) DropConnection(name string, database string) error {
+	if name == "" {
+		return newIllegalArgumentError("SubscriptionName cannot be null")
+	}
+
 	if database == "" {
 		database = s.store.GetDatabase()
 	}
 	requestExecutor := s.store.GetRequestExecutor(database)
 
-	command := newDropSubscriptionConnectionCommand(name)
+	command := NewDropSubscriptionConnectionCommand(name)
 	return requestExecutor.ExecuteCommand(command, nil)
 }
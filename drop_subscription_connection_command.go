@@ -15,7 +15,7 @@ type DropSubscriptionConnectionCommand struct {
 	name string
 }
 
-func newDropSubscriptionConnectionCommand(name string) *DropSubscriptionConnectionCommand {
+func NewDropSubscriptionConnectionCommand(name string) *DropSubscriptionConnectionCommand {
 	cmd := &DropSubscriptionConnectionCommand{
 		RavenCommandBase: NewRavenCommandBase(),
 
@@ -0,0 +1,28 @@
+package ravendb
+
+var (
+	_ IMaintenanceOperation = &DropSubscriptionConnectionOperation{}
+)
+
+// DropSubscriptionConnectionOperation makes the server close the connection
+// of a worker of a subscription, so that another worker can connect right
+// away instead of waiting for the old connection to time out
+type DropSubscriptionConnectionOperation struct {
+	subscriptionName string
+
+	Command *DropSubscriptionConnectionCommand
+}
+
+func NewDropSubscriptionConnectionOperation(subscriptionName string) (*DropSubscriptionConnectionOperation, error) {
+	if subscriptionName == "" {
+		return nil, newIllegalArgumentError("SubscriptionName cannot be null")
+	}
+	return &DropSubscriptionConnectionOperation{
+		subscriptionName: subscriptionName,
+	}, nil
+}
+
+func (o *DropSubscriptionConnectionOperation) GetCommand(conventions *DocumentConventions) (RavenCommand, error) {
+	o.Command = NewDropSubscriptionConnectionCommand(o.subscriptionName)
+	return o.Command, nil
+}
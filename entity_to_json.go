@@ -25,18 +25,22 @@ func (e *entityToJSON) getMissingDictionary() map[interface{}]map[string]interfa
 	return e.missingDictionary
 }
 
-func convertEntityToJSON(entity interface{}, documentInfo *documentInfo) map[string]interface{} {
+// conventions can be nil, in which case default JSON serializer is used
+func convertEntityToJSON(conventions *DocumentConventions, entity interface{}, documentInfo *documentInfo) (map[string]interface{}, error) {
 	// maybe we don't need to do anything?
 	if v, ok := entity.(map[string]interface{}); ok {
-		return v
+		return v, nil
+	}
+	jsonNode, err := entityToJSONMap(conventions, entity)
+	if err != nil {
+		return nil, err
 	}
-	jsonNode := structToJSONMap(entity)
 
 	entityToJSONWriteMetadata(jsonNode, documentInfo)
 
 	tryRemoveIdentityProperty(jsonNode)
 
-	return jsonNode
+	return jsonNode, nil
 }
 
 // TODO: verify is correct, write a test
@@ -107,7 +111,7 @@ func (e *entityToJSON) convertToEntity2(result interface{}, id string, document
 		return setInterfaceToValue(result, document)
 	}
 	entityType := reflect.TypeOf(result)
-	entity, err := makeStructFromJSONMap(entityType, document)
+	entity, err := entityFromJSONMap(e.session.GetConventions(), entityType, document)
 	if err != nil {
 		// fmt.Printf("makeStructFromJSONMap() failed with %s\n. Wanted type: %s, document: %v\n", err, entityType, document)
 		return err
@@ -126,7 +130,7 @@ func (e *entityToJSON) convertToEntity(entityType reflect.Type, id string, docum
 	if isTypeObjectNode(entityType) {
 		return document, nil
 	}
-	entity, err := makeStructFromJSONMap(entityType, document)
+	entity, err := entityFromJSONMap(e.session.GetConventions(), entityType, document)
 	if err != nil {
 		return nil, err
 	}
@@ -134,11 +138,11 @@ func (e *entityToJSON) convertToEntity(entityType reflect.Type, id string, docum
 	return entity, nil
 }
 
-func entityToJSONConvertToEntity(entityType reflect.Type, id string, document map[string]interface{}) (interface{}, error) {
+func entityToJSONConvertToEntity(conventions *DocumentConventions, entityType reflect.Type, id string, document map[string]interface{}) (interface{}, error) {
 	if isTypeObjectNode(entityType) {
 		return document, nil
 	}
-	entity, err := makeStructFromJSONMap(entityType, document)
+	entity, err := entityFromJSONMap(conventions, entityType, document)
 	if err != nil {
 		return nil, err
 	}
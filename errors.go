@@ -1,10 +1,18 @@
 package ravendb
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 )
 
+var (
+	// ErrNoResults is returned by First if query returns no results
+	ErrNoResults = errors.New("query returned no results")
+	// ErrInsufficientResults is returned by Second if query returns less than 2 results
+	ErrInsufficientResults = errors.New("query returned less than 2 results")
+)
+
 type CancellationError struct {
 }
 
@@ -27,6 +35,12 @@ func (e *errorBase) WrappedError() error {
 	return e.wrapped
 }
 
+// Unwrap returns the wrapped error so that errors.Is and errors.As can
+// inspect it
+func (e *errorBase) Unwrap() error {
+	return e.wrapped
+}
+
 type iWrappedError interface {
 	WrappedError() error
 }
@@ -89,6 +103,18 @@ func newIllegalStateError(format string, args ...interface{}) *IllegalStateError
 	return res
 }
 
+// StoreDisposedError is returned when using DocumentStore, or its
+// RequestExecutor, after it has been closed
+type StoreDisposedError struct {
+	errorBase
+}
+
+func newStoreDisposedError(format string, args ...interface{}) *StoreDisposedError {
+	res := &StoreDisposedError{}
+	res.setErrorf(format, args...)
+	return res
+}
+
 // IllegalArgumentError represents illegal argument error
 type IllegalArgumentError struct {
 	errorBase
@@ -38,4 +38,11 @@ func TestErrorWrapping(t *testing.T) {
 		assert.Equal(t, "message", err.Error())
 	}
 
+	{
+		err := exceptionDispatherMakeErrorFromType("Raven.Client.Exceptions.Documents.Indexes.IndexDoesNotExistException", "no index")
+		_, ok := err.(*IndexDoesNotExistError)
+		assert.True(t, ok)
+		assert.Equal(t, "no index", err.Error())
+	}
+
 }
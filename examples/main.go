@@ -510,7 +510,7 @@ func queryIndex() {
 	}
 }
 
-// shows how to use First() to get first result
+// shows how to use FirstOrDefault() to get first result
 func queryFirst() {
 	store, session, err := openSession(dbName)
 	if err != nil {
@@ -524,12 +524,12 @@ func queryFirst() {
 	printRQL(q)
 
 	var first *northwind.Employee
-	err = q.First(&first)
+	found, err := q.FirstOrDefault(&first)
 	if err != nil {
-		log.Fatalf("q.First() failed with '%s'\n", err)
+		log.Fatalf("q.FirstOrDefault() failed with '%s'\n", err)
 	}
 	// if there are no matching results, first will be unchanged (i.e. equal to nil)
-	if first != nil {
+	if found {
 		fmt.Print("First() returned:\n")
 		pretty.Print(first)
 	}
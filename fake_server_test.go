@@ -0,0 +1,31 @@
+package ravendb
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// newTestStore returns an initialized store for database "db" on a server
+// with a given url. Topology updates are disabled so that the store only
+// talks to that url
+func newTestStore(t *testing.T, url string) *DocumentStore {
+	store := NewDocumentStore([]string{url}, "db")
+	store.GetConventions().SetDisableTopologyUpdates(true)
+	require.NoError(t, store.Initialize())
+	return store
+}
+
+// newFakeServerStore starts a server that answers requests with fn and
+// returns a store connected to it. Both are closed when the test ends
+func newFakeServerStore(t *testing.T, fn http.HandlerFunc) *DocumentStore {
+	srv := httptest.NewServer(fn)
+	t.Cleanup(srv.Close)
+	store := newTestStore(t, srv.URL)
+	t.Cleanup(func() {
+		_ = store.Close()
+	})
+	return store
+}
@@ -0,0 +1,17 @@
+package ravendb
+
+import "strings"
+
+var _ queryToken = &falseToken{}
+
+var falseTokenInstance = &falseToken{}
+
+// falseToken matches no documents.
+// RQL doesn't have a false literal so we negate true
+type falseToken struct {
+}
+
+func (t *falseToken) writeTo(writer *strings.Builder) error {
+	writer.WriteString("(true and not true)")
+	return nil
+}
@@ -0,0 +1,56 @@
+package ravendb
+
+import (
+	"net/http"
+)
+
+var (
+	_ IServerOperation = &GetBuildNumberOperation{}
+)
+
+// GetBuildNumberOperation returns version of the server
+type GetBuildNumberOperation struct {
+	Command *GetBuildNumberCommand
+}
+
+// NewGetBuildNumberOperation returns new GetBuildNumberOperation
+func NewGetBuildNumberOperation() *GetBuildNumberOperation {
+	return &GetBuildNumberOperation{}
+}
+
+// GetCommand returns a command for this operation
+func (o *GetBuildNumberOperation) GetCommand(conventions *DocumentConventions) (RavenCommand, error) {
+	o.Command = NewGetBuildNumberCommand()
+	return o.Command, nil
+}
+
+var _ RavenCommand = &GetBuildNumberCommand{}
+
+// GetBuildNumberCommand represents "get build number" command
+type GetBuildNumberCommand struct {
+	RavenCommandBase
+
+	Result *BuildNumber
+}
+
+// NewGetBuildNumberCommand returns new GetBuildNumberCommand
+func NewGetBuildNumberCommand() *GetBuildNumberCommand {
+	cmd := &GetBuildNumberCommand{
+		RavenCommandBase: NewRavenCommandBase(),
+	}
+	cmd.IsReadRequest = true
+	return cmd
+}
+
+func (c *GetBuildNumberCommand) CreateRequest(node *ServerNode) (*http.Request, error) {
+	url := node.URL + "/build/version"
+	return newHttpGet(url)
+}
+
+func (c *GetBuildNumberCommand) SetResponse(response []byte, fromCache bool) error {
+	if len(response) == 0 {
+		return throwInvalidResponse()
+	}
+
+	return jsonUnmarshal(response, &c.Result)
+}
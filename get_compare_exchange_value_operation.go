@@ -62,7 +62,7 @@ func NewGetCompareExchangeValueCommand(clazz reflect.Type, key string, conventio
 }
 
 func (c *GetCompareExchangeValueCommand) CreateRequest(node *ServerNode) (*http.Request, error) {
-	url := node.URL + "/databases/" + node.Database + "/cmpxchg?key=" + urlEncode(c._key)
+	url := node.URL + "/databases/" + node.Database + "/cmpxchg?key=" + urlUtilsEscapeDataString(c._key)
 	return newHttpGet(url)
 
 }
@@ -29,7 +29,7 @@ func NewGetConflictsCommand(id string) *GetConflictsCommand {
 }
 
 func (c *GetConflictsCommand) CreateRequest(node *ServerNode) (*http.Request, error) {
-	url := node.URL + "/databases/" + node.Database + "/replication/conflicts?docId=" + c._id
+	url := node.URL + "/databases/" + node.Database + "/replication/conflicts?docId=" + urlUtilsEscapeDataString(c._id)
 
 	return newHttpGet(url)
 }
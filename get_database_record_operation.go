@@ -47,7 +47,7 @@ func NewGetDatabaseRecordCommand(conventions *DocumentConventions, database stri
 }
 
 func (c *GetDatabaseRecordCommand) CreateRequest(node *ServerNode) (*http.Request, error) {
-	url := node.URL + "/admin/databases?name=" + c.database
+	url := node.URL + "/admin/databases?name=" + urlUtilsEscapeDataString(c.database)
 	return newHttpGet(url)
 }
 
@@ -24,7 +24,7 @@ func NewGetDatabaseTopologyCommand() *GetDatabaseTopologyCommand {
 }
 
 func (c *GetDatabaseTopologyCommand) CreateRequest(node *ServerNode) (*http.Request, error) {
-	url := node.URL + "/topology?name=" + node.Database
+	url := node.URL + "/topology?name=" + urlUtilsEscapeDataString(node.Database)
 	if strings.Contains(strings.ToLower(node.URL), ".fiddler") {
 		// we want to keep the '.fiddler' stuff there so we'll keep tracking request
 		// so we are going to ask the server to respect it
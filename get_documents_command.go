@@ -17,6 +17,9 @@ type GetDocumentsCommand struct {
 	_ids      []string
 	_includes []string
 
+	_counterIncludes    []string
+	_timeSeriesIncludes []*TimeSeriesRange
+
 	_metadataOnly bool
 
 	_startWith  string
@@ -52,6 +55,43 @@ func NewGetDocumentsCommand(ids []string, includes []string, metadataOnly bool)
 	return cmd, nil
 }
 
+// NewGetDocumentsCommandWithIncludes returns a command that loads documents
+// together with related documents, counters and time series entries
+func NewGetDocumentsCommandWithIncludes(ids []string, includes []string, counterIncludes []string, timeSeriesIncludes []*TimeSeriesRange, metadataOnly bool) (*GetDocumentsCommand, error) {
+	for _, ts := range timeSeriesIncludes {
+		if ts == nil || ts.Name == "" {
+			return nil, newIllegalArgumentError("Time series name cannot be empty")
+		}
+	}
+	cmd, err := NewGetDocumentsCommand(ids, includes, metadataOnly)
+	if err != nil {
+		return nil, err
+	}
+	cmd._counterIncludes = counterIncludes
+	cmd._timeSeriesIncludes = timeSeriesIncludes
+	return cmd, nil
+}
+
+// NewGetDocumentsCommandRange returns a command that loads pageSize
+// documents from the database, skipping the first start documents
+func NewGetDocumentsCommandRange(start int, pageSize int, metadataOnly bool) (*GetDocumentsCommand, error) {
+	if start < 0 {
+		return nil, newIllegalArgumentError("start cannot be negative")
+	}
+	if pageSize <= 0 {
+		return nil, newIllegalArgumentError("pageSize must be positive")
+	}
+	cmd := &GetDocumentsCommand{
+		RavenCommandBase: NewRavenCommandBase(),
+
+		_start:        start,
+		_pageSize:     pageSize,
+		_metadataOnly: metadataOnly,
+	}
+	cmd.IsReadRequest = true
+	return cmd, nil
+}
+
 func NewGetDocumentsCommandFull(startWith string, startAfter string, matches string, exclude string, start int, pageSize int, metadataOnly bool) (*GetDocumentsCommand, error) {
 	if startWith == "" {
 		return nil, newIllegalArgumentError("startWith cannot be null")
@@ -69,6 +109,17 @@ func NewGetDocumentsCommandFull(startWith string, startAfter string, matches str
 	}, nil
 }
 
+func (c *GetDocumentsCommand) requiredServerFeatures() []*serverFeature {
+	var res []*serverFeature
+	if len(c._counterIncludes) > 0 {
+		res = append(res, serverFeatureCounters)
+	}
+	if len(c._timeSeriesIncludes) > 0 {
+		res = append(res, serverFeatureTimeSeries)
+	}
+	return res
+}
+
 func (c *GetDocumentsCommand) CreateRequest(node *ServerNode) (*http.Request, error) {
 	url := node.URL + "/databases/" + node.Database + "/docs?"
 	if c._start > 0 {
@@ -89,23 +140,34 @@ func (c *GetDocumentsCommand) CreateRequest(node *ServerNode) (*http.Request, er
 
 		if c._matches != "" {
 			url += "&matches="
-			url += c._matches
+			url += urlUtilsEscapeDataString(c._matches)
 		}
 
 		if c._exclude != "" {
 			url += "&exclude="
-			url += c._exclude
+			url += urlUtilsEscapeDataString(c._exclude)
 		}
 
 		if c._startAfter != "" {
 			url += "&startAfter="
-			url += c._startAfter
+			url += urlUtilsEscapeDataString(c._startAfter)
 		}
 	}
 
 	for _, include := range c._includes {
 		url += "&include="
-		url += include
+		url += urlUtilsEscapeDataString(include)
+	}
+
+	for _, counter := range c._counterIncludes {
+		url += "&counter="
+		url += urlUtilsEscapeDataString(counter)
+	}
+
+	for _, ts := range c._timeSeriesIncludes {
+		url += "&timeseries=" + urlUtilsEscapeDataString(ts.Name)
+		url += "&from=" + formatTimeSeriesRangeTime(ts.From)
+		url += "&to=" + formatTimeSeriesRangeTime(ts.To)
 	}
 
 	if c._id != "" {
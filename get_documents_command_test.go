@@ -0,0 +1,88 @@
+package ravendb
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetDocumentsCommandWithIncludes(t *testing.T) {
+	from := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	timeSeries := []*TimeSeriesRange{
+		{Name: "Heart Rate", From: &from},
+	}
+	cmd, err := NewGetDocumentsCommandWithIncludes([]string{"users/1"}, []string{"Friend"}, []string{"likes", "dislikes"}, timeSeries, false)
+	require.NoError(t, err)
+
+	js := `{"Results":[{"@metadata":{"@id":"users/1"}}],"Includes":{},` +
+		`"CounterIncludes":{"users/1":[{"CounterName":"likes","TotalValue":3}]},` +
+		`"TimeSeriesIncludes":{"users/1":{"Heart Rate":[]}}}`
+	require.NoError(t, cmd.SetResponse([]byte(js), false))
+	assert.NotNil(t, cmd.Result.CounterIncludes["users/1"])
+	assert.NotNil(t, cmd.Result.TimeSeriesIncludes["users/1"])
+}
+
+func makeUserIDs(n int) []string {
+	var ids []string
+	for i := 0; i < n; i++ {
+		ids = append(ids, fmt.Sprintf("users/%d", i))
+	}
+	return ids
+}
+
+func TestGetDocumentsCommandManyIdsMetadataOnly(t *testing.T) {
+	cmd, err := NewGetDocumentsCommand(makeUserIDs(1500), nil, true)
+	require.NoError(t, err)
+
+	req, body := createTestRequest(t, cmd)
+	assert.Equal(t, http.MethodPost, req.Method)
+	assert.True(t, strings.HasPrefix(req.URL.String(), "http://127.0.0.1:8080/databases/db/docs?&metadataOnly=true&loadHash="))
+
+	var payload struct {
+		Ids []string
+	}
+	require.NoError(t, json.Unmarshal(body, &payload))
+	assert.Equal(t, 1500, len(payload.Ids))
+}
+
+func TestSessionLoadMetadataOnly(t *testing.T) {
+	var methods []string
+	fn := func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/databases/db/docs" || r.URL.Query().Get("metadataOnly") != "true" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		methods = append(methods, r.Method)
+		_, _ = w.Write([]byte(`{"Results":[` +
+			`{"@metadata":{"@id":"users/1","@collection":"Users","@change-vector":"A:1"}},` +
+			`null,` +
+			`{"@metadata":{"@id":"users/2","@collection":"Users","@change-vector":"A:2","@flags":"HasAttachments"}}` +
+			`],"Includes":{}}`))
+	}
+	store := newFakeServerStore(t, fn)
+	session, err := store.OpenSession("")
+	require.NoError(t, err)
+	defer session.Close()
+
+	metadata, err := session.Advanced().LoadMetadataOnly(makeUserIDs(1500))
+	require.NoError(t, err)
+	assert.Equal(t, []string{http.MethodPost}, methods)
+	require.Equal(t, 2, len(metadata))
+	changeVector, ok := metadata["users/2"].Get(MetadataChangeVector)
+	assert.True(t, ok)
+	assert.Equal(t, "A:2", changeVector)
+	assert.Nil(t, metadata["users/0"])
+
+	// metadata is not tracked by the session
+	assert.Equal(t, 0, session.GetNumberOfEntitiesInUnitOfWork())
+	assert.Equal(t, 1, session.GetNumberOfRequests())
+
+	_, err = session.Advanced().LoadMetadataOnly(nil)
+	assert.Error(t, err)
+}
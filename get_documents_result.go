@@ -5,4 +5,11 @@ type GetDocumentsResult struct {
 	Includes      map[string]interface{}   `json:"Includes"`
 	Results       []map[string]interface{} `json:"Results"`
 	NextPageStart int                      `json:"NextPageStart"`
+
+	// CounterIncludes maps document id to its counters. A counter that
+	// doesn't exist is nil
+	CounterIncludes map[string][]*CounterDetail `json:"CounterIncludes"`
+	// TimeSeriesIncludes maps document id to time series name to
+	// ranges of entries
+	TimeSeriesIncludes map[string]map[string][]*TimeSeriesRangeResult `json:"TimeSeriesIncludes"`
 }
@@ -48,12 +48,10 @@ func NewGetIndexErrorsCommand(indexNames []string) *GetIndexErrorsCommand {
 func (c *GetIndexErrorsCommand) CreateRequest(node *ServerNode) (*http.Request, error) {
 	url := node.URL + "/databases/" + node.Database + "/indexes/errors"
 
-	if len(c.indexNames) > 0 {
-		url += "?"
-
-		for _, indexName := range c.indexNames {
-			url += "&name=" + indexName
-		}
+	sep := "?"
+	for _, indexName := range c.indexNames {
+		url += sep + "name=" + urlUtilsEscapeDataString(indexName)
+		sep = "&"
 	}
 
 	return newHttpGet(url)
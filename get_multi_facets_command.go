@@ -0,0 +1,106 @@
+package ravendb
+
+import (
+	"net/http"
+)
+
+var _ RavenCommand = &GetMultiFacetsCommand{}
+
+// GetMultiFacetsCommand sends multiple aggregation (facet) queries
+// to the server in a single request
+type GetMultiFacetsCommand struct {
+	RavenCommandBase
+
+	conventions *DocumentConventions
+	queries     []*IndexQuery
+
+	// QueryResults are raw results, in the same order as queries
+	QueryResults []*QueryResult
+	// Result has facet results keyed by facet name for each query,
+	// in the same order as queries
+	Result []map[string]*FacetResult
+}
+
+// NewGetMultiFacetsCommand returns a command executing given aggregation queries
+func NewGetMultiFacetsCommand(conventions *DocumentConventions, queries []*IndexQuery) (*GetMultiFacetsCommand, error) {
+	if len(queries) == 0 {
+		return nil, newIllegalArgumentError("Queries cannot be empty")
+	}
+	for _, query := range queries {
+		if query == nil {
+			return nil, newIllegalArgumentError("Query cannot be null")
+		}
+	}
+	cmd := &GetMultiFacetsCommand{
+		RavenCommandBase: NewRavenCommandBase(),
+
+		conventions: conventions,
+		queries:     queries,
+	}
+	cmd.IsReadRequest = true
+	return cmd, nil
+}
+
+func (c *GetMultiFacetsCommand) CreateRequest(node *ServerNode) (*http.Request, error) {
+	var requests []map[string]interface{}
+	for _, query := range c.queries {
+		v := map[string]interface{}{
+			"Url":     "/databases/" + node.Database + "/queries",
+			"Query":   "?queryHash=" + query.GetQueryHash(),
+			"Method":  http.MethodPost,
+			"Headers": map[string]string{},
+			"Content": jsonExtensionsWriteIndexQuery(c.conventions, query),
+		}
+		requests = append(requests, v)
+	}
+
+	m := map[string]interface{}{
+		"Requests": requests,
+	}
+	d, err := jsonMarshal(m)
+	if err != nil {
+		return nil, err
+	}
+
+	url := node.URL + "/databases/" + node.Database + "/multi_get"
+	return NewHttpPost(url, d)
+}
+
+func (c *GetMultiFacetsCommand) SetResponse(response []byte, fromCache bool) error {
+	if len(response) == 0 {
+		return throwInvalidResponse()
+	}
+
+	var res *resultsJSON
+	if err := jsonUnmarshal(response, &res); err != nil {
+		return err
+	}
+	if len(res.Results) != len(c.queries) {
+		return newIllegalStateError("Expected %d results, got %d", len(c.queries), len(res.Results))
+	}
+
+	c.QueryResults = nil
+	c.Result = nil
+	for _, rsp := range res.Results {
+		getResponse := &GetResponse{
+			StatusCode: rsp.StatusCode,
+			Headers:    rsp.Headers,
+			Result:     rsp.Result,
+		}
+		if getResponse.requestHasErrors() || getResponse.StatusCode == http.StatusNotFound {
+			return newIllegalStateError("Got an error from server, status code: %d\n%s", getResponse.StatusCode, getResponse.Result)
+		}
+
+		var queryResult *QueryResult
+		if err := jsonUnmarshal(getResponse.Result, &queryResult); err != nil {
+			return err
+		}
+		facets, err := facetResultsFromQueryResult(queryResult)
+		if err != nil {
+			return err
+		}
+		c.QueryResults = append(c.QueryResults, queryResult)
+		c.Result = append(c.Result, facets)
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package ravendb
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetMultiFacetsCommand(t *testing.T) {
+	queries := []*IndexQuery{
+		NewIndexQuery("from index 'Orders' select facet(product)"),
+		NewIndexQuery("from index 'Orders' select facet(currency)"),
+	}
+	cmd, err := NewGetMultiFacetsCommand(NewDocumentConventions(), queries)
+	require.NoError(t, err)
+
+	req, d := createTestRequest(t, cmd)
+	assert.Equal(t, "/databases/db/multi_get", req.URL.Path)
+	var body struct {
+		Requests []struct {
+			Url     string
+			Method  string
+			Content map[string]interface{}
+		}
+	}
+	require.NoError(t, json.Unmarshal(d, &body))
+	require.Equal(t, 2, len(body.Requests))
+	assert.Equal(t, "/databases/db/queries", body.Requests[0].Url)
+	assert.Equal(t, "POST", body.Requests[0].Method)
+	assert.Equal(t, "from index 'Orders' select facet(currency)", body.Requests[1].Content["Query"])
+
+	response := `{"Results": [
+	{"StatusCode": 200, "Result": {"Results": [{"Name": "product", "Values": [{"Range": "milk", "Count": 2}]}]}},
+	{"StatusCode": 200, "Result": {"Results": [{"Name": "currency", "Values": [{"Range": "eur", "Count": 1}]}]}}
+]}`
+	require.NoError(t, cmd.SetResponse([]byte(response), false))
+	require.Equal(t, 2, len(cmd.Result))
+	assert.Equal(t, 2, cmd.Result[0]["product"].Values[0].Count)
+	assert.Equal(t, "eur", cmd.Result[1]["currency"].Values[0].Range)
+
+	response = `{"Results": [
+	{"StatusCode": 200, "Result": {"Results": []}},
+	{"StatusCode": 500, "Result": {"Error": "boom"}}
+]}`
+	assert.Error(t, cmd.SetResponse([]byte(response), false))
+}
@@ -34,6 +34,9 @@ func (c *GetNextOperationIDCommand) CreateRequest(node *ServerNode) (*http.Reque
 }
 
 func (c *GetNextOperationIDCommand) SetResponse(response []byte, fromCache bool) error {
+	if len(response) == 0 {
+		return throwInvalidResponse()
+	}
 	var res _GetNextOperationIDCommandResponse
 	err := jsonUnmarshal(response, &res)
 	if err != nil {
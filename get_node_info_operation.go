@@ -0,0 +1,74 @@
+package ravendb
+
+import (
+	"net/http"
+)
+
+var (
+	_ IServerOperation = &GetNodeInfoOperation{}
+)
+
+// NodeInfo describes a single node of a RavenDB cluster, as returned by
+// /cluster/node-info. It's cheap to fetch and doesn't require admin
+// privileges, which makes it suitable for health checks
+type NodeInfo struct {
+	NodeTag             string       `json:"NodeTag"`
+	TopologyID          string       `json:"TopologyId"`
+	ServerID            string       `json:"ServerId"`
+	CurrentState        string       `json:"CurrentState"`
+	ClusterStatus       string       `json:"ClusterStatus"`
+	NumberOfCores       int          `json:"NumberOfCores"`
+	InstalledMemoryInGb float64      `json:"InstalledMemoryInGb"`
+	UsableMemoryInGb    float64      `json:"UsableMemoryInGb"`
+	HasFixedPort        bool         `json:"HasFixedPort"`
+	ServerSchemaVersion int          `json:"ServerSchemaVersion"`
+	BuildInfo           *BuildNumber `json:"BuildInfo"`
+}
+
+// GetNodeInfoOperation returns information about the node it's sent to
+type GetNodeInfoOperation struct {
+	Command *GetNodeInfoCommand
+}
+
+// NewGetNodeInfoOperation returns new GetNodeInfoOperation
+func NewGetNodeInfoOperation() *GetNodeInfoOperation {
+	return &GetNodeInfoOperation{}
+}
+
+// GetCommand returns a command for this operation
+func (o *GetNodeInfoOperation) GetCommand(conventions *DocumentConventions) (RavenCommand, error) {
+	o.Command = NewGetNodeInfoCommand()
+	return o.Command, nil
+}
+
+var _ RavenCommand = &GetNodeInfoCommand{}
+
+// GetNodeInfoCommand represents "get node info" command. To ping a specific
+// node, execute it with RequestExecutor.Execute and that node
+type GetNodeInfoCommand struct {
+	RavenCommandBase
+
+	Result *NodeInfo
+}
+
+// NewGetNodeInfoCommand returns new GetNodeInfoCommand
+func NewGetNodeInfoCommand() *GetNodeInfoCommand {
+	cmd := &GetNodeInfoCommand{
+		RavenCommandBase: NewRavenCommandBase(),
+	}
+	cmd.IsReadRequest = true
+	return cmd
+}
+
+func (c *GetNodeInfoCommand) CreateRequest(node *ServerNode) (*http.Request, error) {
+	url := node.URL + "/cluster/node-info"
+	return newHttpGet(url)
+}
+
+func (c *GetNodeInfoCommand) SetResponse(response []byte, fromCache bool) error {
+	if len(response) == 0 {
+		return throwInvalidResponse()
+	}
+
+	return jsonUnmarshal(response, &c.Result)
+}
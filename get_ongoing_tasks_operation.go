@@ -0,0 +1,62 @@
+package ravendb
+
+import (
+	"net/http"
+)
+
+var (
+	_ IMaintenanceOperation = &GetOngoingTasksOperation{}
+)
+
+// GetOngoingTasksOperation returns all ongoing tasks of a database
+type GetOngoingTasksOperation struct {
+	Command *GetOngoingTasksCommand
+}
+
+func NewGetOngoingTasksOperation() *GetOngoingTasksOperation {
+	return &GetOngoingTasksOperation{}
+}
+
+func (o *GetOngoingTasksOperation) GetCommand(conventions *DocumentConventions) (RavenCommand, error) {
+	o.Command = NewGetOngoingTasksCommand()
+	return o.Command, nil
+}
+
+var _ RavenCommand = &GetOngoingTasksCommand{}
+
+type GetOngoingTasksCommand struct {
+	RavenCommandBase
+
+	Result *OngoingTasksResult
+}
+
+func NewGetOngoingTasksCommand() *GetOngoingTasksCommand {
+	cmd := &GetOngoingTasksCommand{
+		RavenCommandBase: NewRavenCommandBase(),
+	}
+	cmd.IsReadRequest = true
+	return cmd
+}
+
+func (c *GetOngoingTasksCommand) CreateRequest(node *ServerNode) (*http.Request, error) {
+	url := node.URL + "/databases/" + node.Database + "/tasks"
+	return newHttpGet(url)
+}
+
+func (c *GetOngoingTasksCommand) SetResponse(response []byte, fromCache bool) error {
+	if len(response) == 0 {
+		return throwInvalidResponse()
+	}
+
+	var res struct {
+		OngoingTasksList []*OngoingTask `json:"OngoingTasksList"`
+	}
+	if err := jsonUnmarshal(response, &res); err != nil {
+		return err
+	}
+	c.Result = &OngoingTasksResult{}
+	for _, task := range res.OngoingTasksList {
+		c.Result.add(task)
+	}
+	return nil
+}
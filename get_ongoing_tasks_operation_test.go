@@ -0,0 +1,32 @@
+package ravendb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetOngoingTasksCommandGroupsTasks(t *testing.T) {
+	response := `{
+	"OngoingTasksList": [
+		{"TaskId": 1, "TaskType": "Subscription", "TaskName": "users", "TaskState": "Enabled",
+		 "ResponsibleNode": {"NodeTag": "A", "NodeUrl": "http://127.0.0.1:8080"}},
+		{"TaskId": 2, "TaskType": "Backup", "TaskName": "nightly", "TaskState": "Disabled"},
+		{"TaskId": 3, "TaskType": "PullReplicationAsSink", "TaskName": "sink", "TaskState": "Enabled"}
+	],
+	"SubscriptionsCount": 1
+}`
+	cmd := NewGetOngoingTasksCommand()
+	require.NoError(t, cmd.SetResponse([]byte(response), false))
+
+	res := cmd.Result
+	require.Equal(t, 1, len(res.Subscriptions))
+	assert.Equal(t, int64(1), res.Subscriptions[0].TaskID)
+	assert.Equal(t, "users", res.Subscriptions[0].TaskName)
+	assert.Equal(t, "A", res.Subscriptions[0].ResponsibleNode.NodeTag)
+	require.Equal(t, 1, len(res.Backups))
+	assert.Equal(t, OngoingTaskStateDisabled, res.Backups[0].TaskState)
+	assert.Equal(t, 1, len(res.PullReplications))
+	assert.Equal(t, 0, len(res.PushReplications))
+}
@@ -4,12 +4,25 @@ import (
 	"net/http"
 )
 
+var (
+	_ IMaintenanceOperation = &GetOperationStateOperation{}
+)
+
 type GetOperationStateOperation struct {
 	id int64
+
+	Command *GetOperationStateCommand
+}
+
+func NewGetOperationStateOperation(id int64) *GetOperationStateOperation {
+	return &GetOperationStateOperation{
+		id: id,
+	}
 }
 
-func (o *GetOperationStateOperation) GetCommand(conventions *DocumentConventions) *GetOperationStateCommand {
-	return NewGetOperationStateCommand(getDefaultConventions(), o.id)
+func (o *GetOperationStateOperation) GetCommand(conventions *DocumentConventions) (RavenCommand, error) {
+	o.Command = NewGetOperationStateCommand(conventions, o.id)
+	return o.Command, nil
 }
 
 type GetOperationStateCommand struct {
@@ -0,0 +1,70 @@
+package ravendb
+
+import (
+	"net/http"
+	"strconv"
+)
+
+var (
+	_ IMaintenanceOperation = &GetPeriodicBackupStatusOperation{}
+)
+
+// GetPeriodicBackupStatusOperation returns status of a periodic backup task.
+// Result is nil if the task hasn't run yet.
+type GetPeriodicBackupStatusOperation struct {
+	taskID int64
+
+	Command *GetPeriodicBackupStatusCommand
+}
+
+func NewGetPeriodicBackupStatusOperation(taskID int64) *GetPeriodicBackupStatusOperation {
+	return &GetPeriodicBackupStatusOperation{
+		taskID: taskID,
+	}
+}
+
+func (o *GetPeriodicBackupStatusOperation) GetCommand(conventions *DocumentConventions) (RavenCommand, error) {
+	o.Command = NewGetPeriodicBackupStatusCommand(o.taskID)
+	return o.Command, nil
+}
+
+var _ RavenCommand = &GetPeriodicBackupStatusCommand{}
+
+type GetPeriodicBackupStatusCommand struct {
+	RavenCommandBase
+
+	taskID int64
+
+	Result *PeriodicBackupStatus
+}
+
+func NewGetPeriodicBackupStatusCommand(taskID int64) *GetPeriodicBackupStatusCommand {
+	cmd := &GetPeriodicBackupStatusCommand{
+		RavenCommandBase: NewRavenCommandBase(),
+
+		taskID: taskID,
+	}
+	cmd.IsReadRequest = true
+	return cmd
+}
+
+func (c *GetPeriodicBackupStatusCommand) CreateRequest(node *ServerNode) (*http.Request, error) {
+	url := node.URL + "/databases/" + node.Database + "/periodic-backup/status?taskId=" + strconv.FormatInt(c.taskID, 10)
+
+	return newHttpGet(url)
+}
+
+func (c *GetPeriodicBackupStatusCommand) SetResponse(response []byte, fromCache bool) error {
+	if len(response) == 0 {
+		return throwInvalidResponse()
+	}
+
+	var res struct {
+		Status *PeriodicBackupStatus `json:"Status"`
+	}
+	if err := jsonUnmarshal(response, &res); err != nil {
+		return err
+	}
+	c.Result = res.Status
+	return nil
+}
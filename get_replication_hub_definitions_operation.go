@@ -0,0 +1,61 @@
+package ravendb
+
+import (
+	"net/http"
+)
+
+var (
+	_ IMaintenanceOperation = &GetReplicationHubDefinitionsOperation{}
+)
+
+// GetReplicationHubDefinitionsOperation returns definitions of all pull
+// replication hubs of a database
+type GetReplicationHubDefinitionsOperation struct {
+	Command *GetReplicationHubDefinitionsCommand
+}
+
+func NewGetReplicationHubDefinitionsOperation() *GetReplicationHubDefinitionsOperation {
+	return &GetReplicationHubDefinitionsOperation{}
+}
+
+func (o *GetReplicationHubDefinitionsOperation) GetCommand(conventions *DocumentConventions) (RavenCommand, error) {
+	o.Command = NewGetReplicationHubDefinitionsCommand()
+	return o.Command, nil
+}
+
+var _ RavenCommand = &GetReplicationHubDefinitionsCommand{}
+
+type GetReplicationHubDefinitionsCommand struct {
+	RavenCommandBase
+
+	Result []*PullReplicationDefinition
+}
+
+func NewGetReplicationHubDefinitionsCommand() *GetReplicationHubDefinitionsCommand {
+	cmd := &GetReplicationHubDefinitionsCommand{
+		RavenCommandBase: NewRavenCommandBase(),
+	}
+	cmd.IsReadRequest = true
+	return cmd
+}
+
+func (c *GetReplicationHubDefinitionsCommand) CreateRequest(node *ServerNode) (*http.Request, error) {
+	// hub definitions are returned as part of ongoing tasks of a database
+	url := node.URL + "/databases/" + node.Database + "/tasks"
+	return newHttpGet(url)
+}
+
+func (c *GetReplicationHubDefinitionsCommand) SetResponse(response []byte, fromCache bool) error {
+	if len(response) == 0 {
+		return throwInvalidResponse()
+	}
+
+	var res struct {
+		PullReplications []*PullReplicationDefinition `json:"PullReplications"`
+	}
+	if err := jsonUnmarshal(response, &res); err != nil {
+		return err
+	}
+	c.Result = res.PullReplications
+	return nil
+}
@@ -4,12 +4,25 @@ import (
 	"net/http"
 )
 
+var (
+	_ IServerOperation = &GetServerWideOperationStateOperation{}
+)
+
 type GetServerWideOperationStateOperation struct {
 	id int64
+
+	Command *GetServerWideOperationStateCommand
+}
+
+func NewGetServerWideOperationStateOperation(id int64) *GetServerWideOperationStateOperation {
+	return &GetServerWideOperationStateOperation{
+		id: id,
+	}
 }
 
-func (o *GetServerWideOperationStateOperation) GetCommand(conventions *DocumentConventions) *GetServerWideOperationStateCommand {
-	return NewGetServerWideOperationStateCommand(getDefaultConventions(), o.id)
+func (o *GetServerWideOperationStateOperation) GetCommand(conventions *DocumentConventions) (RavenCommand, error) {
+	o.Command = NewGetServerWideOperationStateCommand(conventions, o.id)
+	return o.Command, nil
 }
 
 type GetServerWideOperationStateCommand struct {
@@ -0,0 +1,134 @@
+package ravendb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// statistics as returned by a 4.2 server
+const databaseStatistics42JSON = `{
+	"LastDocEtag": 1032,
+	"LastDatabaseEtag": 1040,
+	"CountOfIndexes": 2,
+	"CountOfDocuments": 1059,
+	"CountOfRevisionDocuments": 0,
+	"CountOfDocumentsConflicts": 0,
+	"CountOfTombstones": 3,
+	"CountOfConflicts": 0,
+	"CountOfAttachments": 17,
+	"CountOfUniqueAttachments": 17,
+	"DatabaseChangeVector": "A:1040-5nJ0ILsHl0OmEMDXm/D6wg",
+	"DatabaseId": "5nJ0ILsHl0OmEMDXm/D6wg",
+	"Is64Bit": true,
+	"Pager": "Voron.Impl.Paging.RvnMemoryMapPager",
+	"LastIndexingTime": "2019-11-20T10:03:42.7734321Z",
+	"Indexes": [
+		{"Name": "Orders/Totals", "IsStale": false, "State": "Normal", "LockMode": "Unlock", "Priority": "Normal", "Type": "Map", "LastIndexingTime": "2019-11-20T10:03:42.7734321Z"},
+		{"Name": "Auto/Users/Byname", "IsStale": true, "State": "Normal", "LockMode": "Unlock", "Priority": "Normal", "Type": "AutoMap", "LastIndexingTime": null}
+	],
+	"SizeOnDisk": {"HumaneSize": "64.13 MBytes", "SizeInBytes": 67239936},
+	"TempBuffersSizeOnDisk": {"HumaneSize": "1 MBytes", "SizeInBytes": 1048576},
+	"NumberOfTransactionMergerQueueOperations": 0
+}`
+
+// statistics as returned by a 5.x server
+const databaseStatistics5JSON = `{
+	"LastDocEtag": 5121,
+	"LastDatabaseEtag": 5200,
+	"CountOfIndexes": 1,
+	"CountOfDocuments": 1059,
+	"CountOfRevisionDocuments": 12,
+	"CountOfDocumentsConflicts": 0,
+	"CountOfTombstones": 0,
+	"CountOfConflicts": 0,
+	"CountOfAttachments": 17,
+	"CountOfUniqueAttachments": 17,
+	"CountOfCounterEntries": 4,
+	"CountOfTimeSeriesSegments": 31,
+	"DatabaseChangeVector": "A:5200-5nJ0ILsHl0OmEMDXm/D6wg",
+	"DatabaseId": "5nJ0ILsHl0OmEMDXm/D6wg",
+	"Is64Bit": true,
+	"Pager": "Voron.Impl.Paging.RvnMemoryMapPager",
+	"LastIndexingTime": "2021-06-01T08:15:00.1000000Z",
+	"Indexes": [
+		{"Name": "Orders/Totals", "IsStale": false, "State": "Normal", "LockMode": "Unlock", "Priority": "Normal", "Type": "Map", "SourceType": "Documents", "LastIndexingTime": "2021-06-01T08:15:00.1000000Z"}
+	],
+	"SizeOnDisk": {"HumaneSize": "72.5 MBytes", "SizeInBytes": 76021760},
+	"TempBuffersSizeOnDisk": {"HumaneSize": "1 MBytes", "SizeInBytes": 1048576},
+	"NumberOfTransactionMergerQueueOperations": 0
+}`
+
+func TestGetStatisticsCommandSetResponse(t *testing.T) {
+	cmd := NewGetStatisticsCommand("")
+	require.NoError(t, cmd.SetResponse([]byte(databaseStatistics42JSON), false))
+	stats := cmd.Result
+	require.NotNil(t, stats)
+	assert.Equal(t, int64(1032), stats.LastDocEtag)
+	assert.Equal(t, 2, stats.CountOfIndexes)
+	assert.Equal(t, int64(1059), stats.CountOfDocuments)
+	assert.Equal(t, int64(17), stats.CountOfAttachments)
+	assert.Equal(t, int64(0), stats.CountOfTimeSeriesSegments)
+	expected := time.Date(2019, 11, 20, 10, 3, 42, 773432100, time.UTC)
+	assert.Equal(t, expected, *stats.GetLastIndexingTime())
+	assert.Equal(t, int64(67239936), stats.SizeOnDisk.SizeInBytes)
+	stale := stats.GetStaleIndexes()
+	require.Equal(t, 1, len(stale))
+	assert.Equal(t, "Auto/Users/Byname", stale[0].Name)
+
+	cmd = NewGetStatisticsCommand("")
+	require.NoError(t, cmd.SetResponse([]byte(databaseStatistics5JSON), false))
+	stats = cmd.Result
+	assert.Equal(t, int64(12), stats.CountOfRevisionDocuments)
+	assert.Equal(t, int64(4), stats.CountOfCounterEntries)
+	assert.Equal(t, int64(31), stats.CountOfTimeSeriesSegments)
+	assert.Equal(t, 0, len(stats.GetStaleIndexes()))
+
+	// a malformed time is an error, not a panic
+	cmd = NewGetStatisticsCommand("")
+	err := cmd.SetResponse([]byte(`{"LastIndexingTime": "yesterday"}`), false)
+	assert.Error(t, err)
+}
+
+func TestDatabaseStatisticsIndexes(t *testing.T) {
+	cmd := NewGetStatisticsCommand("")
+	require.NoError(t, cmd.SetResponse([]byte(databaseStatistics42JSON), false))
+	indexes := cmd.Result.Indexes
+	require.Equal(t, 2, len(indexes))
+
+	index := indexes[0]
+	assert.Equal(t, "Orders/Totals", index.Name)
+	assert.False(t, index.IsStale)
+	assert.Equal(t, IndexStateNormal, index.State)
+	assert.Equal(t, IndexLockModeUnlock, index.LockMode)
+	assert.Equal(t, IndexPriorityNormal, index.Priority)
+	assert.Equal(t, IndexTypeMap, index.Type)
+	assert.Equal(t, time.Date(2019, 11, 20, 10, 3, 42, 773432100, time.UTC), index.GetLastIndexingTime())
+
+	index = indexes[1]
+	assert.Equal(t, "Auto/Users/Byname", index.Name)
+	assert.True(t, index.IsStale)
+	assert.Equal(t, IndexTypeAutoMap, index.Type)
+	assert.True(t, index.GetLastIndexingTime().IsZero())
+
+	cmd = NewGetStatisticsCommand("")
+	require.NoError(t, cmd.SetResponse([]byte(databaseStatistics5JSON), false))
+	assert.Equal(t, IndexSourceTypeDocuments, cmd.Result.Indexes[0].SourceType)
+}
+
+func TestGetDetailedStatisticsCommandSetResponse(t *testing.T) {
+	// detailed statistics are regular statistics with a few extra fields
+	js := databaseStatistics5JSON[:len(databaseStatistics5JSON)-1] +
+		`, "CountOfIdentities": 3, "CountOfCompareExchange": 5, "CountOfCompareExchangeTombstones": 1}`
+	cmd := NewGetDetailedStatisticsCommand("")
+	require.NoError(t, cmd.SetResponse([]byte(js), false))
+	stats := cmd.Result
+	require.NotNil(t, stats)
+	assert.Equal(t, int64(1059), stats.CountOfDocuments)
+	assert.Equal(t, int64(31), stats.CountOfTimeSeriesSegments)
+	assert.Equal(t, int64(3), stats.CountOfIdentities)
+	assert.Equal(t, int64(5), stats.CountOfCompareExchange)
+	assert.Equal(t, int64(1), stats.CountOfCompareExchangeTombstones)
+}
@@ -17,7 +17,7 @@ type GetSubscriptionStateCommand struct {
 	Result *SubscriptionState
 }
 
-func newGetSubscriptionStateCommand(subscriptionName string) *GetSubscriptionStateCommand {
+func NewGetSubscriptionStateCommand(subscriptionName string) *GetSubscriptionStateCommand {
 	cmd := &GetSubscriptionStateCommand{
 		RavenCommandBase: NewRavenCommandBase(),
 
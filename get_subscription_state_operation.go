@@ -0,0 +1,27 @@
+package ravendb
+
+var (
+	_ IMaintenanceOperation = &GetSubscriptionStateOperation{}
+)
+
+// GetSubscriptionStateOperation returns definition and current state of
+// a subscription
+type GetSubscriptionStateOperation struct {
+	subscriptionName string
+
+	Command *GetSubscriptionStateCommand
+}
+
+func NewGetSubscriptionStateOperation(subscriptionName string) (*GetSubscriptionStateOperation, error) {
+	if subscriptionName == "" {
+		return nil, newIllegalArgumentError("SubscriptionName cannot be null")
+	}
+	return &GetSubscriptionStateOperation{
+		subscriptionName: subscriptionName,
+	}, nil
+}
+
+func (o *GetSubscriptionStateOperation) GetCommand(conventions *DocumentConventions) (RavenCommand, error) {
+	o.Command = NewGetSubscriptionStateCommand(o.subscriptionName)
+	return o.Command, nil
+}
@@ -0,0 +1,69 @@
+package ravendb
+
+import (
+	"net/http"
+	"strconv"
+)
+
+var (
+	_ IMaintenanceOperation = &GetSubscriptionTaskOperation{}
+)
+
+// GetSubscriptionTaskOperation returns details of a single subscription task.
+// The task is looked up by taskName if given, by taskID otherwise.
+type GetSubscriptionTaskOperation struct {
+	taskID   int64
+	taskName string
+
+	Command *GetSubscriptionTaskCommand
+}
+
+func NewGetSubscriptionTaskOperation(taskID int64, taskName string) *GetSubscriptionTaskOperation {
+	return &GetSubscriptionTaskOperation{
+		taskID:   taskID,
+		taskName: taskName,
+	}
+}
+
+func (o *GetSubscriptionTaskOperation) GetCommand(conventions *DocumentConventions) (RavenCommand, error) {
+	o.Command = NewGetSubscriptionTaskCommand(o.taskID, o.taskName)
+	return o.Command, nil
+}
+
+var _ RavenCommand = &GetSubscriptionTaskCommand{}
+
+type GetSubscriptionTaskCommand struct {
+	RavenCommandBase
+
+	taskID   int64
+	taskName string
+
+	Result *OngoingTaskSubscription
+}
+
+func NewGetSubscriptionTaskCommand(taskID int64, taskName string) *GetSubscriptionTaskCommand {
+	cmd := &GetSubscriptionTaskCommand{
+		RavenCommandBase: NewRavenCommandBase(),
+
+		taskID:   taskID,
+		taskName: taskName,
+	}
+	cmd.IsReadRequest = true
+	return cmd
+}
+
+func (c *GetSubscriptionTaskCommand) CreateRequest(node *ServerNode) (*http.Request, error) {
+	url := node.URL + "/databases/" + node.Database + "/task?key=" + strconv.FormatInt(c.taskID, 10) + "&type=" + OngoingTaskTypeSubscription
+	if c.taskName != "" {
+		url += "&taskName=" + urlUtilsEscapeDataString(c.taskName)
+	}
+	return newHttpGet(url)
+}
+
+func (c *GetSubscriptionTaskCommand) SetResponse(response []byte, fromCache bool) error {
+	if len(response) == 0 {
+		return throwInvalidResponse()
+	}
+
+	return jsonUnmarshal(response, &c.Result)
+}
@@ -9,7 +9,7 @@ var (
 	_ RavenCommand = &GetSubscriptionsCommand{}
 )
 
-// GetSubscriptionsCommand describes "delete subscription" command
+// GetSubscriptionsCommand describes "get subscriptions" command
 type GetSubscriptionsCommand struct {
 	RavenCommandBase
 
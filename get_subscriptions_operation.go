@@ -0,0 +1,32 @@
+package ravendb
+
+var (
+	_ IMaintenanceOperation = &GetSubscriptionsOperation{}
+)
+
+// GetSubscriptionsOperation returns states of subscriptions of a database,
+// skipping the first start subscriptions and returning at most pageSize
+type GetSubscriptionsOperation struct {
+	start    int
+	pageSize int
+
+	Command *GetSubscriptionsCommand
+}
+
+func NewGetSubscriptionsOperation(start int, pageSize int) (*GetSubscriptionsOperation, error) {
+	if start < 0 {
+		return nil, newIllegalArgumentError("start cannot be negative")
+	}
+	if pageSize <= 0 {
+		return nil, newIllegalArgumentError("pageSize must be positive")
+	}
+	return &GetSubscriptionsOperation{
+		start:    start,
+		pageSize: pageSize,
+	}, nil
+}
+
+func (o *GetSubscriptionsOperation) GetCommand(conventions *DocumentConventions) (RavenCommand, error) {
+	o.Command = newGetSubscriptionsCommand(o.start, o.pageSize)
+	return o.Command, nil
+}
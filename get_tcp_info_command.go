@@ -35,9 +35,9 @@ func NewGetTcpInfoCommand(tag, dbName string) *GetTcpInfoCommand {
 func (c *GetTcpInfoCommand) CreateRequest(node *ServerNode) (*http.Request, error) {
 	url := ""
 	if c.dbName == "" {
-		url = node.URL + "/info/tcp?tcp=" + c.tag
+		url = node.URL + "/info/tcp?tcp=" + urlUtilsEscapeDataString(c.tag)
 	} else {
-		url = node.URL + "/databases/" + c.dbName + "/info/tcp?tag=" + c.tag
+		url = node.URL + "/databases/" + c.dbName + "/info/tcp?tag=" + urlUtilsEscapeDataString(c.tag)
 	}
 	c.requestedNode = node
 	return newHttpGet(url)
@@ -1,5 +1,7 @@
 package ravendb
 
+import "strings"
+
 // GroupBy represents arguments to "group by" query
 type GroupBy struct {
 	Field  string
@@ -14,10 +16,26 @@ func NewGroupByField(fieldName string) *GroupBy {
 	}
 }
 
-// NewGroupByField returns new GroupBy for an array
+// NewGroupByArray returns new GroupBy for an array. Documents are grouped
+// by the content of the whole array i.e. "group by array(fieldName)"
 func NewGroupByArray(fieldName string) *GroupBy {
 	return &GroupBy{
 		Field:  fieldName,
 		Method: GroupByMethodArray,
 	}
 }
+
+// NewGroupByArrayContent is the same as NewGroupByArray
+func NewGroupByArrayContent(fieldName string) *GroupBy {
+	return NewGroupByArray(fieldName)
+}
+
+// NewGroupByArrayValues returns new GroupBy that groups by each value of
+// an array separately. If fieldName doesn't address array elements
+// (e.g. "lines[].product") "[]" is appended so "tags" becomes "tags[]"
+func NewGroupByArrayValues(fieldName string) *GroupBy {
+	if !strings.Contains(fieldName, "[]") {
+		fieldName += "[]"
+	}
+	return NewGroupByField(fieldName)
+}
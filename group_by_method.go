@@ -1,8 +1,11 @@
 package ravendb
 
+// GroupByMethod describes how a field is used in "group by"
 type GroupByMethod = string
 
 const (
-	GroupByMethodNone  = "None"
+	// GroupByMethodNone groups by the value of the field
+	GroupByMethodNone = "None"
+	// GroupByMethodArray groups by the content of the whole array
 	GroupByMethodArray = "Array"
 )
@@ -19,7 +19,7 @@ func createGroupByToken(fieldName string, method GroupByMethod) *groupByToken {
 func (t *groupByToken) writeTo(writer *strings.Builder) error {
 	_method := t.method
 	if _method != GroupByMethodNone {
-		writer.WriteString("Array(")
+		writer.WriteString("array(")
 	}
 	writeQueryTokenField(writer, t.fieldName)
 	if _method != GroupByMethodNone {
@@ -76,7 +76,7 @@ func (h *HashCalculator) write(v interface{}) {
 			io.WriteString(&h._buffer, k)
 			if v == nil {
 				io.WriteString(&h._buffer, "null")
-				return
+				continue
 			}
 			tp := reflect.TypeOf(v)
 			if _, ok := isPtrStruct(tp); ok || tp.Kind() == reflect.Struct {
@@ -85,7 +85,7 @@ func (h *HashCalculator) write(v interface{}) {
 				// param that is custom type used by the user
 				s := fmt.Sprintf("%#v", v)
 				io.WriteString(&h._buffer, s)
-				return
+				continue
 			}
 			h.write(v)
 		}
@@ -96,7 +96,7 @@ func (h *HashCalculator) write(v interface{}) {
 		}
 		must(binary.Write(&h._buffer, binary.LittleEndian, toWrite))
 	case time.Time:
-		t := v2.UTC().Unix()
+		t := v2.UTC().UnixNano()
 		must(binary.Write(&h._buffer, binary.LittleEndian, t))
 	case int:
 		must(binary.Write(&h._buffer, binary.LittleEndian, int64(v2)))
@@ -5,13 +5,13 @@ import (
 	"fmt"
 	"io"
 	"net/http"
-	"net/url"
 )
 
 var ()
 
+// urlEncode escapes s for use as a query string value
 func urlEncode(s string) string {
-	return url.PathEscape(s)
+	return urlUtilsEscapeDataString(s)
 }
 
 func addChangeVectorIfNotNull(changeVector *string, req *http.Request) {
@@ -1,14 +1,20 @@
 package ravendb
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
+	"strings"
 	"sync/atomic"
 	"time"
 )
 
 var (
 	clientSessionIDCounter int32 = 1
+
+	// ErrCannotInferCollectionForInterface is returned when querying for
+	// an interface type without specifying a collection or an index
+	ErrCannotInferCollectionForInterface = errors.New("cannot infer collection name from an interface type, use FromCollection, FromIndex or QueryInterface")
 )
 
 func newClientSessionID() int {
@@ -34,6 +40,8 @@ type InMemoryDocumentSessionOperations struct {
 	sessionInfo                 *SessionInfo
 	saveChangesOptions          *BatchOptions
 	isDisposed                  bool
+	// if true, entities are never tracked and changes can't be saved
+	noTracking bool
 
 	// Note: skipping unused isDisposed
 	id string
@@ -44,6 +52,8 @@ type InMemoryDocumentSessionOperations struct {
 	onBeforeDelete []func(*BeforeDeleteEventArgs)
 	onBeforeQuery  []func(*BeforeQueryEventArgs)
 
+	onEntityMaterialized []func(interface{}, map[string]interface{})
+
 	// ids of entities that were deleted
 	knownMissingIds []string // case insensitive
 
@@ -55,6 +65,15 @@ type InMemoryDocumentSessionOperations struct {
 	// TODO: ignore case for keys
 	includedDocumentsByID map[string]*documentInfo
 
+	// counters and time series included when loading documents, by
+	// lower-cased document id
+	includedCountersByDocID   map[string]map[string]int64
+	includedTimeSeriesByDocID map[string]map[string][]*TimeSeriesRangeResult
+
+	// compare exchange values included by queries, as returned by
+	// the server, by key
+	includedCompareExchangeValues map[string]map[string]interface{}
+
 	// hold the data required to manage the data for RavenDB's Unit of Work
 	// Note: in Java it's LinkedHashMap where iteration order is same
 	// as insertion order. In Go map has random iteration order so we must
@@ -74,6 +93,9 @@ type InMemoryDocumentSessionOperations struct {
 
 	deferredCommands []ICommandData
 
+	transactionMode    TransactionMode
+	clusterTransaction *ClusterTransactionOperations
+
 	// Note: using value type so that lookups are based on value
 	deferredCommandsMap map[idTypeAndName]ICommandData
 
@@ -103,6 +125,11 @@ func newInMemoryDocumentSessionOperations(dbName string, store *DocumentStore, r
 		maxNumberOfRequestsPerSession: re.conventions.MaxNumberOfRequestsPerSession,
 		useOptimisticConcurrency:      re.conventions.UseOptimisticConcurrency,
 		deferredCommandsMap:           map[idTypeAndName]ICommandData{},
+		transactionMode:               TransactionModeSingleNode,
+	}
+	res.clusterTransaction = newClusterTransactionOperations(res)
+	if store != nil {
+		res.sessionInfo.LastClusterTransactionIndex = store.GetLastTransactionIndex(dbName)
 	}
 
 	genIDFunc := func(entity interface{}) (string, error) {
@@ -191,6 +218,32 @@ func (s *InMemoryDocumentSessionOperations) RemoveBeforeQueryListener(handlerID
 	s.onBeforeQuery[handlerID] = nil
 }
 
+// OnEntityMaterialized registers a function that will be called for each query
+// result after it has been decoded from JSON and before it's returned to the caller.
+// It allows post-processing results e.g. decrypting a field.
+// entity is a pointer to the result (e.g. *User for []*User results and
+// *string for []string results) so changes made by the handler are
+// returned to the caller.
+// Returns listener id that can be passed to RemoveEntityMaterializedListener to unregister
+// the listener.
+func (s *InMemoryDocumentSessionOperations) OnEntityMaterialized(handler func(entity interface{}, metadata map[string]interface{})) int {
+	s.onEntityMaterialized = append(s.onEntityMaterialized, handler)
+	return len(s.onEntityMaterialized) - 1
+}
+
+// RemoveEntityMaterializedListener removes a listener given id returned by OnEntityMaterialized
+func (s *InMemoryDocumentSessionOperations) RemoveEntityMaterializedListener(handlerID int) {
+	s.onEntityMaterialized[handlerID] = nil
+}
+
+func (s *InMemoryDocumentSessionOperations) onEntityMaterializedInvoke(entity interface{}, metadata map[string]interface{}) {
+	for _, handler := range s.onEntityMaterialized {
+		if handler != nil {
+			handler(entity, metadata)
+		}
+	}
+}
+
 func (s *InMemoryDocumentSessionOperations) getEntityToJSON() *entityToJSON {
 	return s.entityToJSON
 }
@@ -213,6 +266,11 @@ func (s *InMemoryDocumentSessionOperations) GetDocumentStore() *DocumentStore {
 	return s.documentStore
 }
 
+// GetDatabaseName returns the name of the database this session works on
+func (s *InMemoryDocumentSessionOperations) GetDatabaseName() string {
+	return s.DatabaseName
+}
+
 func (s *InMemoryDocumentSessionOperations) GetRequestExecutor() *RequestExecutor {
 	return s.requestExecutor
 }
@@ -397,13 +455,14 @@ func (s *InMemoryDocumentSessionOperations) incrementRequestCount() error {
 
 // result is a pointer to expected value
 func (s *InMemoryDocumentSessionOperations) TrackEntityInDocumentInfo(result interface{}, documentFound *documentInfo) error {
-	return s.TrackEntity(result, documentFound.id, documentFound.document, documentFound.metadata, false)
+	return s.TrackEntity(result, documentFound.id, documentFound.document, documentFound.metadata, s.noTracking)
 }
 
 // TrackEntity tracks a given object
 // result is a pointer to a decoded value (e.g. **Foo) and will be set with
 // value decoded from JSON (e.g. *result = &Foo{})
 func (s *InMemoryDocumentSessionOperations) TrackEntity(result interface{}, id string, document map[string]interface{}, metadata map[string]interface{}, noTracking bool) error {
+	noTracking = noTracking || s.noTracking
 	if id == "" {
 		return s.deserializeFromTransformer(result, "", document)
 	}
@@ -520,14 +579,20 @@ func (s *InMemoryDocumentSessionOperations) Delete(entity interface{}) error {
 // DeleteByID marks the specified entity for deletion. The entity will be deleted when SaveChanges is called.
 // WARNING: This method will not call beforeDelete listener!
 func (s *InMemoryDocumentSessionOperations) DeleteByID(id string, expectedChangeVector string) error {
-	if id == "" {
-		return newIllegalArgumentError("id cannot be empty")
+	if err := validateDocumentID(id, false); err != nil {
+		return err
+	}
+	if s.noTracking {
+		return newIllegalStateError("Cannot delete document '%s'. Entity tracking is disabled in this session.", id)
 	}
 
 	var changeVector string
 	documentInfo := s.documentsByID.getValue(id)
 	if documentInfo != nil {
-		newObj := convertEntityToJSON(documentInfo.entity, documentInfo)
+		newObj, err := convertEntityToJSON(s.GetConventions(), documentInfo.entity, documentInfo)
+		if err != nil {
+			return err
+		}
 		if documentInfo.entity != nil && s.entityChanged(newObj, documentInfo, nil) {
 			return newIllegalStateError("Can't delete changed entity using identifier. Use delete(Class clazz, T entity) instead.")
 		}
@@ -661,6 +726,9 @@ func (s *InMemoryDocumentSessionOperations) rememberEntityForDocumentIdGeneratio
 }
 
 func (s *InMemoryDocumentSessionOperations) storeInternal(entity interface{}, changeVector string, id string, forceConcurrencyCheck ConcurrencyCheckMode) error {
+	if s.noTracking {
+		return newIllegalStateError("Cannot store entity. Entity tracking is disabled in this session.")
+	}
 	value := getDocumentInfoByEntity(s.documentsByEntity, entity)
 	if value != nil {
 		if changeVector != "" {
@@ -682,6 +750,9 @@ func (s *InMemoryDocumentSessionOperations) storeInternal(entity interface{}, ch
 			}
 		}
 	} else {
+		if err = validateDocumentID(id, true); err != nil {
+			return err
+		}
 		// Store it back into the Id field so the client has access to it
 		s.generateEntityIDOnTheClient.trySetIdentity(entity, id)
 	}
@@ -703,15 +774,7 @@ func (s *InMemoryDocumentSessionOperations) storeInternal(entity interface{}, ch
 		return err
 	}
 
-	collectionName := s.requestExecutor.GetConventions().getCollectionName(entity)
-	metadata := map[string]interface{}{}
-	if collectionName != "" {
-		metadata[MetadataCollection] = collectionName
-	}
-	goType := s.requestExecutor.GetConventions().getGoTypeName(entity)
-	if goType != "" {
-		metadata[MetadataRavenGoType] = goType
-	}
+	metadata := buildDefaultMetadata(entity, s.requestExecutor.GetConventions())
 	if id != "" {
 		s.knownMissingIds = stringArrayRemoveNoCase(s.knownMissingIds, id)
 	}
@@ -723,6 +786,20 @@ func (s *InMemoryDocumentSessionOperations) storeInternal(entity interface{}, ch
 	return nil
 }
 
+// buildDefaultMetadata returns metadata of a newly stored entity.
+// @id, @change-vector and @last-modified are assigned by the server
+// and are added to metadata when the document is loaded
+func buildDefaultMetadata(entity interface{}, conventions *DocumentConventions) map[string]interface{} {
+	metadata := map[string]interface{}{}
+	if collectionName := conventions.getCollectionName(entity); collectionName != "" {
+		metadata[MetadataCollection] = collectionName
+	}
+	if goType := conventions.getGoTypeName(entity); goType != "" {
+		metadata[MetadataRavenGoType] = goType
+	}
+	return metadata
+}
+
 func (s *InMemoryDocumentSessionOperations) storeEntityInUnitOfWork(id string, entity interface{}, changeVector *string, metadata map[string]interface{}, forceConcurrencyCheck ConcurrencyCheckMode) {
 	s.deletedEntities.remove(entity)
 	if id != "" {
@@ -744,8 +821,7 @@ func (s *InMemoryDocumentSessionOperations) storeEntityInUnitOfWork(id string, e
 }
 
 func (s *InMemoryDocumentSessionOperations) assertNoNonUniqueInstance(entity interface{}, id string) error {
-	nLastChar := len(id) - 1
-	if len(id) == 0 || id[nLastChar] == '|' || id[nLastChar] == '/' {
+	if id == "" || isServerGeneratedDocumentID(id, s.GetConventions()) {
 		return nil
 	}
 	info := s.documentsByID.getValue(id)
@@ -770,6 +846,9 @@ func (s *InMemoryDocumentSessionOperations) prepareForSaveChanges() (*saveChange
 	if err != nil {
 		return nil, err
 	}
+	if err = s.prepareCompareExchangeEntities(result); err != nil {
+		return nil, err
+	}
 
 	if len(s.deferredCommands) > 0 {
 		// this allow OnBeforeStore to call Defer during the call to include
@@ -784,6 +863,35 @@ func (s *InMemoryDocumentSessionOperations) prepareForSaveChanges() (*saveChange
 	return result, nil
 }
 
+// updateSessionAfterSaveChanges remembers the index of cluster-wide
+// transaction so that subsequent reads, also from other sessions, see it
+func (s *InMemoryDocumentSessionOperations) updateSessionAfterSaveChanges(result *JSONArrayResult) {
+	index := result.TransactionIndex
+	if index <= 0 {
+		return
+	}
+	if s.documentStore != nil {
+		s.documentStore.SetLastTransactionIndex(s.DatabaseName, index)
+	}
+	if index > s.sessionInfo.LastClusterTransactionIndex {
+		s.sessionInfo.LastClusterTransactionIndex = index
+	}
+}
+
+func (s *InMemoryDocumentSessionOperations) prepareCompareExchangeEntities(result *saveChangesData) error {
+	result.transactionMode = s.transactionMode
+	if s.transactionMode != TransactionModeClusterWide {
+		return nil
+	}
+	if s.useOptimisticConcurrency {
+		return newIllegalStateError("useOptimisticConcurrency is not supported with TransactionMode set to ClusterWide")
+	}
+	for _, cmd := range s.clusterTransaction.commands {
+		result.addSessionCommandData(cmd)
+	}
+	return nil
+}
+
 func (s *InMemoryDocumentSessionOperations) UpdateMetadataModifications(documentInfo *documentInfo) bool {
 	dirty := false
 	metadataInstance := documentInfo.metadataInstance
@@ -874,16 +982,24 @@ func (s *InMemoryDocumentSessionOperations) prepareForEntitiesDeletion(result *s
 
 func (s *InMemoryDocumentSessionOperations) prepareForEntitiesPuts(result *saveChangesData) error {
 	for _, entityValue := range s.documentsByEntity {
-		if entityValue.ignoreChanges {
+		if s.shouldIgnoreChanges(entityValue) {
 			continue
 		}
 		entityKey := entityValue.entity
 
 		dirtyMetadata := s.UpdateMetadataModifications(entityValue)
 
-		document := convertEntityToJSON(entityKey, entityValue)
+		document, err := convertEntityToJSON(s.GetConventions(), entityKey, entityValue)
+		if err != nil {
+			return err
+		}
+
+		// entity was refreshed from the raw document so after saving
+		// its JSON is what we compare future changes against
+		rawDocument := entityValue.rawDocument
+		entityValue.rawDocument = nil
 
-		if !s.entityChanged(document, entityValue, nil) && !dirtyMetadata {
+		if rawDocument == nil && !s.entityChanged(document, entityValue, nil) && !dirtyMetadata {
 			continue
 		}
 
@@ -907,7 +1023,10 @@ func (s *InMemoryDocumentSessionOperations) prepareForEntitiesPuts(result *saveC
 				s.UpdateMetadataModifications(entityValue)
 			}
 			if beforeStoreEventArgs.isMetadataAccessed() || s.entityChanged(document, entityValue, nil) {
-				document = convertEntityToJSON(entityKey, entityValue)
+				document, err = convertEntityToJSON(s.GetConventions(), entityKey, entityValue)
+				if err != nil {
+					return err
+				}
 			}
 		}
 
@@ -919,6 +1038,10 @@ func (s *InMemoryDocumentSessionOperations) prepareForEntitiesPuts(result *saveC
 		}
 
 		entityValue.document = document
+		if rawDocument != nil {
+			document = rawDocument
+			entityToJSONWriteMetadata(document, entityValue)
+		}
 
 		var changeVector *string
 		if s.useOptimisticConcurrency {
@@ -960,7 +1083,10 @@ func (s *InMemoryDocumentSessionOperations) WhatChanged() (map[string][]*Documen
 	if err != nil {
 		return nil, err
 	}
-	s.getAllEntitiesChanges(changes)
+	err = s.getAllEntitiesChanges(changes)
+	if err != nil {
+		return nil, err
+	}
 	return changes, nil
 }
 
@@ -971,8 +1097,19 @@ func (s *InMemoryDocumentSessionOperations) HasChanges() bool {
 	}
 
 	for _, documentInfo := range s.documentsByEntity {
+		if s.shouldIgnoreChanges(documentInfo) {
+			continue
+		}
+		if documentInfo.rawDocument != nil {
+			return true
+		}
 		entity := documentInfo.entity
-		document := convertEntityToJSON(entity, documentInfo)
+		document, err := convertEntityToJSON(s.GetConventions(), entity, documentInfo)
+		if err != nil {
+			// can't compare with what was loaded, SaveChanges will
+			// report the error
+			return true
+		}
 		changed := s.entityChanged(document, documentInfo, nil)
 		if changed {
 			return true
@@ -989,11 +1126,17 @@ func (s *InMemoryDocumentSessionOperations) HasChanged(entity interface{}) (bool
 	}
 	documentInfo := getDocumentInfoByEntity(s.documentsByEntity, entity)
 
-	if documentInfo == nil {
+	if documentInfo == nil || s.shouldIgnoreChanges(documentInfo) {
 		return false, nil
 	}
+	if documentInfo.rawDocument != nil {
+		return true, nil
+	}
 
-	document := convertEntityToJSON(entity, documentInfo)
+	document, err := convertEntityToJSON(s.GetConventions(), entity, documentInfo)
+	if err != nil {
+		return false, err
+	}
 	return s.entityChanged(document, documentInfo, nil), nil
 }
 
@@ -1021,13 +1164,31 @@ func (s *InMemoryDocumentSessionOperations) WaitForIndexesAfterSaveChanges(optio
 	builderOptions.waitForIndexes = true
 }
 
-func (s *InMemoryDocumentSessionOperations) getAllEntitiesChanges(changes map[string][]*DocumentsChanges) {
+func (s *InMemoryDocumentSessionOperations) getAllEntitiesChanges(changes map[string][]*DocumentsChanges) error {
 	for _, docInfo := range s.documentsByID.inner {
+		if s.shouldIgnoreChanges(docInfo) {
+			continue
+		}
 		s.UpdateMetadataModifications(docInfo)
 		entity := docInfo.entity
-		newObj := convertEntityToJSON(entity, docInfo)
+		newObj, err := convertEntityToJSON(s.GetConventions(), entity, docInfo)
+		if err != nil {
+			return err
+		}
 		s.entityChanged(newObj, docInfo, changes)
 	}
+	return nil
+}
+
+// shouldIgnoreChanges returns true if changes to the document should not be
+// tracked, either because of IgnoreChangesFor or
+// conventions.ShouldIgnoreEntityChanges
+func (s *InMemoryDocumentSessionOperations) shouldIgnoreChanges(docInfo *documentInfo) bool {
+	if docInfo.ignoreChanges {
+		return true
+	}
+	fn := s.GetConventions().ShouldIgnoreEntityChanges
+	return fn != nil && fn(s, docInfo.entity, docInfo.id)
 }
 
 // IgnoreChangesFor marks the entity as one that should be ignore for change tracking purposes,
@@ -1041,6 +1202,62 @@ func (s *InMemoryDocumentSessionOperations) IgnoreChangesFor(entity interface{})
 	}
 }
 
+// GetRawEntityByID returns the JSON document of an entity tracked by the
+// session, without deserializing it. It's the document as last seen by
+// the session or as set by SetRawEntityByID. The returned map is a copy.
+// Returns false if there's no entity with this id in the session or if
+// it was stored but not yet saved and can't be serialized
+func (s *InMemoryDocumentSessionOperations) GetRawEntityByID(id string) (map[string]interface{}, bool) {
+	documentInfo := s.documentsByID.getValue(id)
+	if documentInfo == nil || documentInfo.entity == nil {
+		return nil, false
+	}
+	document := documentInfo.rawDocument
+	if document == nil {
+		document = documentInfo.document
+	}
+	if document == nil {
+		// stored but not yet saved. Serialization error will be
+		// returned by SaveChanges
+		document, err := convertEntityToJSON(s.GetConventions(), documentInfo.entity, documentInfo)
+		if err != nil {
+			return nil, false
+		}
+		return document, true
+	}
+	return deepCopy(document).(map[string]interface{}), true
+}
+
+// SetRawEntityByID overrides the JSON document that will be saved for an
+// entity tracked by the session on the next SaveChanges. The entity is
+// updated from raw. Fields of raw that don't map to the entity's type
+// are saved but are dropped if the entity is modified and saved again.
+// @metadata in raw is ignored, use GetMetadataFor to modify metadata
+func (s *InMemoryDocumentSessionOperations) SetRawEntityByID(id string, raw map[string]interface{}) error {
+	if raw == nil {
+		return newIllegalArgumentError("raw cannot be nil")
+	}
+	documentInfo := s.documentsByID.getValue(id)
+	if documentInfo == nil || documentInfo.entity == nil {
+		return newIllegalStateError("Document '%s' is not tracked by the session", id)
+	}
+	if s.deletedEntities.contains(documentInfo.entity) {
+		return newIllegalStateError("Document '%s' is marked for deletion", id)
+	}
+
+	raw = deepCopy(raw).(map[string]interface{})
+	delete(raw, MetadataKey)
+	entity, err := s.entityToJSON.convertToEntity(reflect.TypeOf(documentInfo.entity), id, raw)
+	if err != nil {
+		return err
+	}
+	if err = copyValue(documentInfo.entity, entity); err != nil {
+		return newRuntimeError("Unable to update entity: %s", err)
+	}
+	documentInfo.rawDocument = raw
+	return nil
+}
+
 // Evict evicts the specified entity from the session.
 // Remove the entity from the delete queue and stops tracking changes for this entity.
 func (s *InMemoryDocumentSessionOperations) Evict(entity interface{}) error {
@@ -1065,6 +1282,9 @@ func (s *InMemoryDocumentSessionOperations) Clear() {
 	s.documentsByID = nil
 	s.knownMissingIds = nil
 	s.includedDocumentsByID = nil
+	s.includedCountersByDocID = nil
+	s.includedTimeSeriesByDocID = nil
+	s.includedCompareExchangeValues = nil
 }
 
 // Defer defers commands to be executed on SaveChanges()
@@ -1134,6 +1354,93 @@ func (s *InMemoryDocumentSessionOperations) registerIncludes(includes map[string
 	}
 }
 
+func (s *InMemoryDocumentSessionOperations) registerCounters(counters map[string][]*CounterDetail) {
+	for docID, details := range counters {
+		if s.includedCountersByDocID == nil {
+			s.includedCountersByDocID = map[string]map[string]int64{}
+		}
+		key := strings.ToLower(docID)
+		cache := s.includedCountersByDocID[key]
+		if cache == nil {
+			cache = map[string]int64{}
+			s.includedCountersByDocID[key] = cache
+		}
+		for _, detail := range details {
+			// counters that don't exist are nil
+			if detail != nil {
+				cache[detail.CounterName] = detail.TotalValue
+			}
+		}
+	}
+}
+
+func (s *InMemoryDocumentSessionOperations) registerTimeSeries(timeSeries map[string]map[string][]*TimeSeriesRangeResult) {
+	for docID, byName := range timeSeries {
+		if s.includedTimeSeriesByDocID == nil {
+			s.includedTimeSeriesByDocID = map[string]map[string][]*TimeSeriesRangeResult{}
+		}
+		key := strings.ToLower(docID)
+		cache := s.includedTimeSeriesByDocID[key]
+		if cache == nil {
+			cache = map[string][]*TimeSeriesRangeResult{}
+			s.includedTimeSeriesByDocID[key] = cache
+		}
+		for name, ranges := range byName {
+			for _, r := range ranges {
+				if r == nil {
+					continue
+				}
+				cache[name] = addTimeSeriesRange(cache[name], r)
+			}
+		}
+	}
+}
+
+func (s *InMemoryDocumentSessionOperations) registerCompareExchangeValues(values map[string]map[string]interface{}) {
+	for key, value := range values {
+		if s.includedCompareExchangeValues == nil {
+			s.includedCompareExchangeValues = map[string]map[string]interface{}{}
+		}
+		s.includedCompareExchangeValues[key] = value
+	}
+}
+
+// GetIncludedCountersFor returns values of counters of instance that were
+// included when loading it with MultiLoaderWithInclude.IncludeCounters.
+// Returns nil if no counters were included. Counters that don't exist
+// are not in the result.
+func (s *InMemoryDocumentSessionOperations) GetIncludedCountersFor(instance interface{}) (map[string]int64, error) {
+	err := checkValidEntityIn(instance, "instance")
+	if err != nil {
+		return nil, err
+	}
+	documentInfo, err := s.getDocumentInfo(instance)
+	if err != nil {
+		return nil, err
+	}
+	return s.includedCountersByDocID[strings.ToLower(documentInfo.id)], nil
+}
+
+// GetIncludedTimeSeriesFor returns entries of time series name of instance
+// that were included when loading it with
+// MultiLoaderWithInclude.IncludeTimeSeries.
+// Returns nil if the time series wasn't included.
+func (s *InMemoryDocumentSessionOperations) GetIncludedTimeSeriesFor(instance interface{}, name string) ([]*TimeSeriesEntry, error) {
+	err := checkValidEntityIn(instance, "instance")
+	if err != nil {
+		return nil, err
+	}
+	documentInfo, err := s.getDocumentInfo(instance)
+	if err != nil {
+		return nil, err
+	}
+	var res []*TimeSeriesEntry
+	for _, r := range s.includedTimeSeriesByDocID[strings.ToLower(documentInfo.id)][name] {
+		res = append(res, r.Entries...)
+	}
+	return res, nil
+}
+
 func (s *InMemoryDocumentSessionOperations) registerMissingIncludes(results []map[string]interface{}, includes map[string]interface{}, includePaths []string) {
 	if len(includePaths) == 0 {
 		return
@@ -1259,7 +1566,14 @@ func processQueryParameters(clazz reflect.Type, indexName string, collectionName
 	}
 
 	if !isIndex && !isCollection {
-		collectionName = conventions.getCollectionName(clazz)
+		if isInterfaceType(clazz) {
+			return "", "", ErrCannotInferCollectionForInterface
+		}
+		// without a type the query is over all documents unless
+		// FromIndex or FromCollection is used
+		if clazz != nil {
+			collectionName = conventions.getCollectionName(clazz)
+		}
 		if collectionName == "" {
 			// TODO: what test would exercise this code path?
 			collectionName = MetadataAllDocumentsCollection
@@ -1275,6 +1589,7 @@ type saveChangesData struct {
 	sessionCommands     []ICommandData
 	entities            []interface{}
 	options             *BatchOptions
+	transactionMode     TransactionMode
 }
 
 func newSaveChangesData(session *InMemoryDocumentSessionOperations) *saveChangesData {
@@ -0,0 +1,127 @@
+package ravendb
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildDefaultMetadata(t *testing.T) {
+	conventions := NewDocumentConventions()
+	metadata := buildDefaultMetadata(&User{}, conventions)
+	assert.Equal(t, "Users", metadata[MetadataCollection])
+	assert.Equal(t, "ravendb.User", metadata[MetadataRavenGoType])
+	assert.Equal(t, 2, len(metadata))
+
+	conventions.FindCollectionName = func(interface{}) string {
+		return ""
+	}
+	metadata = buildDefaultMetadata(&User{}, conventions)
+	_, ok := metadata[MetadataCollection]
+	assert.False(t, ok)
+}
+
+type ReadOnlyView struct {
+	Name string
+}
+
+func TestSessionShouldIgnoreEntityChanges(t *testing.T) {
+	var paths []string
+	fn := func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		if r.URL.Path != "/databases/db/docs" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"Results": [{"Name": "John", "@metadata": {"@id": "views/1", "@collection": "ReadOnlyViews", "@change-vector": "A:1"}}], "Includes": {}}`))
+	}
+	store := newFakeServerStore(t, fn)
+	store.GetConventions().ShouldIgnoreEntityChanges = func(sessionOperations *InMemoryDocumentSessionOperations, entity interface{}, id string) bool {
+		_, ok := entity.(*ReadOnlyView)
+		return ok
+	}
+	session, err := store.OpenSession("")
+	require.NoError(t, err)
+	defer session.Close()
+
+	var view *ReadOnlyView
+	err = session.Load(&view, "views/1")
+	require.NoError(t, err)
+	require.NotNil(t, view)
+	view.Name = "Jane"
+
+	changes, err := session.Advanced().WhatChanged()
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(changes))
+	assert.False(t, session.Advanced().HasChanges())
+	changed, err := session.Advanced().HasChanged(view)
+	require.NoError(t, err)
+	assert.False(t, changed)
+
+	err = session.SaveChanges()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/databases/db/docs"}, paths)
+
+	// the entity is still tracked by the session
+	assert.True(t, session.Advanced().IsLoaded("views/1"))
+}
+
+func TestSessionRawEntityByID(t *testing.T) {
+	var putBody map[string]interface{}
+	fn := func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/databases/db/docs":
+			_, _ = w.Write([]byte(`{"Results": [{"Name": "John", "@metadata": {"@id": "users/1", "@collection": "Users", "@change-vector": "A:1"}}], "Includes": {}}`))
+		case "/databases/db/bulk_docs":
+			var body struct {
+				Commands []map[string]interface{}
+			}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			putBody, _ = body.Commands[0]["Document"].(map[string]interface{})
+			_, _ = w.Write([]byte(`{"Results": [{"Type": "PUT", "@id": "users/1", "@collection": "Users", "@change-vector": "A:2"}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+	store := newFakeServerStore(t, fn)
+	session, err := store.OpenSession("")
+	require.NoError(t, err)
+	defer session.Close()
+
+	_, ok := session.Advanced().GetRawEntityByID("users/1")
+	assert.False(t, ok)
+	err = session.Advanced().SetRawEntityByID("users/1", map[string]interface{}{})
+	_, ok = err.(*IllegalStateError)
+	assert.True(t, ok)
+
+	var user *User
+	require.NoError(t, session.Load(&user, "users/1"))
+	raw, ok := session.Advanced().GetRawEntityByID("users/1")
+	require.True(t, ok)
+	assert.Equal(t, "John", raw["Name"])
+
+	// the returned map is a copy
+	raw["Name"] = "Jane"
+	raw["Extra"] = 5
+	assert.Equal(t, "John", user.Name)
+	assert.False(t, session.Advanced().HasChanges())
+
+	require.NoError(t, session.Advanced().SetRawEntityByID("users/1", raw))
+	assert.Equal(t, "Jane", user.Name)
+	assert.True(t, session.Advanced().HasChanges())
+	raw, _ = session.Advanced().GetRawEntityByID("users/1")
+	assert.Equal(t, "Jane", raw["Name"])
+
+	require.NoError(t, session.SaveChanges())
+	require.NotNil(t, putBody)
+	assert.Equal(t, "Jane", putBody["Name"])
+	// fields not in User are preserved
+	assert.Equal(t, float64(5), putBody["Extra"])
+	meta := putBody[MetadataKey].(map[string]interface{})
+	assert.Equal(t, "Users", meta[MetadataCollection])
+
+	assert.False(t, session.Advanced().HasChanges())
+}
@@ -1,5 +1,25 @@
 package ravendb
 
+// CreateIndexes deploys indexes created from tasks to the default database
+// of the store, like store.ExecuteIndexes(tasks, ""). The server doesn't
+// reset an index whose definition didn't change, so deploying the same
+// tasks repeatedly is a no-op.
+func CreateIndexes(store *DocumentStore, tasks ...*IndexCreationTask) error {
+	return store.ExecuteIndexes(tasks, "")
+}
+
+// indexCreationValidateName rejects names that are too long and names of
+// side-by-side indexes which are reserved for the server
+func indexCreationValidateName(indexName string) error {
+	if len(indexName) > 256 {
+		return newIllegalArgumentError("The index name is limited to 256 characters, but was: %s", indexName)
+	}
+	if IsIndexReplacement(indexName) {
+		return newIllegalArgumentError("Index name '%s' cannot start with '%s'. Put the index under its original name and the server will build the replacement side-by-side", indexName, IndexingSideBySideIndexNamePrefix)
+	}
+	return nil
+}
+
 func indexCreationCreateIndexesToAdd(indexCreationTasks []*IndexCreationTask, conventions *DocumentConventions) []*IndexDefinition {
 	var res []*IndexDefinition
 	for _, x := range indexCreationTasks {
@@ -11,6 +31,7 @@ func indexCreationCreateIndexesToAdd(indexCreationTasks []*IndexCreationTask, co
 			pri = IndexPriorityNormal
 		}
 		definition.Priority = pri
+		definition.LockMode = x.LockMode
 		res = append(res, definition)
 	}
 	return res
@@ -1,10 +1,22 @@
 package ravendb
 
+// IndexDefinitionBuilder builds IndexDefinition from map and reduce
+// functions and options of index fields, e.g.:
+//
+//	def, err := NewIndexDefinitionBuilder("Users/ByName").
+//	    Map("from u in docs.Users select new { u.name }").
+//	    Index("name", FieldIndexingSearch).
+//	    Analyze("name", "StandardAnalyzer").
+//	    ToIndexDefinition(nil)
+//
+// The definition can be deployed with NewPutIndexesOperation
 type IndexDefinitionBuilder struct {
 	indexName string
 
 	smap   string // Note: in Go map is a reserved keyword
 	reduce string
+	// maps after the first, for multi-map indexes
+	additionalMaps []string
 
 	storesStrings            map[string]FieldStorage
 	indexesStrings           map[string]FieldIndexing
@@ -16,13 +28,16 @@ type IndexDefinitionBuilder struct {
 	priority                 IndexPriority
 	outputReduceToCollection string
 	additionalSources        map[string]string
+	configuration            IndexConfiguration
+
+	// first error of a builder method, returned by ToIndexDefinition
+	err error
 }
 
 func NewIndexDefinitionBuilder(indexName string) *IndexDefinitionBuilder {
 	if indexName == "" {
 		indexName = "IndexDefinitionBuilder"
 	}
-	panicIf(len(indexName) > 256, "The index name is limited to 256 characters, but was: %s", indexName)
 	return &IndexDefinitionBuilder{
 		indexName:             indexName,
 		storesStrings:         make(map[string]FieldStorage),
@@ -102,6 +117,9 @@ func (d *IndexDefinitionBuilder) toIndexDefinition(conventions *DocumentConventi
 	}
 
 	indexDefinition.SetAdditionalSources(d.additionalSources)
+	for key, value := range d.configuration {
+		indexDefinition.GetConfiguration()[key] = value
+	}
 	return indexDefinition
 }
 
@@ -184,3 +202,165 @@ func (d *IndexDefinitionBuilder) getMap() string {
 func (d *IndexDefinitionBuilder) setMap(smap string) {
 	d.smap = smap
 }
+
+func (d *IndexDefinitionBuilder) setErr(err error) {
+	if d.err == nil {
+		d.err = err
+	}
+}
+
+func (d *IndexDefinitionBuilder) validateFieldName(field string) bool {
+	if stringIsBlank(field) {
+		d.setErr(newIllegalArgumentError("Field name cannot be empty (in index %s)", d.indexName))
+		return false
+	}
+	return true
+}
+
+// Map sets the map function of the index. Calling it more than once
+// creates a multi-map index
+func (d *IndexDefinitionBuilder) Map(smap string) *IndexDefinitionBuilder {
+	if stringIsBlank(smap) {
+		d.setErr(newIllegalArgumentError("Map cannot be empty (in index %s)", d.indexName))
+		return d
+	}
+	if d.smap == "" {
+		d.smap = smap
+	} else {
+		d.additionalMaps = append(d.additionalMaps, smap)
+	}
+	return d
+}
+
+// Reduce sets the reduce function of a map-reduce index
+func (d *IndexDefinitionBuilder) Reduce(reduce string) *IndexDefinitionBuilder {
+	d.reduce = reduce
+	return d
+}
+
+// Index sets how field is indexed
+func (d *IndexDefinitionBuilder) Index(field string, indexing FieldIndexing) *IndexDefinitionBuilder {
+	if d.validateFieldName(field) {
+		d.indexesStrings[field] = indexing
+	}
+	return d
+}
+
+// Store sets if the value of field is stored in the index
+func (d *IndexDefinitionBuilder) Store(field string, storage FieldStorage) *IndexDefinitionBuilder {
+	if d.validateFieldName(field) {
+		d.storesStrings[field] = storage
+	}
+	return d
+}
+
+// StoreAllFields sets if values of all fields are stored in the index
+func (d *IndexDefinitionBuilder) StoreAllFields(storage FieldStorage) *IndexDefinitionBuilder {
+	return d.Store(IndexingFieldAllFields, storage)
+}
+
+// Analyze sets analyzer of field
+func (d *IndexDefinitionBuilder) Analyze(field string, analyzer string) *IndexDefinitionBuilder {
+	if !d.validateFieldName(field) {
+		return d
+	}
+	if stringIsBlank(analyzer) {
+		d.setErr(newIllegalArgumentError("Analyzer of field '%s' cannot be empty (in index %s)", field, d.indexName))
+		return d
+	}
+	d.analyzersStrings[field] = analyzer
+	return d
+}
+
+// TermVector sets term vector of field
+func (d *IndexDefinitionBuilder) TermVector(field string, termVector FieldTermVector) *IndexDefinitionBuilder {
+	if d.validateFieldName(field) {
+		d.termVectorsStrings[field] = termVector
+	}
+	return d
+}
+
+// Suggestion enables suggestions for field
+func (d *IndexDefinitionBuilder) Suggestion(field string) *IndexDefinitionBuilder {
+	if d.validateFieldName(field) {
+		d.suggestionsOptions = append(d.suggestionsOptions, field)
+	}
+	return d
+}
+
+// Spatial sets options of a spatial field
+func (d *IndexDefinitionBuilder) Spatial(field string, options *SpatialOptions) *IndexDefinitionBuilder {
+	if d.validateFieldName(field) {
+		d.spatialIndexesStrings[field] = options
+	}
+	return d
+}
+
+// LockMode sets lock mode of the index
+func (d *IndexDefinitionBuilder) LockMode(lockMode IndexLockMode) *IndexDefinitionBuilder {
+	d.lockMode = lockMode
+	return d
+}
+
+// Priority sets priority of the index
+func (d *IndexDefinitionBuilder) Priority(priority IndexPriority) *IndexDefinitionBuilder {
+	d.priority = priority
+	return d
+}
+
+// OutputReduceToCollection sets the collection that results of
+// a map-reduce index are saved to
+func (d *IndexDefinitionBuilder) OutputReduceToCollection(collection string) *IndexDefinitionBuilder {
+	d.outputReduceToCollection = collection
+	return d
+}
+
+// AdditionalSource adds source code available to map and reduce functions
+func (d *IndexDefinitionBuilder) AdditionalSource(name string, source string) *IndexDefinitionBuilder {
+	if d.additionalSources == nil {
+		d.additionalSources = map[string]string{}
+	}
+	d.additionalSources[name] = source
+	return d
+}
+
+// Configuration overrides server configuration key for this index
+func (d *IndexDefinitionBuilder) Configuration(key string, value string) *IndexDefinitionBuilder {
+	if d.configuration == nil {
+		d.configuration = NewIndexConfiguration()
+	}
+	d.configuration[key] = value
+	return d
+}
+
+// ToIndexDefinition returns index definition built so far or the first
+// error of the builder methods. It fails if map wasn't set or if a field
+// that is not indexed has an analyzer or a term vector
+func (d *IndexDefinitionBuilder) ToIndexDefinition(conventions *DocumentConventions) (*IndexDefinition, error) {
+	if d.err != nil {
+		return nil, d.err
+	}
+	if d.smap == "" {
+		return nil, newIllegalStateError("Map is required to generate an index, you cannot create an index without a valid Map property (in index %s).", d.indexName)
+	}
+	if err := indexCreationValidateName(d.indexName); err != nil {
+		return nil, err
+	}
+	for field := range d.analyzersStrings {
+		if d.indexesStrings[field] == FieldIndexingNo {
+			return nil, newIllegalStateError("Field '%s' is not indexed so it cannot have an analyzer (in index %s)", field, d.indexName)
+		}
+	}
+	for field, termVector := range d.termVectorsStrings {
+		if termVector != FieldTermVectorNo && d.indexesStrings[field] == FieldIndexingNo {
+			return nil, newIllegalStateError("Field '%s' is not indexed so it cannot have term vectors (in index %s)", field, d.indexName)
+		}
+	}
+
+	if conventions == nil {
+		conventions = NewDocumentConventions()
+	}
+	def := d.toIndexDefinition(conventions, true)
+	def.Maps = append(def.Maps, d.additionalMaps...)
+	return def, nil
+}
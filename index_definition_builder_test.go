@@ -0,0 +1,86 @@
+package ravendb
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	usersByNameMap    = "from c in docs.Users select new { c.name, count = 1 }"
+	usersByNameReduce = "from result in results group result by result.name into g select new { name = g.Key, count = g.Sum(x => x.count) }"
+)
+
+func TestIndexDefinitionBuilderMatchesIndexCreationTask(t *testing.T) {
+	task := NewIndexCreationTask("UsersByName")
+	task.Map = usersByNameMap
+	task.Reduce = usersByNameReduce
+	task.Suggestion("name")
+	task.Index("name", FieldIndexingSearch)
+	task.Analyze("name", "StandardAnalyzer")
+	task.Store("count", FieldStorageYes)
+	task.TermVector("name", FieldTermVectorWithPositionsAndOffsets)
+	exp := task.CreateIndexDefinition()
+
+	def, err := NewIndexDefinitionBuilder("UsersByName").
+		Map(usersByNameMap).
+		Reduce(usersByNameReduce).
+		Suggestion("name").
+		Index("name", FieldIndexingSearch).
+		Analyze("name", "StandardAnalyzer").
+		Store("count", FieldStorageYes).
+		TermVector("name", FieldTermVectorWithPositionsAndOffsets).
+		ToIndexDefinition(nil)
+	require.NoError(t, err)
+	assert.Equal(t, exp, def)
+
+	name := def.Fields["name"]
+	assert.Equal(t, FieldIndexing(FieldIndexingSearch), name.Indexing)
+	assert.Equal(t, "StandardAnalyzer", name.Analyzer)
+	assert.True(t, name.Suggestions)
+	assert.Equal(t, FieldStorageYes, def.Fields["count"].Storage)
+	assert.Equal(t, usersByNameReduce, *def.Reduce)
+}
+
+func TestIndexDefinitionBuilderMultiMap(t *testing.T) {
+	def, err := NewIndexDefinitionBuilder("People/ByName").
+		Map("from u in docs.Users select new { u.name }").
+		Map("from c in docs.Companies select new { c.name }").
+		Priority(IndexPriorityHigh).
+		Configuration("Indexing.MapTimeoutInSec", "30").
+		ToIndexDefinition(nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"from u in docs.Users select new { u.name }", "from c in docs.Companies select new { c.name }"}, def.Maps)
+	assert.Equal(t, IndexPriorityHigh, def.Priority)
+	assert.Equal(t, "30", def.Configuration["Indexing.MapTimeoutInSec"])
+}
+
+func TestIndexDefinitionBuilderValidation(t *testing.T) {
+	_, err := NewIndexDefinitionBuilder("Users/ByName").Index("name", FieldIndexingSearch).ToIndexDefinition(nil)
+	_, ok := err.(*IllegalStateError)
+	assert.True(t, ok, "expected *IllegalStateError, got %T (%v)", err, err)
+
+	_, err = NewIndexDefinitionBuilder("Users/ByName").Map(usersByNameMap).Index("", FieldIndexingSearch).ToIndexDefinition(nil)
+	_, ok = err.(*IllegalArgumentError)
+	assert.True(t, ok, "expected *IllegalArgumentError, got %T (%v)", err, err)
+
+	_, err = NewIndexDefinitionBuilder("Users/ByName").Map(usersByNameMap).Analyze("name", "").ToIndexDefinition(nil)
+	assert.Error(t, err)
+
+	_, err = NewIndexDefinitionBuilder("Users/ByName").Map(usersByNameMap).
+		Index("name", FieldIndexingNo).Analyze("name", "StandardAnalyzer").ToIndexDefinition(nil)
+	assert.Error(t, err)
+
+	_, err = NewIndexDefinitionBuilder("Users/ByName").Map(usersByNameMap).
+		Index("name", FieldIndexingNo).TermVector("name", FieldTermVectorYes).ToIndexDefinition(nil)
+	assert.Error(t, err)
+
+	_, err = NewIndexDefinitionBuilder("ReplacementOf/Users/ByName").Map(usersByNameMap).ToIndexDefinition(nil)
+	assert.Error(t, err)
+
+	_, err = NewIndexDefinitionBuilder(strings.Repeat("a", 257)).Map(usersByNameMap).ToIndexDefinition(nil)
+	_, ok = err.(*IllegalArgumentError)
+	assert.True(t, ok, "expected *IllegalArgumentError, got %T (%v)", err, err)
+}
@@ -2,16 +2,23 @@ package ravendb
 
 import "time"
 
+// IndexInformation is a short description of an index, returned as part of
+// DatabaseStatistics. Detailed statistics of an index, like errors count
+// or last indexed etag, are returned by GetIndexesStatisticsOperation.
 type IndexInformation struct {
-	Name             string        `json:"Name"`
-	IsStale          bool          `json:"IsStale"`
-	State            IndexState    `json:"State"`
-	LockMode         IndexLockMode `json:"LockMode"`
-	Priority         IndexPriority `json:"Priority"`
-	Type             IndexType     `json:"Type"`
-	LastIndexingTime Time          `json:"LastIndexingTime"`
+	Name     string        `json:"Name"`
+	IsStale  bool          `json:"IsStale"`
+	State    IndexState    `json:"State"`
+	LockMode IndexLockMode `json:"LockMode"`
+	Priority IndexPriority `json:"Priority"`
+	Type     IndexType     `json:"Type"`
+	// SourceType is only returned by 5.x servers
+	SourceType       IndexSourceType `json:"SourceType"`
+	LastIndexingTime Time            `json:"LastIndexingTime"`
 }
 
+// GetLastIndexingTime returns last indexing time, zero value if the index
+// hasn't indexed anything yet
 func (i *IndexInformation) GetLastIndexingTime() time.Time {
 	return time.Time(i.LastIndexingTime)
 }
@@ -15,7 +15,9 @@ type IndexQuery struct {
 	waitForNonStaleResultsTimeout time.Duration
 
 	// from IndexQueryWithParameters
-	skipDuplicateChecking bool
+	skipDuplicateChecking    bool
+	projectionBehavior       ProjectionBehavior
+	disableAutoIndexCreation bool
 
 	// from IndexQuery
 	disableCaching bool
@@ -41,11 +43,18 @@ func (q *IndexQuery) GetQueryParameters() Parameters {
 	return q.queryParameters
 }
 
+// GetQueryHash returns a hash of the query text and its options and
+// parameters. Queries built the same way (same clauses in the same order)
+// get the same parameter names so they only differ in hash if parameter
+// values or options differ. It's used as a key for http cache of query
+// results.
 func (q *IndexQuery) GetQueryHash() string {
 	hasher := &HashCalculator{}
 	hasher.write(q.query)
 	hasher.write(q.waitForNonStaleResults)
 	hasher.write(q.skipDuplicateChecking)
+	hasher.write(q.projectionBehavior)
+	hasher.write(q.disableAutoIndexCreation)
 	//TBD 4.1 hasher.write(isShowTimings());
 	//TBD 4.1 hasher.write(isExplainScores());
 	n := int64(q.waitForNonStaleResultsTimeout)
@@ -0,0 +1,33 @@
+package ravendb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func queryHash(t *testing.T, q *DocumentQuery) string {
+	iq, err := q.GetIndexQuery()
+	require.NoError(t, err)
+	return iq.GetQueryHash()
+}
+
+func TestIndexQueryGetQueryHash(t *testing.T) {
+	session := newQueryTestSession()
+
+	build := func(name string, age int) *DocumentQuery {
+		return session.QueryCollection("Users").WhereEquals("name", name).AndAlso().WhereGreaterThan("age", age)
+	}
+
+	// structurally identical queries with the same values have the same hash
+	assert.Equal(t, queryHash(t, build("John", 3)), queryHash(t, build("John", 3)))
+	assert.NotEqual(t, queryHash(t, build("John", 3)), queryHash(t, build("John", 4)))
+	assert.NotEqual(t, queryHash(t, build("John", 3)), queryHash(t, build("Jane", 3)))
+
+	// values after a nil parameter are part of the hash
+	withNil := func(age int) *DocumentQuery {
+		return session.QueryCollection("Users").WhereEquals("name", nil).AndAlso().WhereEquals("age", age)
+	}
+	assert.NotEqual(t, queryHash(t, withNil(3)), queryHash(t, withNil(4)))
+}
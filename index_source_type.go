@@ -0,0 +1,11 @@
+package ravendb
+
+// IndexSourceType describes what an index is built from
+type IndexSourceType = string
+
+const (
+	IndexSourceTypeNone       = "None"
+	IndexSourceTypeDocuments  = "Documents"
+	IndexSourceTypeTimeSeries = "TimeSeries"
+	IndexSourceTypeCounters   = "Counters"
+)
@@ -19,7 +19,7 @@ type IndexStats struct {
 	Priority         IndexPriority      `json:"Priority"`
 	CreatedTimestamp Time               `json:"CreatedTimestamp"`
 	LastIndexingTime Time               `json:"LastIndexingTime"`
-	IsStale          bool               `json:"Stale"`
+	IsStale          bool               `json:"IsStale"`
 	LockMode         IndexLockMode      `json:"LockMode"`
 	Type             IndexType          `json:"Type"`
 	Status           IndexRunningStatus `json:"Status"`
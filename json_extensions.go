@@ -27,9 +27,17 @@ func jsonExtensionsWriteIndexQuery(conventions *DocumentConventions, query *Inde
 	if query.skipDuplicateChecking {
 		res["SkipDuplicateChecking"] = query.skipDuplicateChecking
 	}
+
+	if query.projectionBehavior != "" && query.projectionBehavior != ProjectionBehaviorDefault {
+		res["ProjectionBehavior"] = query.projectionBehavior
+	}
+
+	if query.disableAutoIndexCreation {
+		res["DisableAutoIndexCreation"] = query.disableAutoIndexCreation
+	}
 	params := query.queryParameters
 	if params != nil {
-		res["QueryParameters"] = convertEntityToJSON(params, nil)
+		res["QueryParameters"] = params
 	} else {
 		res["QueryParameters"] = nil
 	}
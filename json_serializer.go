@@ -0,0 +1,53 @@
+package ravendb
+
+import "reflect"
+
+// JSONSerializer converts entities to and from JSON.
+// It can be set as DocumentConventions.JSONSerializer to use a different
+// JSON library (e.g. jsoniter) or to customize how entities are stored.
+// The JSON it produces must be a JSON object.
+type JSONSerializer interface {
+	Marshal(v interface{}) ([]byte, error)
+	Unmarshal(data []byte, v interface{}) error
+}
+
+// defaultJSONSerializer uses encoding/json
+type defaultJSONSerializer struct{}
+
+func (defaultJSONSerializer) Marshal(v interface{}) ([]byte, error) {
+	return jsonMarshal(v)
+}
+
+func (defaultJSONSerializer) Unmarshal(data []byte, v interface{}) error {
+	return jsonUnmarshal(data, v)
+}
+
+// getJSONSerializer returns serializer configured in conventions
+// or the default serializer if none is configured
+func getJSONSerializer(conventions *DocumentConventions) JSONSerializer {
+	if conventions == nil || conventions.JSONSerializer == nil {
+		return defaultJSONSerializer{}
+	}
+	return conventions.JSONSerializer
+}
+
+// entityToJSONMap serializes an entity to JSON using serializer configured
+// in conventions and returns it in the form of map[string]interface{}
+func entityToJSONMap(conventions *DocumentConventions, entity interface{}) (map[string]interface{}, error) {
+	d, err := getJSONSerializer(conventions).Marshal(entity)
+	if err != nil {
+		return nil, err
+	}
+	var res map[string]interface{}
+	err = jsonUnmarshal(d, &res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// entityFromJSONMap is like makeStructFromJSONMap but uses serializer configured
+// in conventions
+func entityFromJSONMap(conventions *DocumentConventions, typ reflect.Type, js map[string]interface{}) (interface{}, error) {
+	return makeStructFromJSONMapWithSerializer(getJSONSerializer(conventions), typ, js)
+}
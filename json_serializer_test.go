@@ -0,0 +1,50 @@
+package ravendb
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type failingJSONSerializer struct {
+	defaultJSONSerializer
+}
+
+var errFailingSerializer = errors.New("cannot serialize")
+
+func (failingJSONSerializer) Marshal(v interface{}) ([]byte, error) {
+	return nil, errFailingSerializer
+}
+
+func TestJSONSerializerErrorIsReturned(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	defer srv.Close()
+
+	store := NewDocumentStore([]string{srv.URL}, "db")
+	store.GetConventions().SetDisableTopologyUpdates(true)
+	store.GetConventions().JSONSerializer = failingJSONSerializer{}
+	require.NoError(t, store.Initialize())
+	defer store.Close()
+
+	session, err := store.OpenSession("")
+	require.NoError(t, err)
+	defer session.Close()
+
+	user := &User{Name: "John"}
+	require.NoError(t, session.StoreWithID(user, "users/1"))
+
+	_, err = session.Advanced().HasChanged(user)
+	assert.Equal(t, errFailingSerializer, err)
+	_, err = session.Advanced().WhatChanged()
+	assert.Equal(t, errFailingSerializer, err)
+	_, ok := session.Advanced().GetRawEntityByID("users/1")
+	assert.False(t, ok)
+	assert.True(t, session.Advanced().HasChanges())
+
+	err = session.SaveChanges()
+	assert.Equal(t, errFailingSerializer, err)
+}
@@ -29,7 +29,7 @@ func NewKillOperationCommand(id string) (*KillOperationCommand, error) {
 }
 
 func (c *KillOperationCommand) CreateRequest(node *ServerNode) (*http.Request, error) {
-	url := node.URL + "/databases/" + node.Database + "/operations/kill?id=" + c.id
+	url := node.URL + "/databases/" + node.Database + "/operations/kill?id=" + urlUtilsEscapeDataString(c.id)
 
 	return NewHttpPost(url, nil)
 }
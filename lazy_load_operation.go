@@ -37,7 +37,7 @@ func (o *LazyLoadOperation) createRequest() *getRequest {
 	if o._includes != nil {
 		for _, include := range o._includes {
 			queryBuilder += "&include="
-			queryBuilder += include
+			queryBuilder += urlUtilsEscapeDataString(include)
 		}
 	}
 
@@ -0,0 +1,85 @@
+package ravendb
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newMultiGetHandler answers multi_get requests for documents,
+// reporting server time of each request as 5 ms.
+// Number of requests in each multi_get is appended to batches
+func newMultiGetHandler(batches *[]int) http.HandlerFunc {
+	fn := func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/databases/db/multi_get" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		var body struct {
+			Requests []struct {
+				Query string
+			}
+		}
+		d, _ := ioutil.ReadAll(r.Body)
+		_ = json.Unmarshal(d, &body)
+		*batches = append(*batches, len(body.Requests))
+
+		var results []string
+		for _, req := range body.Requests {
+			q, _ := url.ParseQuery(strings.TrimPrefix(req.Query, "?"))
+			id := q.Get("id")
+			doc := fmt.Sprintf(`{"Results": [{"name": "%s", "@metadata": {"@id": "%s", "@collection": "Users", "@change-vector": "A:1"}}], "Includes": {}}`, id, id)
+			results = append(results, `{"StatusCode": 200, "Headers": {"Raven-Request-Time": "5"}, "Result": `+doc+`}`)
+		}
+		_, _ = w.Write([]byte(`{"Results": [` + strings.Join(results, ",") + `]}`))
+	}
+	return fn
+}
+
+func TestExecuteAllPendingLazyOperations(t *testing.T) {
+	var batches []int
+	store := newFakeServerStore(t, newMultiGetHandler(&batches))
+	store.GetConventions().MaxNumberOfLazyOperationsPerRequest = 2
+
+	session, err := store.OpenSession("")
+	require.NoError(t, err)
+	defer session.Close()
+
+	var lazies []*Lazy
+	for i := 1; i <= 5; i++ {
+		lazy, err := session.Lazily().Load(fmt.Sprintf("users/%d", i))
+		require.NoError(t, err)
+		lazies = append(lazies, lazy)
+	}
+
+	info, err := session.Advanced().Eagerly().ExecuteAllPendingLazyOperations()
+	require.NoError(t, err)
+	assert.Equal(t, []int{2, 2, 1}, batches)
+	assert.Equal(t, 3, session.GetNumberOfRequests())
+
+	require.Equal(t, 5, len(info.DurationBreakdown))
+	assert.Equal(t, time.Millisecond*5, info.DurationBreakdown[0].Duration)
+	assert.Contains(t, info.DurationBreakdown[4].URL, "/docs")
+	assert.Equal(t, time.Millisecond*25, info.TotalServerDuration)
+	assert.True(t, info.TotalClientDuration > 0)
+
+	// values are available without further requests
+	var user *User
+	err = lazies[4].GetValue(&user)
+	require.NoError(t, err)
+	assert.Equal(t, "users/5", user.ID)
+	assert.Equal(t, 3, len(batches))
+
+	// nothing left to execute
+	info, err = session.Advanced().Eagerly().ExecuteAllPendingLazyOperations()
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(info.DurationBreakdown))
+}
@@ -50,7 +50,7 @@ func (o *LazyStartsWithOperation) createRequest() *getRequest {
 		urlUtilsEscapeDataString(o.exclude),
 		o.start,
 		pageSize,
-		o.startAfter)
+		urlUtilsEscapeDataString(o.startAfter))
 
 	request := &getRequest{
 		url:   "/docs",
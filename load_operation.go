@@ -12,7 +12,13 @@ type LoadOperation struct {
 
 	ids                []string
 	includes           []string
+	counters           []string
+	timeSeries         []*TimeSeriesRange
 	idsToCheckOnServer []string
+
+	// documents loaded by a session with disabled tracking, by
+	// lower-cased id
+	noTrackingDocuments map[string]*documentInfo
 }
 
 func NewLoadOperation(session *InMemoryDocumentSessionOperations) *LoadOperation {
@@ -26,7 +32,7 @@ func (o *LoadOperation) createRequest() (*GetDocumentsCommand, error) {
 		return nil, nil
 	}
 
-	if o.session.checkIfIdAlreadyIncluded(o.ids, o.includes) {
+	if !o.hasCounterOrTimeSeriesIncludes() && o.session.checkIfIdAlreadyIncluded(o.ids, o.includes) {
 		return nil, nil
 	}
 
@@ -34,7 +40,13 @@ func (o *LoadOperation) createRequest() (*GetDocumentsCommand, error) {
 		return nil, err
 	}
 
-	return NewGetDocumentsCommand(o.idsToCheckOnServer, o.includes, false)
+	return NewGetDocumentsCommandWithIncludes(o.idsToCheckOnServer, o.includes, o.counters, o.timeSeries, false)
+}
+
+// counters and time series are not tracked by the session so they must
+// be fetched even if the documents are already loaded
+func (o *LoadOperation) hasCounterOrTimeSeriesIncludes() bool {
+	return len(o.counters) > 0 || len(o.timeSeries) > 0
 }
 
 func (o *LoadOperation) byID(id string) *LoadOperation {
@@ -46,7 +58,7 @@ func (o *LoadOperation) byID(id string) *LoadOperation {
 		o.ids = []string{id}
 	}
 
-	if o.session.IsLoadedOrDeleted(id) {
+	if o.session.IsLoadedOrDeleted(id) && !o.hasCounterOrTimeSeriesIncludes() {
 		return o
 	}
 
@@ -59,6 +71,16 @@ func (o *LoadOperation) withIncludes(includes []string) *LoadOperation {
 	return o
 }
 
+func (o *LoadOperation) withCounters(counters []string) *LoadOperation {
+	o.counters = counters
+	return o
+}
+
+func (o *LoadOperation) withTimeSeries(timeSeries []*TimeSeriesRange) *LoadOperation {
+	o.timeSeries = timeSeries
+	return o
+}
+
 func (o *LoadOperation) byIds(ids []string) *LoadOperation {
 	o.ids = stringArrayCopy(ids)
 
@@ -94,6 +116,14 @@ func (o *LoadOperation) getDocumentWithID(result interface{}, id string) error {
 		return nil
 	}
 
+	if o.session.noTracking {
+		doc := o.noTrackingDocuments[strings.ToLower(id)]
+		if doc == nil {
+			return nil
+		}
+		return o.session.TrackEntityInDocumentInfo(result, doc)
+	}
+
 	doc := o.session.documentsByID.getValue(id)
 	if doc == nil {
 		doc = o.session.includedDocumentsByID[id]
@@ -151,7 +181,23 @@ func (o *LoadOperation) setResult(result *GetDocumentsResult) {
 		return
 	}
 
+	// nothing is registered in the session, the documents are only
+	// kept until they're converted to results
+	if o.session.noTracking {
+		o.noTrackingDocuments = map[string]*documentInfo{}
+		for _, document := range result.Results {
+			if document == nil {
+				continue
+			}
+			info := getNewDocumentInfo(document)
+			o.noTrackingDocuments[strings.ToLower(info.id)] = info
+		}
+		return
+	}
+
 	o.session.registerIncludes(result.Includes)
+	o.session.registerCounters(result.CounterIncludes)
+	o.session.registerTimeSeries(result.TimeSeriesIncludes)
 
 	results := result.Results
 	for _, document := range results {
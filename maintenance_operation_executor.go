@@ -1,6 +1,9 @@
 package ravendb
 
-import "strings"
+import (
+	"strings"
+	"time"
+)
 
 type MaintenanceOperationExecutor struct {
 	store                   *DocumentStore
@@ -64,17 +67,138 @@ func (e *MaintenanceOperationExecutor) SendAsync(operation IMaintenanceOperation
 	if err != nil {
 		return nil, err
 	}
+	if err = assertCommandReturnsOperationID(command); err != nil {
+		return nil, err
+	}
 	if err = e.GetRequestExecutor().ExecuteCommand(command, nil); err != nil {
 		return nil, err
 	}
 	fn := func() *DatabaseChanges {
-		return e.store.Changes("")
+		return e.store.Changes(e.databaseName)
 	}
 	re := e.GetRequestExecutor()
-	id := getCommandOperationIDResult(command)
+	id, err := getCommandOperationIDResult(command)
+	if err != nil {
+		return nil, err
+	}
 	return NewOperation(re, fn, re.GetConventions(), id.OperationID), nil
 }
 
+// WaitForIndexesToBecomeNonStale waits until given indexes (all indexes
+// if none are given) are no longer stale and have no side-by-side
+// replacements in progress. Disabled indexes are skipped.
+// Returns TimeoutError if the indexes are still stale after timeout
+// (a minute if 0). If they are stale and one of them is in error state,
+// it returns IllegalStateError right away instead of waiting for timeout.
+func (e *MaintenanceOperationExecutor) WaitForIndexesToBecomeNonStale(timeout time.Duration, indexes ...string) error {
+	if timeout == 0 {
+		timeout = time.Minute
+	}
+	indexes = stringArrayRemoveDuplicatesNoCase(stringArrayCopy(indexes))
+	isWaitedFor := func(name string) bool {
+		if len(indexes) == 0 {
+			return true
+		}
+		return stringArrayContainsNoCase(indexes, OriginalIndexName(name))
+	}
+
+	start := time.Now()
+	for {
+		op := NewGetStatisticsOperation("")
+		if err := e.Send(op); err != nil {
+			return err
+		}
+		isDone := true
+		nFound := 0
+		erroredIndex := ""
+		for _, index := range op.Command.Result.Indexes {
+			if !isWaitedFor(index.Name) {
+				continue
+			}
+			if !IsIndexReplacement(index.Name) {
+				nFound++
+			}
+			if index.State == IndexStateDisabled {
+				continue
+			}
+			if index.IsStale || IsIndexReplacement(index.Name) {
+				isDone = false
+			}
+			if index.State == IndexStateError && erroredIndex == "" {
+				erroredIndex = index.Name
+			}
+		}
+		// indexes that were just deployed might not be visible yet
+		if nFound < len(indexes) {
+			isDone = false
+		}
+		if isDone {
+			return nil
+		}
+		// the index won't catch up so there's no point in waiting
+		if erroredIndex != "" {
+			return e.newIndexInErrorStateError(erroredIndex)
+		}
+		if time.Since(start) > timeout {
+			return NewTimeoutError("The indexes stayed stale for more than %s", timeout)
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
+
+// WaitForIndexReplacement waits until side-by-side replacement of an index
+// (created by the server when index definition changes) becomes non-stale
+// and replaces the original index.
+// Returns immediately if the index is not being replaced.
+func (e *MaintenanceOperationExecutor) WaitForIndexReplacement(indexName string, timeout time.Duration) error {
+	if stringIsBlank(indexName) {
+		return newIllegalArgumentError("indexName cannot be empty")
+	}
+	replacementName := IndexReplacementName(indexName)
+
+	start := time.Now()
+	for {
+		op := NewGetStatisticsOperation("")
+		if err := e.Send(op); err != nil {
+			return err
+		}
+		var replacement *IndexInformation
+		for _, index := range op.Command.Result.Indexes {
+			if index.Name == replacementName {
+				replacement = index
+				break
+			}
+		}
+		if replacement == nil {
+			return nil
+		}
+		if replacement.State == IndexStateError {
+			return newIllegalStateError("Replacement index '%s' is in error state", replacementName)
+		}
+		if time.Since(start) > timeout {
+			return NewTimeoutError("Index '%s' was not replaced within %s", OriginalIndexName(replacementName), timeout)
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
+
+func (e *MaintenanceOperationExecutor) newIndexInErrorStateError(indexName string) error {
+	op := NewGetIndexErrorsOperation([]string{indexName})
+	if err := e.Send(op); err != nil {
+		return newIllegalStateError("Index '%s' is in error state", indexName)
+	}
+	var errorsText []string
+	for _, indexErrors := range op.Command.Result {
+		for _, indexingError := range indexErrors.Errors {
+			errorsText = append(errorsText, indexingError.String())
+		}
+	}
+	if len(errorsText) == 0 {
+		return newIllegalStateError("Index '%s' is in error state", indexName)
+	}
+	return newIllegalStateError("Index '%s' is in error state (%d errors): %s", indexName, len(errorsText), strings.Join(errorsText, "; "))
+}
+
 func (e *MaintenanceOperationExecutor) assertDatabaseNameSet() error {
 	if e.databaseName == "" {
 		return newIllegalStateError("Cannot use maintenance without a database defined, did you forget to call forDatabase?")
@@ -0,0 +1,110 @@
+package ravendb
+
+import (
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newIndexStatsHandler answers database statistics requests with indexes
+// returned by indexesJSON for n-th (starting at 0) stats request
+func newIndexStatsHandler(indexesJSON func(n int32) string, nStatsRequests *int32) http.HandlerFunc {
+	fn := func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/databases/db/stats":
+			n := atomic.AddInt32(nStatsRequests, 1) - 1
+			_, _ = fmt.Fprintf(w, `{"Indexes":[%s]}`, indexesJSON(n))
+		case "/databases/db/indexes/errors":
+			_, _ = w.Write([]byte(`{"Results":[{"Name":"Users/ByName","Errors":[{"Error":"boom","Document":"users/1","Action":"Map"}]}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+	return fn
+}
+
+func TestMaintenanceWaitForIndexesToBecomeNonStale(t *testing.T) {
+	var nStatsRequests int32
+	indexesJSON := func(n int32) string {
+		if n < 2 {
+			return `{"Name":"Users/ByName","IsStale":true,"State":"Normal"},{"Name":"Orders/ByCompany","IsStale":false,"State":"Normal"}`
+		}
+		if n < 3 {
+			return `{"Name":"Users/ByName","IsStale":false,"State":"Normal"},{"Name":"ReplacementOf/Users/ByName","IsStale":false,"State":"Normal"}`
+		}
+		return `{"Name":"Users/ByName","IsStale":false,"State":"Normal"},{"Name":"Orders/ByCompany","IsStale":true,"State":"Disabled"}`
+	}
+	store := newFakeServerStore(t, newIndexStatsHandler(indexesJSON, &nStatsRequests))
+
+	// only Orders/ByCompany is non-stale from the start
+	err := store.Maintenance().WaitForIndexesToBecomeNonStale(time.Second*5, "orders/bycompany")
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&nStatsRequests))
+
+	// waits for Users/ByName and its side-by-side replacement, skips
+	// disabled indexes
+	err = store.Maintenance().WaitForIndexesToBecomeNonStale(time.Second * 5)
+	require.NoError(t, err)
+	assert.Equal(t, int32(4), atomic.LoadInt32(&nStatsRequests))
+}
+
+func TestMaintenanceWaitForIndexesToBecomeNonStaleErrors(t *testing.T) {
+	var nStatsRequests int32
+	indexesJSON := func(n int32) string {
+		return `{"Name":"Users/ByName","IsStale":true,"State":"Error"},{"Name":"Orders/ByCompany","IsStale":true,"State":"Normal"},` +
+			`{"Name":"Companies/ByCountry","IsStale":false,"State":"Error"}`
+	}
+	store := newFakeServerStore(t, newIndexStatsHandler(indexesJSON, &nStatsRequests))
+
+	err := store.Maintenance().WaitForIndexesToBecomeNonStale(time.Second*5, "Users/ByName")
+	_, ok := err.(*IllegalStateError)
+	require.True(t, ok, "expected *IllegalStateError, got %T (%v)", err, err)
+	assert.Contains(t, err.Error(), "boom")
+
+	// index in error state that is not stale is not an error
+	err = store.Maintenance().WaitForIndexesToBecomeNonStale(time.Second*5, "Companies/ByCountry")
+	assert.NoError(t, err)
+
+	err = store.Maintenance().WaitForIndexesToBecomeNonStale(time.Millisecond*150, "Orders/ByCompany")
+	_, ok = err.(*TimeoutError)
+	assert.True(t, ok, "expected *TimeoutError, got %T (%v)", err, err)
+
+	// index that doesn't exist is waited for until timeout
+	err = store.Maintenance().WaitForIndexesToBecomeNonStale(time.Millisecond*150, "Companies/ByName")
+	_, ok = err.(*TimeoutError)
+	assert.True(t, ok, "expected *TimeoutError, got %T (%v)", err, err)
+}
+
+func TestMaintenanceWaitForIndexReplacement(t *testing.T) {
+	var nStatsRequests int32
+	indexesJSON := func(n int32) string {
+		if n < 2 {
+			return `{"Name":"Users/ByName","IsStale":false,"State":"Normal"},{"Name":"ReplacementOf/Users/ByName","IsStale":true,"State":"Normal"},` +
+				`{"Name":"ReplacementOf/Orders/ByCompany","IsStale":true,"State":"Error"}`
+		}
+		return `{"Name":"Users/ByName","IsStale":false,"State":"Normal"},{"Name":"ReplacementOf/Orders/ByCompany","IsStale":true,"State":"Error"}`
+	}
+	store := newFakeServerStore(t, newIndexStatsHandler(indexesJSON, &nStatsRequests))
+
+	err := store.Maintenance().WaitForIndexReplacement("Users/ByName", time.Second*5)
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&nStatsRequests))
+
+	// not being replaced
+	err = store.Maintenance().WaitForIndexReplacement("Companies/ByName", time.Second*5)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(4), atomic.LoadInt32(&nStatsRequests))
+
+	err = store.Maintenance().WaitForIndexReplacement("Orders/ByCompany", time.Second*5)
+	_, ok := err.(*IllegalStateError)
+	assert.True(t, ok, "expected *IllegalStateError, got %T (%v)", err, err)
+
+	err = store.Maintenance().WaitForIndexReplacement("", time.Second)
+	_, ok = err.(*IllegalArgumentError)
+	assert.True(t, ok, "expected *IllegalArgumentError, got %T (%v)", err, err)
+}
@@ -0,0 +1,94 @@
+package ravendb
+
+import (
+	"net/http"
+)
+
+var (
+	_ IServerOperation = &ModifyConflictSolverOperation{}
+)
+
+// ModifySolverResult is a result of ModifyConflictSolverOperation
+type ModifySolverResult struct {
+	Key              string          `json:"Key"`
+	RaftCommandIndex int64           `json:"RaftCommandIndex"`
+	Solver           *ConflictSolver `json:"Solver"`
+}
+
+// ModifyConflictSolverOperation changes how replication conflicts are
+// resolved in a database: by a per-collection script or by picking
+// the latest version of a document
+type ModifyConflictSolverOperation struct {
+	database           string
+	collectionByScript map[string]*ScriptResolver
+	resolveToLatest    bool
+
+	Command *ModifyConflictSolverCommand
+}
+
+// NewModifyConflictSolverOperation returns new ModifyConflictSolverOperation.
+// collectionByScript maps collection name to a script resolving conflicts
+// for documents in that collection
+func NewModifyConflictSolverOperation(database string, collectionByScript map[string]*ScriptResolver, resolveToLatest bool) (*ModifyConflictSolverOperation, error) {
+	if database == "" {
+		return nil, newIllegalArgumentError("database cannot be empty")
+	}
+	return &ModifyConflictSolverOperation{
+		database:           database,
+		collectionByScript: collectionByScript,
+		resolveToLatest:    resolveToLatest,
+	}, nil
+}
+
+func (o *ModifyConflictSolverOperation) GetCommand(conventions *DocumentConventions) (RavenCommand, error) {
+	var err error
+	o.Command, err = NewModifyConflictSolverCommand(o.database, o.collectionByScript, o.resolveToLatest)
+	if err != nil {
+		return nil, err
+	}
+	return o.Command, nil
+}
+
+var _ RavenCommand = &ModifyConflictSolverCommand{}
+
+type ModifyConflictSolverCommand struct {
+	RavenCommandBase
+
+	database   string
+	parameters []byte
+
+	Result *ModifySolverResult
+}
+
+func NewModifyConflictSolverCommand(database string, collectionByScript map[string]*ScriptResolver, resolveToLatest bool) (*ModifyConflictSolverCommand, error) {
+	if collectionByScript == nil {
+		collectionByScript = map[string]*ScriptResolver{}
+	}
+	m := map[string]interface{}{
+		"ScriptResolvers": collectionByScript,
+		"ResolveToLatest": resolveToLatest,
+	}
+	d, err := jsonMarshal(m)
+	if err != nil {
+		return nil, err
+	}
+	cmd := &ModifyConflictSolverCommand{
+		RavenCommandBase: NewRavenCommandBase(),
+
+		database:   database,
+		parameters: d,
+	}
+	return cmd, nil
+}
+
+func (c *ModifyConflictSolverCommand) CreateRequest(node *ServerNode) (*http.Request, error) {
+	url := node.URL + "/admin/replication/conflicts/solver?name=" + urlUtilsEscapeDataString(c.database)
+	return NewHttpPost(url, c.parameters)
+}
+
+func (c *ModifyConflictSolverCommand) SetResponse(response []byte, fromCache bool) error {
+	if len(response) == 0 {
+		return throwInvalidResponse()
+	}
+	return jsonUnmarshal(response, &c.Result)
+}
@@ -35,12 +35,17 @@ func (g *MultiDatabaseHiLoIDGenerator) GenerateDocumentID(dbName string, entity
 	return generator.GenerateDocumentID(entity)
 }
 
-// ReturnUnusedRange returns unused range for all generators
-func (g *MultiDatabaseHiLoIDGenerator) ReturnUnusedRange() {
+// ReturnUnusedRange returns unused range for all generators.
+// Returns the first error encountered.
+func (g *MultiDatabaseHiLoIDGenerator) ReturnUnusedRange() error {
+	var err error
 	cb := func(key, value interface{}) bool {
 		generator := value.(*MultiTypeHiLoIDGenerator)
-		generator.ReturnUnusedRange()
+		if err2 := generator.ReturnUnusedRange(); err2 != nil && err == nil {
+			err = err2
+		}
 		return true
 	}
 	g._generators.Range(cb)
+	return err
 }
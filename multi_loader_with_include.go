@@ -2,13 +2,16 @@ package ravendb
 
 import (
 	"reflect"
+	"time"
 )
 
 // ILoaderWithInclude is NewMultiLoaderWithInclude
 
 type MultiLoaderWithInclude struct {
-	session  *DocumentSession
-	includes []string
+	session    *DocumentSession
+	includes   []string
+	counters   []string
+	timeSeries []*TimeSeriesRange
 }
 
 func NewMultiLoaderWithInclude(session *DocumentSession) *MultiLoaderWithInclude {
@@ -22,6 +25,31 @@ func (l *MultiLoaderWithInclude) Include(path string) *MultiLoaderWithInclude {
 	return l
 }
 
+// IncludeCounters includes values of counters of loaded documents. They
+// can be read with GetIncludedCountersFor
+func (l *MultiLoaderWithInclude) IncludeCounters(names ...string) *MultiLoaderWithInclude {
+	l.counters = append(l.counters, names...)
+	return l
+}
+
+// IncludeTimeSeries includes entries of time series name of loaded documents
+// between from and to. nil from or to leaves that side of the range open.
+// The entries can be read with GetIncludedTimeSeriesFor
+func (l *MultiLoaderWithInclude) IncludeTimeSeries(name string, from *time.Time, to *time.Time) *MultiLoaderWithInclude {
+	r := &TimeSeriesRange{
+		Name: name,
+		From: from,
+		To:   to,
+	}
+	l.timeSeries = append(l.timeSeries, r)
+	return l
+}
+
+func (l *MultiLoaderWithInclude) newLoadOperation() *LoadOperation {
+	op := NewLoadOperation(l.session.InMemoryDocumentSessionOperations)
+	return op.withIncludes(l.includes).withCounters(l.counters).withTimeSeries(l.timeSeries)
+}
+
 // results should be map[string]*struct
 func (l *MultiLoaderWithInclude) LoadMulti(results interface{}, ids []string) error {
 	if len(ids) == 0 {
@@ -31,10 +59,9 @@ func (l *MultiLoaderWithInclude) LoadMulti(results interface{}, ids []string) er
 		return err
 	}
 
-	return l.session.loadInternalMulti(results, ids, l.includes)
+	return l.session.loadInternalMultiWithOperation(results, ids, l.newLoadOperation())
 }
 
-// TODO: needs a test
 // TODO: better implementation
 func (l *MultiLoaderWithInclude) Load(result interface{}, id string) error {
 	if id == "" {
@@ -52,7 +79,7 @@ func (l *MultiLoaderWithInclude) Load(result interface{}, id string) error {
 	mapType := reflect.MapOf(stringType, rt)
 	m := reflect.MakeMap(mapType)
 	ids := []string{id}
-	err := l.session.loadInternalMulti(m.Interface(), ids, l.includes)
+	err := l.session.loadInternalMultiWithOperation(m.Interface(), ids, l.newLoadOperation())
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,77 @@
+package ravendb
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const docsWithCountersAndTimeSeriesJSON = `{
+	"Results": [{"name": "John", "@metadata": {"@id": "users/1", "@collection": "Users", "@change-vector": "A:1"}}],
+	"Includes": {},
+	"CounterIncludes": {"users/1": [{"DocumentId": "users/1", "CounterName": "likes", "TotalValue": 3}, null]},
+	"TimeSeriesIncludes": {"users/1": {"HeartRate": [{
+		"From": "2020-01-01T00:00:00.0000000Z", "To": null,
+		"Entries": [{"Timestamp": "2020-01-01T10:00:00.0000000Z", "Tag": "watch", "Values": [70], "IsRollup": false}]
+	}]}}
+}`
+
+func TestMultiLoaderWithIncludeCountersAndTimeSeries(t *testing.T) {
+	var queries []string
+	fn := func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/databases/db/docs" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		queries = append(queries, r.URL.RawQuery)
+		_, _ = w.Write([]byte(docsWithCountersAndTimeSeriesJSON))
+	}
+	store := newFakeServerStore(t, fn)
+	session, err := store.OpenSession("")
+	require.NoError(t, err)
+	defer session.Close()
+
+	from := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	var user *User
+	err = session.Include("Friend").IncludeCounters("likes", "dislikes").IncludeTimeSeries("HeartRate", &from, nil).Load(&user, "users/1")
+	require.NoError(t, err)
+	require.NotNil(t, user)
+	require.Equal(t, 1, len(queries))
+	assert.Equal(t, "&include=Friend&counter=likes&counter=dislikes&timeseries=HeartRate&from=2020-01-01T00%3A00%3A00.0000000Z&to=&id=users%2F1", queries[0])
+
+	counters, err := session.Advanced().GetIncludedCountersFor(user)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int64{"likes": 3}, counters)
+
+	entries, err := session.Advanced().GetIncludedTimeSeriesFor(user, "HeartRate")
+	require.NoError(t, err)
+	require.Equal(t, 1, len(entries))
+	assert.Equal(t, "watch", entries[0].Tag)
+	assert.Equal(t, []float64{70}, entries[0].Values)
+	assert.Equal(t, time.Date(2020, 1, 1, 10, 0, 0, 0, time.UTC), entries[0].GetTimestamp())
+
+	entries, err = session.Advanced().GetIncludedTimeSeriesFor(user, "Temperature")
+	require.NoError(t, err)
+	assert.Nil(t, entries)
+
+	// reading the included values doesn't go to the server but loading
+	// a tracked document with counter includes does
+	assert.Equal(t, 1, session.GetNumberOfRequests())
+	err = session.Include("Friend").Load(&user, "users/1")
+	require.NoError(t, err)
+	assert.Equal(t, 1, len(queries))
+	err = session.Include("Friend").IncludeCounters("likes").Load(&user, "users/1")
+	require.NoError(t, err)
+	assert.Equal(t, 2, len(queries))
+
+	// loading the same range again replaces its entries
+	err = session.Include("Friend").IncludeTimeSeries("HeartRate", &from, nil).Load(&user, "users/1")
+	require.NoError(t, err)
+	assert.Equal(t, 3, len(queries))
+	entries, err = session.Advanced().GetIncludedTimeSeriesFor(user, "HeartRate")
+	require.NoError(t, err)
+	assert.Equal(t, 1, len(entries))
+}
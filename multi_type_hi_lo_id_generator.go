@@ -42,9 +42,14 @@ func (g *MultiTypeHiLoIDGenerator) GenerateDocumentID(entity interface{}) (strin
 	return value.GenerateDocumentID(entity)
 }
 
-// ReturnUnusedRange returns unused range for all generators
-func (g *MultiTypeHiLoIDGenerator) ReturnUnusedRange() {
+// ReturnUnusedRange returns unused range for all generators.
+// Returns the first error encountered.
+func (g *MultiTypeHiLoIDGenerator) ReturnUnusedRange() error {
+	var err error
 	for _, generator := range g._idGeneratorsByTag {
-		generator.ReturnUnusedRange()
+		if err2 := generator.ReturnUnusedRange(); err2 != nil && err == nil {
+			err = err2
+		}
 	}
+	return err
 }
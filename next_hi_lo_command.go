@@ -44,7 +44,7 @@ func (c *NextHiLoCommand) CreateRequest(node *ServerNode) (*http.Request, error)
 	if c._lastRangeAt != nil && !c._lastRangeAt.IsZero() {
 		date = (*c._lastRangeAt).Format(timeFormat)
 	}
-	path := "/hilo/next?tag=" + c._tag + "&lastBatchSize=" + i64toa(c._lastBatchSize) + "&lastRangeAt=" + date + "&identityPartsSeparator=" + c._identityPartsSeparator + "&lastMax=" + i64toa(c._lastRangeMax)
+	path := "/hilo/next?tag=" + urlUtilsEscapeDataString(c._tag) + "&lastBatchSize=" + i64toa(c._lastBatchSize) + "&lastRangeAt=" + date + "&identityPartsSeparator=" + urlUtilsEscapeDataString(c._identityPartsSeparator) + "&lastMax=" + i64toa(c._lastRangeMax)
 	url := node.URL + "/databases/" + node.Database + path
 	return newHttpGet(url)
 }
@@ -33,7 +33,7 @@ func (c *NextIdentityForCommand) CreateRequest(node *ServerNode) (*http.Request,
 		return nil, err
 	}
 
-	url := node.URL + "/databases/" + node.Database + "/identity/next?name=" + urlEncode(c._id)
+	url := node.URL + "/databases/" + node.Database + "/identity/next?name=" + urlUtilsEscapeDataString(c._id)
 
 	return NewHttpPost(url, nil)
 }
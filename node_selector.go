@@ -1,29 +1,42 @@
 package ravendb
 
 import (
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
+// speedTestLatencyWeight is the weight of the newest sample in the rolling
+// average of node latencies measured in the speed test phase
+const speedTestLatencyWeight = 0.3
+
 // NodeSelector describes node selector
 type NodeSelector struct {
+	timerMu                sync.Mutex // protects updateFastestNodeTimer
 	updateFastestNodeTimer *time.Timer
-	state                  *NodeSelectorState
+	state                  atomic.Value // *NodeSelectorState, atomic to avoid data races
+
+	// if set, called in a new goroutine when speed test phase starts
+	onSpeedTestPhase func()
 }
 
 // NewNodeSelector creates a new NodeSelector
 func NewNodeSelector(t *Topology) *NodeSelector {
-	state := NewNodeSelectorState(t)
-	return &NodeSelector{
-		state: state,
-	}
+	res := &NodeSelector{}
+	res.state.Store(NewNodeSelectorState(t))
+	return res
+}
+
+func (s *NodeSelector) getState() *NodeSelectorState {
+	return s.state.Load().(*NodeSelectorState)
 }
 
 func (s *NodeSelector) getTopology() *Topology {
-	return s.state.topology
+	return s.getState().topology
 }
 
 func (s *NodeSelector) onFailedRequest(nodeIndex int) {
-	state := s.state
+	state := s.getState()
 	if nodeIndex < 0 || nodeIndex >= len(state.failures) {
 		return // probably already changed
 	}
@@ -36,20 +49,20 @@ func (s *NodeSelector) onUpdateTopology(topology *Topology, forceUpdate bool) bo
 		return false
 	}
 
-	stateEtag := s.state.topology.Etag
+	stateEtag := s.getState().topology.Etag
 	topologyEtag := topology.Etag
 
 	if stateEtag >= topologyEtag && !forceUpdate {
 		return false
 	}
 
-	s.state = NewNodeSelectorState(topology)
+	s.state.Store(NewNodeSelectorState(topology))
 
 	return true
 }
 
 func (s *NodeSelector) getPreferredNode() (*CurrentIndexAndNode, error) {
-	state := s.state
+	state := s.getState()
 	stateFailures := state.failures
 	serverNodes := state.nodes
 	n := min(len(serverNodes), len(stateFailures))
@@ -72,7 +85,7 @@ func (s *NodeSelector) unlikelyEveryoneFaultedChoice(state *NodeSelectorState) (
 }
 
 func (s *NodeSelector) getNodeBySessionID(sessionId int) (*CurrentIndexAndNode, error) {
-	state := s.state
+	state := s.getState()
 	index := sessionId % len(state.topology.Nodes)
 
 	for i := index; i < len(state.failures); i++ {
@@ -91,7 +104,7 @@ func (s *NodeSelector) getNodeBySessionID(sessionId int) (*CurrentIndexAndNode,
 }
 
 func (s *NodeSelector) getFastestNode() (*CurrentIndexAndNode, error) {
-	state := s.state
+	state := s.getState()
 	if state.failures[state.fastest].get() == 0 && state.nodes[state.fastest].ServerRole == ServerNodeRoleMember {
 		return NewCurrentIndexAndNode(state.fastest, state.nodes[state.fastest]), nil
 	}
@@ -105,7 +118,7 @@ func (s *NodeSelector) getFastestNode() (*CurrentIndexAndNode, error) {
 }
 
 func (s *NodeSelector) restoreNodeIndex(nodeIndex int) {
-	state := s.state
+	state := s.getState()
 	if len(state.failures) < nodeIndex {
 		return // the state was changed and we no longer have it?
 	}
@@ -121,7 +134,7 @@ func nodeSelectorThrowEmptyTopology() error {
 */
 
 func (s *NodeSelector) switchToSpeedTestPhase() {
-	state := s.state
+	state := s.getState()
 
 	if !state.speedTestMode.compareAndSet(0, 1) {
 		return
@@ -132,14 +145,18 @@ func (s *NodeSelector) switchToSpeedTestPhase() {
 	}
 
 	state.speedTestMode.incrementAndGet()
+
+	if s.onSpeedTestPhase != nil {
+		go s.onSpeedTestPhase()
+	}
 }
 
 func (s *NodeSelector) inSpeedTestPhase() bool {
-	return s.state.speedTestMode.get() > 1
+	return s.getState().speedTestMode.get() > 1
 }
 
 func (s *NodeSelector) recordFastest(index int, node *ServerNode) {
-	state := s.state
+	state := s.getState()
 	stateFastest := state.fastestRecords
 
 	// the following two checks are to verify that things didn't move
@@ -189,22 +206,74 @@ func (s *NodeSelector) selectFastest(state *NodeSelectorState, index int) {
 	state.fastest = index
 	state.speedTestMode.set(0)
 
+	s.timerMu.Lock()
+	defer s.timerMu.Unlock()
 	if s.updateFastestNodeTimer != nil {
 		s.updateFastestNodeTimer.Reset(time.Minute)
 	} else {
 		f := func() {
+			s.timerMu.Lock()
 			s.updateFastestNodeTimer = nil
+			s.timerMu.Unlock()
 			s.switchToSpeedTestPhase()
 		}
 		s.updateFastestNodeTimer = time.AfterFunc(time.Minute, f)
 	}
 }
 
+// recordLatency adds a latency sample for the node at index to its
+// rolling average
+func (s *NodeSelector) recordLatency(index int, node *ServerNode, latency time.Duration) {
+	state := s.getState()
+	if index < 0 || index >= len(state.latencies) || node != state.nodes[index] {
+		return // the topology changed while we were measuring
+	}
+	if latency <= 0 {
+		latency = 1
+	}
+
+	state.mu.Lock()
+	defer state.mu.Unlock()
+	prev := state.latencies[index]
+	if prev == 0 {
+		state.latencies[index] = latency
+		return
+	}
+	avg := speedTestLatencyWeight*float64(latency) + (1-speedTestLatencyWeight)*float64(prev)
+	state.latencies[index] = time.Duration(avg)
+}
+
+// selectFastestByLatency makes the healthy member node with the lowest
+// rolling latency the fastest node. Returns false if no node was measured
+func (s *NodeSelector) selectFastestByLatency() bool {
+	state := s.getState()
+	best := -1
+
+	state.mu.Lock()
+	for i, latency := range state.latencies {
+		if latency == 0 || state.failures[i].get() != 0 || state.nodes[i].ServerRole != ServerNodeRoleMember {
+			continue
+		}
+		if best < 0 || latency < state.latencies[best] {
+			best = i
+		}
+	}
+	state.mu.Unlock()
+
+	if best < 0 {
+		return false
+	}
+	s.selectFastest(state, best)
+	return true
+}
+
 func (s *NodeSelector) scheduleSpeedTest() {
 	s.switchToSpeedTestPhase()
 }
 
 func (s *NodeSelector) Close() {
+	s.timerMu.Lock()
+	defer s.timerMu.Unlock()
 	if s.updateFastestNodeTimer != nil {
 		s.updateFastestNodeTimer.Stop()
 		s.updateFastestNodeTimer = nil
@@ -218,6 +287,11 @@ type NodeSelectorState struct {
 	fastestRecords []int
 	fastest        int
 	speedTestMode  atomicInteger
+
+	mu sync.Mutex // protects latencies
+	// rolling average of latencies measured in the speed test phase,
+	// 0 if not measured yet
+	latencies []time.Duration
 }
 
 func NewNodeSelectorState(topology *Topology) *NodeSelectorState {
@@ -229,5 +303,6 @@ func NewNodeSelectorState(topology *Topology) *NodeSelectorState {
 	failures := make([]atomicInteger, len(nodes))
 	res.failures = failures
 	res.fastestRecords = make([]int, len(nodes))
+	res.latencies = make([]time.Duration, len(nodes))
 	return res
 }
@@ -0,0 +1,80 @@
+package ravendb
+
+// OngoingTaskState describes state of an ongoing task
+type OngoingTaskState = string
+
+const (
+	OngoingTaskStateEnabled          = "Enabled"
+	OngoingTaskStateDisabled         = "Disabled"
+	OngoingTaskStatePartiallyEnabled = "PartiallyEnabled"
+)
+
+// OngoingTaskType describes type of an ongoing task
+type OngoingTaskType = string
+
+const (
+	OngoingTaskTypeReplication           = "Replication"
+	OngoingTaskTypeRavenEtl              = "RavenEtl"
+	OngoingTaskTypeSQLEtl                = "SqlEtl"
+	OngoingTaskTypeBackup                = "Backup"
+	OngoingTaskTypeSubscription          = "Subscription"
+	OngoingTaskTypePullReplicationAsHub  = "PullReplicationAsHub"
+	OngoingTaskTypePullReplicationAsSink = "PullReplicationAsSink"
+)
+
+// OngoingTask describes a task running on the database e.g. a subscription,
+// an ETL process or a backup
+type OngoingTask struct {
+	TaskID          int64            `json:"TaskId"`
+	TaskType        OngoingTaskType  `json:"TaskType"`
+	TaskName        string           `json:"TaskName"`
+	TaskState       OngoingTaskState `json:"TaskState"`
+	ResponsibleNode *NodeID          `json:"ResponsibleNode"`
+	MentorNode      string           `json:"MentorNode"`
+	Error           string           `json:"Error"`
+}
+
+// OngoingTaskSubscription describes a subscription task
+type OngoingTaskSubscription struct {
+	OngoingTask
+
+	Query                                 string `json:"Query"`
+	SubscriptionName                      string `json:"SubscriptionName"`
+	SubscriptionID                        int64  `json:"SubscriptionId"`
+	ChangeVectorForNextBatchStartingPoint string `json:"ChangeVectorForNextBatchStartingPoint"`
+	LastBatchAckTime                      *Time  `json:"LastBatchAckTime"`
+	LastClientConnectionTime              *Time  `json:"LastClientConnectionTime"`
+	Disabled                              bool   `json:"Disabled"`
+}
+
+// OngoingTasksResult groups ongoing tasks of a database by their type.
+// PullReplications are tasks pulling data from a hub (i.e. sinks),
+// PushReplications are hub tasks sending data to sinks.
+type OngoingTasksResult struct {
+	PullReplications     []*OngoingTask
+	PushReplications     []*OngoingTask
+	ExternalReplications []*OngoingTask
+	RavenEtls            []*OngoingTask
+	SqlEtls              []*OngoingTask
+	Backups              []*OngoingTask
+	Subscriptions        []*OngoingTask
+}
+
+func (r *OngoingTasksResult) add(task *OngoingTask) {
+	switch task.TaskType {
+	case OngoingTaskTypePullReplicationAsSink:
+		r.PullReplications = append(r.PullReplications, task)
+	case OngoingTaskTypePullReplicationAsHub:
+		r.PushReplications = append(r.PushReplications, task)
+	case OngoingTaskTypeReplication:
+		r.ExternalReplications = append(r.ExternalReplications, task)
+	case OngoingTaskTypeRavenEtl:
+		r.RavenEtls = append(r.RavenEtls, task)
+	case OngoingTaskTypeSQLEtl:
+		r.SqlEtls = append(r.SqlEtls, task)
+	case OngoingTaskTypeBackup:
+		r.Backups = append(r.Backups, task)
+	case OngoingTaskTypeSubscription:
+		r.Subscriptions = append(r.Subscriptions, task)
+	}
+}
@@ -13,6 +13,11 @@ type Operation struct {
 
 	// if true, this represents ServerWideOperation
 	IsServerWide bool
+
+	// OnProgress, if set, is called by WaitForCompletion with progress
+	// reported by the server while the operation is in progress.
+	// The format depends on the operation e.g. see CompactionProgress
+	OnProgress func(progress map[string]interface{})
 }
 
 func (o *Operation) GetID() int64 {
@@ -52,6 +57,20 @@ func (o *Operation) getOperationStateCommand(conventions *DocumentConventions, i
 	return NewGetOperationStateCommand(o.conventions, o.id)
 }
 
+// Kill asks the server to cancel the operation. The cancellation is
+// asynchronous: the operation's status becomes "Cancelled" once the server
+// stops it and WaitForCompletion then returns *OperationCancelledError
+func (o *Operation) Kill() error {
+	if o.IsServerWide {
+		return newUnsupportedOperationError("Killing server-wide operations is not supported")
+	}
+	command, err := NewKillOperationCommand(i64toa(o.id))
+	if err != nil {
+		return err
+	}
+	return o.requestExecutor.ExecuteCommand(command, nil)
+}
+
 func (o *Operation) WaitForCompletion() error {
 	for {
 		status, err := o.fetchOperationsStatus()
@@ -64,9 +83,13 @@ func (o *Operation) WaitForCompletion() error {
 			return newRavenError("missing 'Status' field in response")
 		}
 		switch operationStatus {
+		case "InProgress":
+			if progress, ok := status["Progress"].(map[string]interface{}); ok && o.OnProgress != nil {
+				o.OnProgress(progress)
+			}
 		case "Completed":
 			return nil
-		case "Cancelled":
+		case "Canceled", "Cancelled":
 			return newOperationCancelledError("")
 		case "Faulted":
 			result, ok := status["Result"].(map[string]interface{})
@@ -78,7 +101,11 @@ func (o *Operation) WaitForCompletion() error {
 			if err != nil {
 				return err
 			}
-			return exceptionDispatcherGet(exceptionResult.Message, exceptionResult.Error, exceptionResult.Type, exceptionResult.StatusCode, nil)
+			errStr := exceptionResult.Error
+			if errStr == "" {
+				errStr = exceptionResult.Message
+			}
+			return exceptionDispatcherGet(exceptionResult.Message, errStr, exceptionResult.Type, exceptionResult.StatusCode, nil)
 		}
 
 		time.Sleep(500 * time.Millisecond)
@@ -5,6 +5,9 @@ import (
 	"strings"
 )
 
+// OperationExecutor sends document-level operations (e.g. PatchOperation or
+// DeleteByQueryOperation) to a database. Use DocumentStore.Operations()
+// to get one
 type OperationExecutor struct {
 	store           *DocumentStore
 	databaseName    string
@@ -44,21 +47,30 @@ func (e *OperationExecutor) Send(operation IOperation, sessionInfo *SessionInfo)
 	return e.requestExecutor.ExecuteCommand(command, sessionInfo)
 }
 
+// SendAsync sends an operation that runs in the background on the server
+// (e.g. PatchByQueryOperation or DeleteByQueryOperation) and returns
+// Operation that can be used to wait for its completion.
 // sessionInfo can be nil
 func (e *OperationExecutor) SendAsync(operation IOperation, sessionInfo *SessionInfo) (*Operation, error) {
 	command, err := operation.GetCommand(e.store, e.requestExecutor.GetConventions(), e.requestExecutor.Cache)
 	if err != nil {
 		return nil, err
 	}
+	if err = assertCommandReturnsOperationID(command); err != nil {
+		return nil, err
+	}
 
 	if err = e.requestExecutor.ExecuteCommand(command, sessionInfo); err != nil {
 		return nil, err
 	}
 
 	changes := func() *DatabaseChanges {
-		return e.store.Changes("")
+		return e.store.Changes(e.databaseName)
+	}
+	result, err := getCommandOperationIDResult(command)
+	if err != nil {
+		return nil, err
 	}
-	result := getCommandOperationIDResult(command)
 
 	return NewOperation(e.requestExecutor, changes, e.requestExecutor.GetConventions(), result.OperationID), nil
 }
@@ -78,10 +90,11 @@ func (e *OperationExecutor) SendPatchOperation(operation *PatchOperation, sessio
 		return nil, err
 	}
 
-	cmdResult := operation.Command.Result
-	result := &PatchOperationResult{
-		Status:   cmdResult.Status,
-		Document: cmdResult.ModifiedDocument,
+	result := &PatchOperationResult{}
+	// Result is nil if the server didn't send a response body (e.g. 404)
+	if cmdResult := operation.Command.Result; cmdResult != nil {
+		result.Status = cmdResult.Status
+		result.Document = cmdResult.ModifiedDocument
 	}
 	switch operation.Command.StatusCode {
 	case http.StatusNotModified:
@@ -0,0 +1,45 @@
+package ravendb
+
+import (
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOperationExecutorSendAsyncRequiresOperationID(t *testing.T) {
+	var nRequests int32
+	fn := func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&nRequests, 1)
+		_, _ = w.Write([]byte(`{"Status":"Patched"}`))
+	}
+	store := newFakeServerStore(t, fn)
+
+	patch := &PatchRequest{Script: "this.name = 'John'"}
+	op, err := NewPatchOperation("users/1", nil, patch, nil, false)
+	require.NoError(t, err)
+
+	// PatchOperation completes synchronously so it must be sent with Send()
+	// and SendAsync() must not execute it
+	_, err = store.Operations().SendAsync(op, nil)
+	_, ok := err.(*IllegalArgumentError)
+	assert.True(t, ok, "expected *IllegalArgumentError, got %T (%v)", err, err)
+	assert.Equal(t, int32(0), atomic.LoadInt32(&nRequests))
+
+	_, err = store.Maintenance().SendAsync(NewGetStatisticsOperation(""))
+	_, ok = err.(*IllegalArgumentError)
+	assert.True(t, ok, "expected *IllegalArgumentError, got %T (%v)", err, err)
+	assert.Equal(t, int32(0), atomic.LoadInt32(&nRequests))
+
+	_, err = store.Maintenance().Server().SendAsync(NewGetBuildNumberOperation())
+	_, ok = err.(*IllegalArgumentError)
+	assert.True(t, ok, "expected *IllegalArgumentError, got %T (%v)", err, err)
+	assert.Equal(t, int32(0), atomic.LoadInt32(&nRequests))
+
+	err = store.Operations().Send(op, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, PatchStatusPatched, op.Command.Result.Status)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&nRequests))
+}
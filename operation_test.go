@@ -0,0 +1,101 @@
+package ravendb
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newKillTestServer returns a server that reports the operation as
+// in progress until it's killed
+func newKillTestServer(killRequests *[]string) *httptest.Server {
+	fn := func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/databases/db/operations/kill":
+			*killRequests = append(*killRequests, r.Method+" "+r.URL.RawQuery)
+		case "/databases/db/operations/state":
+			status := "InProgress"
+			if len(*killRequests) > 0 {
+				status = "Canceled"
+			}
+			_, _ = w.Write([]byte(`{"Status":"` + status + `"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+	return httptest.NewServer(http.HandlerFunc(fn))
+}
+
+func TestOperationKill(t *testing.T) {
+	var killRequests []string
+	srv := newKillTestServer(&killRequests)
+	defer srv.Close()
+
+	re := RequestExecutorCreateForSingleNodeWithoutConfigurationUpdates(srv.URL, "db", nil, nil, nil)
+	defer re.Close()
+
+	op := NewOperation(re, nil, re.GetConventions(), 12)
+	require.NoError(t, op.Kill())
+	assert.Equal(t, []string{"POST id=12"}, killRequests)
+
+	err := op.WaitForCompletion()
+	_, ok := err.(*OperationCancelledError)
+	assert.True(t, ok, "expected *OperationCancelledError, got %T (%v)", err, err)
+
+	op = NewServerWideOperation(re, re.GetConventions(), 12)
+	err = op.Kill()
+	_, ok = err.(*UnsupportedOperationError)
+	assert.True(t, ok)
+}
+
+func TestOperationFaulted(t *testing.T) {
+	fn := func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/operations/state" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"Status":"Faulted","Result":{"Message":"Backup directory does not exist"}}`))
+	}
+	srv := httptest.NewServer(http.HandlerFunc(fn))
+	defer srv.Close()
+
+	re := RequestExecutorCreateForSingleNodeWithoutConfigurationUpdates(srv.URL, "db", nil, nil, nil)
+	defer re.Close()
+
+	op := NewServerWideOperation(re, re.GetConventions(), 3)
+	err := op.WaitForCompletion()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Backup directory does not exist")
+}
+
+func TestOperationReportsProgress(t *testing.T) {
+	nRequests := 0
+	fn := func(w http.ResponseWriter, r *http.Request) {
+		nRequests++
+		if nRequests == 1 {
+			_, _ = w.Write([]byte(`{"Status":"InProgress","Progress":{"TreeName":"docs","GlobalProgress":1,"GlobalTotal":2}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"Status":"Completed"}`))
+	}
+	srv := httptest.NewServer(http.HandlerFunc(fn))
+	defer srv.Close()
+
+	re := RequestExecutorCreateForSingleNodeWithoutConfigurationUpdates(srv.URL, "db", nil, nil, nil)
+	defer re.Close()
+
+	var progresses []*CompactionProgress
+	op := NewServerWideOperation(re, re.GetConventions(), 3)
+	op.OnProgress = func(m map[string]interface{}) {
+		progress, err := NewCompactionProgress(m)
+		require.NoError(t, err)
+		progresses = append(progresses, progress)
+	}
+	require.NoError(t, op.WaitForCompletion())
+	require.Equal(t, 1, len(progresses))
+	assert.Equal(t, "docs", progresses[0].TreeName)
+	assert.Equal(t, int64(2), progresses[0].GlobalTotal)
+}
@@ -14,6 +14,8 @@ type orderByToken struct {
 	fieldName  string
 	descending bool
 	ordering   OrderingType
+	// name of a custom sorter deployed to the database
+	sorterName string
 }
 
 func newOrderByToken(fieldName string, descending bool, ordering OrderingType) *orderByToken {
@@ -57,7 +59,25 @@ func orderByTokenCreateDescending(fieldName string, ordering OrderingType) *orde
 	return newOrderByToken(fieldName, true, ordering)
 }
 
+func orderByTokenCreateWithSorter(fieldName string, sorterName string, descending bool) *orderByToken {
+	res := newOrderByToken(fieldName, descending, OrderingTypeString)
+	res.sorterName = sorterName
+	return res
+}
+
 func (t *orderByToken) writeTo(writer *strings.Builder) error {
+	if t.sorterName != "" {
+		writer.WriteString("custom(")
+		writeQueryTokenField(writer, t.fieldName)
+		writer.WriteString(", ")
+		writer.WriteString(queryFieldUtilQuote(t.sorterName))
+		writer.WriteString(")")
+		if t.descending {
+			writer.WriteString(" desc")
+		}
+		return nil
+	}
+
 	writeQueryTokenField(writer, t.fieldName)
 
 	switch t.ordering {
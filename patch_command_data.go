@@ -7,10 +7,10 @@ type PatchCommandData struct {
 	patchIfMissing *PatchRequest
 }
 
-// NewPatchCommandData creates CommandData for Delete Attachment command
+// NewPatchCommandData creates CommandData for Patch command
 // TODO: return a concrete type?
 func NewPatchCommandData(id string, changeVector *string, patch *PatchRequest, patchIfMissing *PatchRequest) ICommandData {
-	// TODO: verify args
+	// Note: id and patch are validated when the command is serialized
 	res := &PatchCommandData{
 		CommandData: &CommandData{
 			ID:           id,
@@ -23,7 +23,26 @@ func NewPatchCommandData(id string, changeVector *string, patch *PatchRequest, p
 	return res
 }
 
+// NewPatchCommandDataSimple creates ICommandData for Patch command with
+// a string change vector, like NewPutCommandData and NewDeleteCommandData.
+// It can be used with session.Advanced().Defer().
+// If changeVector is not empty, the patch will fail if the document on
+// the server has a different change vector
+func NewPatchCommandDataSimple(id string, changeVector string, patch *PatchRequest) ICommandData {
+	var changeVectorPtr *string
+	if changeVector != "" {
+		changeVectorPtr = &changeVector
+	}
+	return NewPatchCommandData(id, changeVectorPtr, patch, nil)
+}
+
 func (d *PatchCommandData) serialize(conventions *DocumentConventions) (interface{}, error) {
+	if d.ID == "" {
+		return nil, newIllegalArgumentError("Id cannot be empty")
+	}
+	if d.patch == nil {
+		return nil, newIllegalArgumentError("Patch cannot be nil")
+	}
 	res := d.baseJSON()
 	res["Patch"] = d.patch.Serialize()
 
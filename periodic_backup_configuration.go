@@ -0,0 +1,29 @@
+package ravendb
+
+// BackupType describes type of a backup
+type BackupType = string
+
+const (
+	BackupTypeBackup   = "Backup"
+	BackupTypeSnapshot = "Snapshot"
+)
+
+// LocalSettings describes backup destination on a local disk of the server
+type LocalSettings struct {
+	Disabled   bool   `json:"Disabled"`
+	FolderPath string `json:"FolderPath"`
+}
+
+// PeriodicBackupConfiguration describes a periodic backup task.
+// Frequencies are cron expressions. A task with TaskID of 0 is created
+// when sent with UpdatePeriodicBackupOperation.
+type PeriodicBackupConfiguration struct {
+	TaskID                     int64          `json:"TaskId"`
+	Name                       string         `json:"Name,omitempty"`
+	Disabled                   bool           `json:"Disabled"`
+	MentorNode                 string         `json:"MentorNode,omitempty"`
+	BackupType                 BackupType     `json:"BackupType"`
+	LocalSettings              *LocalSettings `json:"LocalSettings,omitempty"`
+	FullBackupFrequency        string         `json:"FullBackupFrequency,omitempty"`
+	IncrementalBackupFrequency string         `json:"IncrementalBackupFrequency,omitempty"`
+}
@@ -0,0 +1,72 @@
+package ravendb
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// PeriodicBackupStatus describes the outcome of the last runs of
+// a periodic backup task
+type PeriodicBackupStatus struct {
+	TaskID                        int64
+	BackupType                    BackupType
+	NodeTag                       string
+	IsFull                        bool
+	IsEncrypted                   bool
+	LastFullBackup                *time.Time
+	LastIncrementalBackup         *time.Time
+	FullBackupDurationInMs        int64
+	IncrementalBackupDurationInMs int64
+	LastOperationID               int64
+	// Error is a message of the error that failed the last backup, if any
+	Error string
+}
+
+// UnmarshalJSON flattens the status as sent by the server
+func (s *PeriodicBackupStatus) UnmarshalJSON(d []byte) error {
+	var v struct {
+		TaskID                int64      `json:"TaskId"`
+		BackupType            BackupType `json:"BackupType"`
+		NodeTag               string     `json:"NodeTag"`
+		IsFull                bool       `json:"IsFull"`
+		IsEncrypted           bool       `json:"IsEncrypted"`
+		LastFullBackup        *Time      `json:"LastFullBackup"`
+		LastIncrementalBackup *Time      `json:"LastIncrementalBackup"`
+		LastOperationID       *int64     `json:"LastOperationId"`
+		LocalBackup           *struct {
+			FullBackupDurationInMs        *int64 `json:"FullBackupDurationInMs"`
+			IncrementalBackupDurationInMs *int64 `json:"IncrementalBackupDurationInMs"`
+		} `json:"LocalBackup"`
+		Error *struct {
+			Exception string `json:"Exception"`
+		} `json:"Error"`
+	}
+	if err := json.Unmarshal(d, &v); err != nil {
+		return err
+	}
+
+	*s = PeriodicBackupStatus{
+		TaskID:                v.TaskID,
+		BackupType:            v.BackupType,
+		NodeTag:               v.NodeTag,
+		IsFull:                v.IsFull,
+		IsEncrypted:           v.IsEncrypted,
+		LastFullBackup:        v.LastFullBackup.toTimePtr(),
+		LastIncrementalBackup: v.LastIncrementalBackup.toTimePtr(),
+	}
+	if v.LastOperationID != nil {
+		s.LastOperationID = *v.LastOperationID
+	}
+	if lb := v.LocalBackup; lb != nil {
+		if lb.FullBackupDurationInMs != nil {
+			s.FullBackupDurationInMs = *lb.FullBackupDurationInMs
+		}
+		if lb.IncrementalBackupDurationInMs != nil {
+			s.IncrementalBackupDurationInMs = *lb.IncrementalBackupDurationInMs
+		}
+	}
+	if v.Error != nil {
+		s.Error = v.Error.Exception
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+package ravendb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetPeriodicBackupStatusCommandSetResponse(t *testing.T) {
+	cmd := NewGetPeriodicBackupStatusCommand(3)
+	require.NoError(t, cmd.SetResponse([]byte(`{"Status":null}`), false))
+	assert.Nil(t, cmd.Result)
+
+	response := `{"Status": {
+	"TaskId": 3,
+	"BackupType": "Backup",
+	"IsFull": true,
+	"NodeTag": "A",
+	"LastFullBackup": "2018-05-21T09:14:42.1234567Z",
+	"LastIncrementalBackup": null,
+	"LocalBackup": {"FullBackupDurationInMs": 120, "IncrementalBackupDurationInMs": null},
+	"LastOperationId": 7,
+	"IsEncrypted": false,
+	"Error": {"Exception": "disk full", "At": "2018-05-21T09:14:42.1234567Z"}
+}}`
+	cmd = NewGetPeriodicBackupStatusCommand(3)
+	require.NoError(t, cmd.SetResponse([]byte(response), false))
+	status := cmd.Result
+	require.NotNil(t, status)
+	assert.Equal(t, int64(3), status.TaskID)
+	assert.True(t, status.IsFull)
+	require.NotNil(t, status.LastFullBackup)
+	assert.Equal(t, 2018, status.LastFullBackup.Year())
+	assert.Nil(t, status.LastIncrementalBackup)
+	assert.Equal(t, int64(120), status.FullBackupDurationInMs)
+	assert.Equal(t, int64(0), status.IncrementalBackupDurationInMs)
+	assert.Equal(t, int64(7), status.LastOperationID)
+	assert.Equal(t, "disk full", status.Error)
+}
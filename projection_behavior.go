@@ -0,0 +1,14 @@
+package ravendb
+
+// ProjectionBehavior defines where values of projected fields are taken from
+type ProjectionBehavior = string
+
+const (
+	// ProjectionBehaviorDefault takes values from the index if they are
+	// stored there, from the document otherwise
+	ProjectionBehaviorDefault = "Default"
+	// ProjectionBehaviorFromIndex only takes values stored in the index
+	ProjectionBehaviorFromIndex = "FromIndex"
+	// ProjectionBehaviorFromDocument only takes values from the document
+	ProjectionBehaviorFromDocument = "FromDocument"
+)
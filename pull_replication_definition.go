@@ -0,0 +1,21 @@
+package ravendb
+
+// PullReplicationDefinition describes a pull replication hub i.e. a database
+// that sink databases pull data from
+type PullReplicationDefinition struct {
+	TaskID              int64    `json:"TaskId"`
+	Name                string   `json:"Name"`
+	Disabled            bool     `json:"Disabled"`
+	MentorNode          string   `json:"MentorNode,omitempty"`
+	DelayReplicationFor Duration `json:"DelayReplicationFor"`
+	// Certificates maps thumbprints to base64 encoded public keys of
+	// certificates that sinks can use to connect to the hub
+	Certificates map[string]string `json:"Certificates,omitempty"`
+}
+
+// NewPullReplicationDefinition returns a definition of a hub with a given name
+func NewPullReplicationDefinition(name string) *PullReplicationDefinition {
+	return &PullReplicationDefinition{
+		Name: name,
+	}
+}
@@ -0,0 +1,38 @@
+package ravendb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPutPullReplicationDefinitionCommandSetResponse(t *testing.T) {
+	definition := NewPullReplicationDefinition("hub")
+	definition.DelayReplicationFor = Duration(time.Minute)
+	cmd, err := NewPutPullReplicationDefinitionCommand(definition)
+	require.NoError(t, err)
+
+	err = cmd.SetResponse([]byte(`{"TaskId": 12, "RaftCommandIndex": 40, "ResponsibleNode": "A"}`), false)
+	require.NoError(t, err)
+	assert.Equal(t, int64(12), cmd.Result.TaskID)
+	assert.Equal(t, int64(12), definition.TaskID)
+}
+
+func TestGetReplicationHubDefinitionsCommand(t *testing.T) {
+	cmd := NewGetReplicationHubDefinitionsCommand()
+	response := `{
+	"OngoingTasksList": [],
+	"SubscriptionsCount": 0,
+	"PullReplications": [
+		{"TaskId": 12, "Name": "hub", "Disabled": false, "DelayReplicationFor": "00:01:00", "Certificates": {}}
+	]
+}`
+	require.NoError(t, cmd.SetResponse([]byte(response), false))
+	require.Equal(t, 1, len(cmd.Result))
+	hub := cmd.Result[0]
+	assert.Equal(t, int64(12), hub.TaskID)
+	assert.Equal(t, "hub", hub.Name)
+	assert.Equal(t, Duration(time.Minute), hub.DelayReplicationFor)
+}
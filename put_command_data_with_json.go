@@ -23,6 +23,19 @@ func newPutCommandDataWithJSON(id string, changeVector *string, document map[str
 	return res
 }
 
+// NewPutCommandData creates ICommandData for Put command.
+// It can be used with session.Advanced().Defer() to store a raw JSON document
+// without tracking it in the session.
+// If changeVector is not empty, the put will fail if the document on the
+// server has a different change vector
+func NewPutCommandData(id string, changeVector string, document map[string]interface{}) ICommandData {
+	var changeVectorPtr *string
+	if changeVector != "" {
+		changeVectorPtr = &changeVector
+	}
+	return newPutCommandDataWithJSON(id, changeVectorPtr, document)
+}
+
 func (d *PutCommandDataWithJSON) serialize(conventions *DocumentConventions) (interface{}, error) {
 	js := d.baseJSON()
 	js["Document"] = d.document
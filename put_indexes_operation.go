@@ -63,7 +63,10 @@ func NewPutIndexesCommand(conventions *DocumentConventions, indexesToAdd []*Inde
 		indexToAdd.updateIndexTypeAndMaps()
 
 		panicIf(indexToAdd.Name == "", "Index name cannot be empty")
-		objectNode := convertEntityToJSON(indexToAdd, nil)
+		objectNode, err := convertEntityToJSON(nil, indexToAdd, nil)
+		if err != nil {
+			return nil, err
+		}
 		cmd.indexToAdd = append(cmd.indexToAdd, objectNode)
 	}
 
@@ -0,0 +1,76 @@
+package ravendb
+
+import (
+	"net/http"
+)
+
+var (
+	_ IMaintenanceOperation = &PutPullReplicationDefinitionOperation{}
+)
+
+// PutPullReplicationDefinitionOperation creates or updates a pull replication
+// hub. On success TaskID of the definition is set to the id of the hub task.
+type PutPullReplicationDefinitionOperation struct {
+	definition *PullReplicationDefinition
+
+	Command *PutPullReplicationDefinitionCommand
+}
+
+func NewPutPullReplicationDefinitionOperation(definition *PullReplicationDefinition) *PutPullReplicationDefinitionOperation {
+	return &PutPullReplicationDefinitionOperation{
+		definition: definition,
+	}
+}
+
+func (o *PutPullReplicationDefinitionOperation) GetCommand(conventions *DocumentConventions) (RavenCommand, error) {
+	var err error
+	o.Command, err = NewPutPullReplicationDefinitionCommand(o.definition)
+	return o.Command, err
+}
+
+var _ RavenCommand = &PutPullReplicationDefinitionCommand{}
+
+type PutPullReplicationDefinitionCommand struct {
+	RavenCommandBase
+
+	definition *PullReplicationDefinition
+
+	Result *ModifyOngoingTaskResult
+}
+
+func NewPutPullReplicationDefinitionCommand(definition *PullReplicationDefinition) (*PutPullReplicationDefinitionCommand, error) {
+	if definition == nil {
+		return nil, newIllegalArgumentError("Definition cannot be null")
+	}
+	if definition.Name == "" {
+		return nil, newIllegalArgumentError("Name of the pull replication definition cannot be empty")
+	}
+	cmd := &PutPullReplicationDefinitionCommand{
+		RavenCommandBase: NewRavenCommandBase(),
+
+		definition: definition,
+	}
+	return cmd, nil
+}
+
+func (c *PutPullReplicationDefinitionCommand) CreateRequest(node *ServerNode) (*http.Request, error) {
+	url := node.URL + "/databases/" + node.Database + "/admin/tasks/pull-replication/hub"
+
+	d, err := jsonMarshal(c.definition)
+	if err != nil {
+		return nil, err
+	}
+	return newHttpPut(url, d)
+}
+
+func (c *PutPullReplicationDefinitionCommand) SetResponse(response []byte, fromCache bool) error {
+	if len(response) == 0 {
+		return throwInvalidResponse()
+	}
+
+	if err := jsonUnmarshal(response, &c.Result); err != nil {
+		return err
+	}
+	c.definition.TaskID = c.Result.TaskID
+	return nil
+}
@@ -0,0 +1,81 @@
+package ravendb
+
+import (
+	"net/http"
+)
+
+var _ IVoidMaintenanceOperation = &PutSortersOperation{}
+
+// PutSortersOperation deploys custom sorters to a database
+type PutSortersOperation struct {
+	sortersToAdd []*SorterDefinition
+
+	Command *PutSortersCommand
+}
+
+// NewPutSortersOperation returns an operation that adds or replaces given sorters
+func NewPutSortersOperation(sortersToAdd ...*SorterDefinition) (*PutSortersOperation, error) {
+	if len(sortersToAdd) == 0 {
+		return nil, newIllegalArgumentError("sortersToAdd cannot be empty")
+	}
+	return &PutSortersOperation{
+		sortersToAdd: sortersToAdd,
+	}, nil
+}
+
+func (o *PutSortersOperation) GetCommand(conventions *DocumentConventions) (RavenCommand, error) {
+	var err error
+	o.Command, err = NewPutSortersCommand(conventions, o.sortersToAdd)
+	if err != nil {
+		return nil, err
+	}
+	return o.Command, nil
+}
+
+var (
+	_ RavenCommand = &PutSortersCommand{}
+)
+
+type PutSortersCommand struct {
+	RavenCommandBase
+
+	sortersToAdd []byte
+}
+
+func NewPutSortersCommand(conventions *DocumentConventions, sortersToAdd []*SorterDefinition) (*PutSortersCommand, error) {
+	if conventions == nil {
+		return nil, newIllegalArgumentError("conventions cannot be null")
+	}
+	if len(sortersToAdd) == 0 {
+		return nil, newIllegalArgumentError("sortersToAdd cannot be empty")
+	}
+	for _, sorter := range sortersToAdd {
+		if sorter == nil {
+			return nil, newIllegalArgumentError("sorter cannot be null")
+		}
+		if sorter.Name == "" {
+			return nil, newIllegalArgumentError("sorter name cannot be empty")
+		}
+	}
+
+	m := map[string]interface{}{
+		"Sorters": sortersToAdd,
+	}
+	d, err := jsonMarshal(m)
+	if err != nil {
+		return nil, err
+	}
+	cmd := &PutSortersCommand{
+		RavenCommandBase: NewRavenCommandBase(),
+
+		sortersToAdd: d,
+	}
+	cmd.ResponseType = RavenCommandResponseTypeEmpty
+	return cmd, nil
+}
+
+func (c *PutSortersCommand) CreateRequest(node *ServerNode) (*http.Request, error) {
+	url := node.URL + "/databases/" + node.Database + "/admin/sorters"
+
+	return newHttpPut(url, c.sortersToAdd)
+}
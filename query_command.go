@@ -35,6 +35,8 @@ func NewQueryCommand(conventions *DocumentConventions, indexQuery *IndexQuery, m
 	return cmd, nil
 }
 
+// CreateRequest creates a POST request with the query and its parameters in
+// the body, so the size of the query is not limited by url length
 func (c *QueryCommand) CreateRequest(node *ServerNode) (*http.Request, error) {
 	c.CanCache = !c.indexQuery.disableCaching
 
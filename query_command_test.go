@@ -0,0 +1,34 @@
+package ravendb
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryCommandLargeWhereInUsesPost(t *testing.T) {
+	session := newQueryTestSession()
+
+	var ids []interface{}
+	for i := 0; i < 1000; i++ {
+		ids = append(ids, "users/"+strings.Repeat("x", 16)+strconv.Itoa(i))
+	}
+	iq, err := session.QueryCollection("Users").WhereIn("ID", ids).GetIndexQuery()
+	require.NoError(t, err)
+
+	conventions := NewDocumentConventions()
+	cmd, err := NewQueryCommand(conventions, iq, false, false)
+	require.NoError(t, err)
+	req, d := createTestRequest(t, cmd)
+	assert.Equal(t, http.MethodPost, req.Method)
+	assert.True(t, len(req.URL.String()) < conventions.MaxLengthOfQueryUsingGetURL)
+	assert.False(t, strings.Contains(req.URL.RawQuery, "users%2F"))
+
+	body := string(d)
+	assert.True(t, strings.Contains(body, `"Query":"from Users where id() in ($p0)"`))
+	assert.True(t, strings.Contains(body, "users/"+strings.Repeat("x", 16)+"999"))
+}
@@ -9,7 +9,7 @@ type QueryData struct {
 
 	// TODO: should those be exposed as well?
 	fromAlias        string
-	declareToken     *declareToken
+	declareTokens    []*declareToken
 	loadTokens       []*loadToken
 	isCustomFunction bool
 }
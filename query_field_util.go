@@ -1,40 +1,108 @@
 package ravendb
 
-func queryFieldUtilEscapeIfNecessary(name string) string {
+import "strings"
+
+// queryFieldUtilEscapeIfNecessary returns field name in a form that can be
+// safely embedded in RQL.
+// Names made of letters, digits and _ - @ . [ ] are returned as is.
+// A name may also contain quoted segments (e.g. Address.'Zip Code') as long
+// as each quote starts and ends a whole segment of the path.
+// Everything else is quoted as a single field name.
+// Names that contain line breaks or comment sequences are rejected.
+func queryFieldUtilEscapeIfNecessary(name string) (string, error) {
 	if stringIsEmpty(name) ||
 		IndexingFieldNameDocumentID == name ||
 		IndexingFieldNameReduceKeyHash == name ||
 		IndexingFieldNameReduceKeyValue == name ||
 		IndexingFieldsNameSpatialShare == name {
-		return name
+		return name, nil
+	}
+
+	if err := queryFieldUtilValidate(name); err != nil {
+		return "", err
+	}
+
+	if queryFieldUtilIsSafe(name) {
+		return name, nil
+	}
+	return queryFieldUtilQuote(name), nil
+}
+
+// queryFieldUtilValidate rejects names that we don't want to put in a query
+// even when quoted
+func queryFieldUtilValidate(name string) error {
+	if strings.ContainsAny(name, "\r\n") {
+		return newIllegalArgumentError("Field name '%s' cannot contain line breaks", name)
+	}
+	for _, seq := range []string{"//", "/*", "*/"} {
+		if strings.Contains(name, seq) {
+			return newIllegalArgumentError("Field name '%s' cannot contain '%s'", name, seq)
+		}
 	}
+	return nil
+}
 
-	escape := false
-	insideEscaped := false
+func queryFieldUtilIsSafeChar(c rune, isFirst bool) bool {
+	if isFirst {
+		return isLetter(c) || c == '_' || c == '@'
+	}
+	return isLetterOrDigit(c) || c == '_' || c == '-' || c == '@' || c == '.' || c == '[' || c == ']'
+}
 
+// queryFieldUtilIsIdentifier returns true if name can be used as an alias
+// or a function name i.e. it's a single, unquoted path segment
+func queryFieldUtilIsIdentifier(name string) bool {
+	if name == "" {
+		return false
+	}
 	for i, c := range name {
+		if !queryFieldUtilIsSafeChar(c, i == 0) || c == '.' || c == '[' || c == ']' || c == '-' {
+			return false
+		}
+	}
+	return true
+}
 
-		if c == '\'' || c == '"' {
-			insideEscaped = !insideEscaped
+// queryFieldUtilIsSafe returns true if name can be written to RQL without
+// additional quoting
+func queryFieldUtilIsSafe(name string) bool {
+	runes := []rune(name)
+	n := len(runes)
+	for i := 0; i < n; i++ {
+		c := runes[i]
+		if c != '\'' && c != '"' {
+			if !queryFieldUtilIsSafeChar(c, i == 0) {
+				return false
+			}
 			continue
 		}
 
-		if i == 0 {
-			if !isLetter(c) && c != '_' && c != '@' && !insideEscaped {
-				escape = true
-				break
+		// quoted segment must start at the beginning of a path segment...
+		if i > 0 && runes[i-1] != '.' {
+			return false
+		}
+		end := -1
+		for j := i + 1; j < n; j++ {
+			if runes[j] == '\\' {
+				// would escape the closing quote
+				return false
 			}
-		} else {
-			if !isLetterOrDigit(c) && c != '_' && c != '-' && c != '@' && c != '.' && c != '[' && c != ']' && !insideEscaped {
-				escape = true
+			if runes[j] == c {
+				end = j
 				break
 			}
 		}
+		// ...and end at the end of a path segment
+		if end == -1 || (end+1 < n && runes[end+1] != '.') {
+			return false
+		}
+		i = end
 	}
+	return true
+}
 
-	if escape || insideEscaped {
-		return "'" + name + "'"
-	}
-
-	return name
+func queryFieldUtilQuote(name string) string {
+	s := strings.Replace(name, `\`, `\\`, -1)
+	s = strings.Replace(s, "'", `\'`, -1)
+	return "'" + s + "'"
 }
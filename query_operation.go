@@ -118,6 +118,8 @@ func (o *queryOperation) complete(results interface{}) error {
 
 	if !o.disableEntitiesTracking {
 		o.session.registerIncludes(queryResult.Includes)
+		o.session.registerCounters(queryResult.CounterIncludes)
+		o.session.registerCompareExchangeValues(queryResult.CompareExchangeValueIncludes)
 	}
 
 	slice, err := makeSliceForResults(results)
@@ -135,13 +137,26 @@ func (o *queryOperation) complete(results interface{}) error {
 		}
 		metadata := metadataI.(map[string]interface{})
 		id, _ := jsonGetAsText(metadata, MetadataID)
-		result := reflect.New(clazz) // this is a pointer to desired value
+		entityType := clazz
+		if clazz.Kind() == reflect.Interface {
+			if entityType, err = o.session.GetConventions().getEntityTypeForInterface(clazz, metadata); err != nil {
+				return err
+			}
+		}
+		result := reflect.New(entityType) // this is a pointer to desired value
 		err := queryOperationDeserialize(result.Interface(), id, document, metadata, o.fieldsToFetch, o.disableEntitiesTracking, o.session)
 		if err != nil {
-			return newRuntimeError("Unable to read json: %s", err)
+			return newRuntimeError("Unable to read json: %s", err.Error(), err)
+		}
+		// listeners get a pointer so that their changes end up in the
+		// results, also when results is a slice of values e.g. []string
+		entity := result
+		if result.Elem().Kind() == reflect.Ptr {
+			entity = result.Elem()
 		}
+		o.session.onEntityMaterializedInvoke(entity.Interface(), metadata)
 		// de-reference pointer value
-		tmpSlice = reflect.Append(tmpSlice, result.Elem())
+		tmpSlice = reflect.Append(tmpSlice, result.Elem().Convert(clazz))
 	}
 
 	if !o.disableEntitiesTracking {
@@ -213,7 +228,7 @@ func queryOperationDeserialize(result interface{}, id string, document map[strin
 		}
 	}
 
-	res, err := treeToValue(clazz, document)
+	res, err := entityFromJSONMap(session.GetConventions(), clazz, document)
 	if err != nil {
 		return err
 	}
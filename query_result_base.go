@@ -14,4 +14,10 @@ type queryResultBase struct {
 	IndexName      string                   `json:"IndexName"`
 	ResultEtag     int64                    `json:"ResultEtag"`
 	LastQueryTime  *Time                    `json:"LastQueryTime"`
+
+	// CounterIncludes maps document id to its included counters
+	CounterIncludes map[string][]*CounterDetail `json:"CounterIncludes"`
+	// CompareExchangeValueIncludes maps key to included compare
+	// exchange value
+	CompareExchangeValueIncludes map[string]map[string]interface{} `json:"CompareExchangeValueIncludes"`
 }
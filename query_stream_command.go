@@ -14,6 +14,11 @@ type QueryStreamCommand struct {
 	_conventions *DocumentConventions
 	_indexQuery  *IndexQuery
 
+	// if "csv", the server returns results as CSV with columns
+	// given by fields (or all fields if empty)
+	format string
+	fields []string
+
 	Result *StreamResultResponse
 }
 
@@ -32,6 +37,12 @@ func NewQueryStreamCommand(conventions *DocumentConventions, indexQuery *IndexQu
 
 func (c *QueryStreamCommand) CreateRequest(node *ServerNode) (*http.Request, error) {
 	url := node.URL + "/databases/" + node.Database + "/streams/queries"
+	if c.format != "" {
+		url += "?format=" + urlUtilsEscapeDataString(c.format)
+		for _, field := range c.fields {
+			url += "&field=" + urlUtilsEscapeDataString(field)
+		}
+	}
 
 	m := jsonExtensionsWriteIndexQuery(c._conventions, c._indexQuery)
 	d, err := jsonMarshal(m)
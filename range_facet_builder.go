@@ -0,0 +1,157 @@
+package ravendb
+
+import (
+	"reflect"
+	"time"
+)
+
+// RangeFacetBuilder builds a range facet over a numeric or date field
+// out of buckets e.g.
+//
+//	facet, err := NewRangeFacetBuilder("price").LessThan(100).Between(100, 500).GreaterThanOrEqual(500).GetFacet()
+//
+// Between includes the lower bound and excludes the upper bound so that
+// consecutive buckets don't overlap.
+// time.Time values are sent in the format expected by the server.
+type RangeFacetBuilder struct {
+	field  string
+	facet  *GenericRangeFacet
+	isDate *bool
+
+	err error
+}
+
+// NewRangeFacetBuilder returns a builder of a range facet over a given field
+func NewRangeFacetBuilder(field string) *RangeFacetBuilder {
+	b := &RangeFacetBuilder{
+		field: field,
+		facet: NewGenericRangeFacet(nil),
+	}
+	if field == "" {
+		b.err = newIllegalArgumentError("field cannot be empty")
+	}
+	return b
+}
+
+// LessThan adds a bucket of values < value
+func (b *RangeFacetBuilder) LessThan(value interface{}) *RangeFacetBuilder {
+	return b.addRange(func(r *RangeBuilder, values []interface{}) *RangeBuilder {
+		return r.IsLessThan(values[0])
+	}, value)
+}
+
+// LessThanOrEqual adds a bucket of values <= value
+func (b *RangeFacetBuilder) LessThanOrEqual(value interface{}) *RangeFacetBuilder {
+	return b.addRange(func(r *RangeBuilder, values []interface{}) *RangeBuilder {
+		return r.IsLessThanOrEqualTo(values[0])
+	}, value)
+}
+
+// GreaterThan adds a bucket of values > value
+func (b *RangeFacetBuilder) GreaterThan(value interface{}) *RangeFacetBuilder {
+	return b.addRange(func(r *RangeBuilder, values []interface{}) *RangeBuilder {
+		return r.IsGreaterThan(values[0])
+	}, value)
+}
+
+// GreaterThanOrEqual adds a bucket of values >= value
+func (b *RangeFacetBuilder) GreaterThanOrEqual(value interface{}) *RangeFacetBuilder {
+	return b.addRange(func(r *RangeBuilder, values []interface{}) *RangeBuilder {
+		return r.IsGreaterThanOrEqualTo(values[0])
+	}, value)
+}
+
+// Between adds a bucket of values >= from and < to
+func (b *RangeFacetBuilder) Between(from interface{}, to interface{}) *RangeFacetBuilder {
+	return b.addRange(func(r *RangeBuilder, values []interface{}) *RangeBuilder {
+		return r.IsGreaterThanOrEqualTo(values[0]).IsLessThan(values[1])
+	}, from, to)
+}
+
+// WithDisplayName sets the name under which results are returned
+func (b *RangeFacetBuilder) WithDisplayName(displayName string) *RangeFacetBuilder {
+	b.facet.SetDisplayFieldName(displayName)
+	return b
+}
+
+// WithOptions sets facet options
+func (b *RangeFacetBuilder) WithOptions(options *FacetOptions) *RangeFacetBuilder {
+	b.facet.SetOptions(options)
+	return b
+}
+
+// GetFacet returns the facet to be passed to AggregateByFacet or the first
+// error encountered while building it
+func (b *RangeFacetBuilder) GetFacet() (FacetBase, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	if len(b.facet.Ranges) == 0 {
+		return nil, newIllegalStateError("Range facet for field '%s' has no ranges", b.field)
+	}
+	return b.facet, nil
+}
+
+// Err returns the first error encountered while building the facet
+func (b *RangeFacetBuilder) Err() error {
+	return b.err
+}
+
+func (b *RangeFacetBuilder) addRange(fn func(*RangeBuilder, []interface{}) *RangeBuilder, values ...interface{}) *RangeFacetBuilder {
+	if b.err != nil {
+		return b
+	}
+	for i, value := range values {
+		v, err := b.rangeValue(value)
+		if err != nil {
+			b.err = err
+			return b
+		}
+		values[i] = v
+	}
+
+	rng := fn(NewRangeBuilder(b.field), values)
+	if err := rng.Err(); err != nil {
+		b.err = err
+		return b
+	}
+	b.facet.addRange(rng)
+	return b
+}
+
+// rangeValue validates that value is a number or a date, the same as
+// values of other ranges, and converts dates to Time
+func (b *RangeFacetBuilder) rangeValue(value interface{}) (interface{}, error) {
+	isDate := false
+	switch v := value.(type) {
+	case time.Time:
+		isDate = true
+		value = Time(v)
+	case *time.Time:
+		if v == nil {
+			return nil, newIllegalArgumentError("range value of field '%s' cannot be nil", b.field)
+		}
+		isDate = true
+		value = Time(*v)
+	case Time:
+		isDate = true
+	default:
+		if value == nil {
+			return nil, newIllegalArgumentError("range value of field '%s' cannot be nil", b.field)
+		}
+		switch reflect.TypeOf(value).Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+			reflect.Float32, reflect.Float64:
+		default:
+			return nil, newIllegalArgumentError("range value of field '%s' must be a number or time.Time, got %T", b.field, value)
+		}
+	}
+
+	if b.isDate == nil {
+		b.isDate = &isDate
+	} else if *b.isDate != isDate {
+		return nil, newIllegalArgumentError("ranges of field '%s' can't mix numbers and dates", b.field)
+	}
+	return value, nil
+}
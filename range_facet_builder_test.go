@@ -0,0 +1,70 @@
+package ravendb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func rangeFacetQuery(b *RangeFacetBuilder) (*IndexQuery, error) {
+	facet, err := b.GetFacet()
+	if err != nil {
+		return nil, err
+	}
+	session := newQueryTestSession()
+	return session.QueryIndex("Products").AggregateByFacet(facet).GetIndexQuery()
+}
+
+func TestRangeFacetBuilderPriceBuckets(t *testing.T) {
+	b := NewRangeFacetBuilder("price").
+		LessThan(100).
+		Between(100, 500).
+		GreaterThanOrEqual(500.5)
+	require.NoError(t, b.Err())
+
+	iq, err := rangeFacetQuery(b)
+	require.NoError(t, err)
+	assert.Equal(t, "from index 'Products' select facet(price < $p0, price >= $p2 and price < $p1, price >= $p3)", iq.GetQuery())
+	params := iq.GetQueryParameters()
+	assert.Equal(t, 100, params["p0"])
+	assert.Equal(t, 500, params["p1"])
+	assert.Equal(t, 100, params["p2"])
+	assert.Equal(t, 500.5, params["p3"])
+}
+
+func TestRangeFacetBuilderDates(t *testing.T) {
+	day := time.Date(2019, 3, 1, 0, 0, 0, 0, time.UTC)
+	b := NewRangeFacetBuilder("at").
+		WithDisplayName("period").
+		LessThan(day).
+		GreaterThanOrEqual(day)
+	iq, err := rangeFacetQuery(b)
+	require.NoError(t, err)
+	assert.Equal(t, "from index 'Products' select facet(at < $p0, at >= $p1) as period", iq.GetQuery())
+	// dates use the same format as other dates sent to the server
+	assert.Equal(t, Time(day), iq.GetQueryParameters()["p0"])
+}
+
+func TestRangeFacetBuilderErrors(t *testing.T) {
+	// can't mix numbers and dates
+	b := NewRangeFacetBuilder("price").LessThan(100).GreaterThan(time.Now())
+	assert.Error(t, b.Err())
+	_, err := rangeFacetQuery(b)
+	assert.Error(t, err)
+
+	b = NewRangeFacetBuilder("price").LessThan("100")
+	assert.Error(t, b.Err())
+
+	b = NewRangeFacetBuilder("price").Between(nil, 100)
+	assert.Error(t, b.Err())
+
+	b = NewRangeFacetBuilder("")
+	_, err = rangeFacetQuery(b.LessThan(1))
+	assert.Error(t, err)
+
+	// no ranges
+	_, err = rangeFacetQuery(NewRangeFacetBuilder("price"))
+	assert.Error(t, err)
+}
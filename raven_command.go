@@ -4,6 +4,7 @@ import (
 	"io"
 	"io/ioutil"
 	"net/http"
+	"time"
 )
 
 var (
@@ -32,6 +33,10 @@ type RavenCommandBase struct {
 	// if true, can be cached
 	IsReadRequest bool
 
+	// Timeout, if non-zero, overrides DocumentConventions.Timeout for
+	// this command
+	Timeout time.Duration
+
 	FailedNodes map[*ServerNode]error
 }
 
@@ -179,18 +184,42 @@ func (c *RavenCommandBase) onResponseFailure(response *http.Response) {
 }
 
 // Note: hackish solution due to lack of generics
-// Returns OperationIDReuslt for commands that have it as a result
+// Returns a pointer to OperationIDReuslt for commands that have it as a result
+// and nil for other commands
 // When new command returning OperationIDResult are added, we must extend it
-func getCommandOperationIDResult(cmd RavenCommand) *OperationIDResult {
+func getCommandOperationIDResultRef(cmd RavenCommand) **OperationIDResult {
 	switch c := cmd.(type) {
 	case *CompactDatabaseCommand:
-		return c.Result
+		return &c.Result
 	case *PatchByQueryCommand:
-		return c.Result
+		return &c.Result
 	case *DeleteByIndexCommand:
-		return c.Result
+		return &c.Result
+	case *StartBackupCommand:
+		return &c.Result
+	case *RestoreBackupCommand:
+		return &c.Result
 	}
+	return nil
+}
 
-	panicIf(true, "called on a command %T that doesn't return OperationIDResult", cmd)
+// assertCommandReturnsOperationID checks, before cmd is executed, that
+// it starts a server operation
+func assertCommandReturnsOperationID(cmd RavenCommand) error {
+	if getCommandOperationIDResultRef(cmd) == nil {
+		return newIllegalArgumentError("command %T doesn't start a server operation, use Send() instead of SendAsync()", cmd)
+	}
 	return nil
 }
+
+// Returns OperationIDReuslt of an executed command
+func getCommandOperationIDResult(cmd RavenCommand) (*OperationIDResult, error) {
+	if err := assertCommandReturnsOperationID(cmd); err != nil {
+		return nil, err
+	}
+	res := *getCommandOperationIDResultRef(cmd)
+	if res == nil {
+		return nil, newIllegalStateError("command %T didn't return operation id", cmd)
+	}
+	return res, nil
+}
@@ -258,6 +258,18 @@ func FieldsFor(s interface{}) []string {
 	return res
 }
 
+// fieldsForType is like FieldsFor but takes a type of struct
+// (or pointer to struct)
+func fieldsForType(typ reflect.Type) []string {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ.Kind() != reflect.Struct {
+		return nil
+	}
+	return FieldsFor(reflect.New(typ).Interface())
+}
+
 // given js value (most likely as map[string]interface{}) decode into res
 func decodeJSONAsStruct(js interface{}, res interface{}) error {
 	d, err := jsonMarshal(js)
@@ -269,6 +281,10 @@ func decodeJSONAsStruct(js interface{}, res interface{}) error {
 
 // given a json represented as map and type of a struct
 func makeStructFromJSONMap(typ reflect.Type, js map[string]interface{}) (interface{}, error) {
+	return makeStructFromJSONMapWithSerializer(defaultJSONSerializer{}, typ, js)
+}
+
+func makeStructFromJSONMapWithSerializer(serializer JSONSerializer, typ reflect.Type, js map[string]interface{}) (interface{}, error) {
 	if typ == reflect.TypeOf(map[string]interface{}{}) {
 		return js, nil
 	}
@@ -289,7 +305,7 @@ func makeStructFromJSONMap(typ reflect.Type, js map[string]interface{}) (interfa
 		return nil, err
 	}
 	v := rvNew.Interface()
-	err = jsonUnmarshal(d, v)
+	err = serializer.Unmarshal(d, v)
 	if err != nil {
 		return nil, err
 	}
@@ -379,6 +395,15 @@ func getSingleMapValue(results interface{}) (interface{}, error) {
 	return v.Interface(), nil
 }
 
+// isInterfaceType returns true if typ is an interface type
+// or a pointer to it
+func isInterfaceType(typ reflect.Type) bool {
+	for typ != nil && typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	return typ != nil && typ.Kind() == reflect.Interface
+}
+
 func checkIsPtrSlice(v interface{}, argName string) error {
 	if v == nil {
 		return newIllegalArgumentError("%s can't be nil", argName)
@@ -0,0 +1,92 @@
+package ravendb
+
+import (
+	"net/http"
+)
+
+var (
+	_ databaseTopologyOperation = &ReorderDatabaseMembersOperation{}
+)
+
+// ReorderDatabaseMembersOperation changes the order of members of a
+// database group. The first member is the preferred node of clients.
+// When sent with ServerOperationExecutor.Send, the order is validated
+// against current members of the database and the store's cached
+// topology of the database is refreshed afterwards.
+type ReorderDatabaseMembersOperation struct {
+	database string
+	order    []string
+
+	Command *ReorderDatabaseMembersCommand
+}
+
+// NewReorderDatabaseMembersOperation returns new ReorderDatabaseMembersOperation
+func NewReorderDatabaseMembersOperation(database string, order []string) *ReorderDatabaseMembersOperation {
+	return &ReorderDatabaseMembersOperation{
+		database: database,
+		order:    order,
+	}
+}
+
+func (o *ReorderDatabaseMembersOperation) GetCommand(conventions *DocumentConventions) (RavenCommand, error) {
+	var err error
+	o.Command, err = NewReorderDatabaseMembersCommand(o.database, o.order)
+	if err != nil {
+		return nil, err
+	}
+	return o.Command, nil
+}
+
+func (o *ReorderDatabaseMembersOperation) getDatabaseName() string {
+	return o.database
+}
+
+// validateTopology checks that the new order is a permutation of current members
+func (o *ReorderDatabaseMembersOperation) validateTopology(topology *DatabaseTopology) error {
+	var members []string
+	if topology != nil {
+		members = topology.Members
+	}
+	if !stringArrayEq(members, o.order) {
+		return newIllegalArgumentError("order %v must contain each member of database '%s' exactly once, members are %v", o.order, o.database, members)
+	}
+	return nil
+}
+
+var _ RavenCommand = &ReorderDatabaseMembersCommand{}
+
+type ReorderDatabaseMembersCommand struct {
+	RavenCommandBase
+
+	database   string
+	parameters []byte
+}
+
+func NewReorderDatabaseMembersCommand(database string, order []string) (*ReorderDatabaseMembersCommand, error) {
+	if database == "" {
+		return nil, newIllegalArgumentError("database cannot be empty")
+	}
+	if len(order) == 0 {
+		return nil, newIllegalArgumentError("order cannot be empty")
+	}
+	m := map[string]interface{}{
+		"MembersOrder": order,
+	}
+	d, err := jsonMarshal(m)
+	if err != nil {
+		return nil, err
+	}
+	cmd := &ReorderDatabaseMembersCommand{
+		RavenCommandBase: NewRavenCommandBase(),
+
+		database:   database,
+		parameters: d,
+	}
+	cmd.ResponseType = RavenCommandResponseTypeEmpty
+	return cmd, nil
+}
+
+func (c *ReorderDatabaseMembersCommand) CreateRequest(node *ServerNode) (*http.Request, error) {
+	url := node.URL + "/admin/databases/reorder?name=" + urlUtilsEscapeDataString(c.database)
+	return NewHttpPost(url, c.parameters)
+}
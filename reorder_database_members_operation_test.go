@@ -0,0 +1,82 @@
+package ravendb
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTopologyTestServer returns a single node cluster server with database
+// db that has members A and B. It records requests other than topology
+// requests and counts database topology requests
+func newTopologyTestServer(mu *sync.Mutex, requests *[]string, nTopologyRequests *int) *httptest.Server {
+	var srv *httptest.Server
+	fn := func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch r.URL.Path {
+		case "/cluster/topology":
+			_, _ = w.Write([]byte(`{"Leader":"A","NodeTag":"A","Topology":{"TopologyId":"t","AllNodes":{"A":"` + srv.URL + `"},` +
+				`"Members":{"A":"` + srv.URL + `"},"Promotables":{},"Watchers":{},"LastNodeId":"A","Etag":1},"Etag":1}`))
+		case "/topology":
+			*nTopologyRequests++
+			_, _ = w.Write([]byte(`{"Nodes":[{"Url":"` + srv.URL + `","ClusterTag":"A","Database":"db","ServerRole":"Member"}],"Etag":1}`))
+		case "/databases/db/stats":
+			_, _ = w.Write([]byte(`{}`))
+		case "/admin/databases":
+			*requests = append(*requests, r.Method+" "+r.URL.RequestURI())
+			_, _ = w.Write([]byte(`{"DatabaseName":"db","DatabaseTopology":{"Members":["A","B"]},"Etag":1}`))
+		case "/admin/databases/reorder":
+			d, _ := ioutil.ReadAll(r.Body)
+			*requests = append(*requests, r.Method+" "+r.URL.RequestURI()+" "+string(d))
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+	srv = httptest.NewServer(http.HandlerFunc(fn))
+	return srv
+}
+
+func TestReorderDatabaseMembersOperation(t *testing.T) {
+	var mu sync.Mutex
+	var requests []string
+	var nTopologyRequests int
+	srv := newTopologyTestServer(&mu, &requests, &nTopologyRequests)
+	defer srv.Close()
+
+	store := NewDocumentStore([]string{srv.URL}, "db")
+	require.NoError(t, store.Initialize())
+	defer store.Close()
+	// make sure the store has a request executor for the database and
+	// its initial topology update is done
+	err := store.GetRequestExecutor("").ExecuteCommand(NewGetStatisticsCommand(""), nil)
+	require.NoError(t, err)
+
+	// not a permutation of members
+	err = store.Maintenance().Server().Send(NewReorderDatabaseMembersOperation("db", []string{"A", "C"}))
+	_, ok := err.(*IllegalArgumentError)
+	assert.True(t, ok, "expected *IllegalArgumentError, got %T (%v)", err, err)
+	mu.Lock()
+	assert.Equal(t, []string{"GET /admin/databases?name=db"}, requests)
+	requests = nil
+	nBefore := nTopologyRequests
+	mu.Unlock()
+
+	err = store.Maintenance().Server().Send(NewReorderDatabaseMembersOperation("db", []string{"B", "A"}))
+	require.NoError(t, err)
+	mu.Lock()
+	defer mu.Unlock()
+	expected := []string{
+		"GET /admin/databases?name=db",
+		`POST /admin/databases/reorder?name=db {"MembersOrder":["B","A"]}`,
+	}
+	assert.Equal(t, expected, requests)
+	// cached topology of the database is refreshed
+	assert.True(t, nTopologyRequests > nBefore)
+}
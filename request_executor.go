@@ -4,9 +4,11 @@ import (
 	"crypto/tls"
 	"crypto/x509"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"math"
+	"net"
 	"net/http"
 	"strings"
 	"sync"
@@ -63,9 +65,19 @@ type RequestExecutor struct {
 	updateTopologyTimer *time.Timer
 	nodeSelector        atomic.Value // atomic to avoid data races
 
-	NumberOfServerRequests  atomicInteger
-	TopologyEtag            int64
+	NumberOfServerRequests atomicInteger
+	// TopologyEtag and ClientConfigurationEtag are sent to the server with
+	// every request so that it can tell us if they're out of date.
+	// They're updated in the background so access must be protected
+	// with etagsMu.
+	//
+	// Deprecated: reading TopologyEtag directly races with topology
+	// updates, use GetTopologyEtag instead
+	TopologyEtag int64
+	// Deprecated: reading ClientConfigurationEtag directly races with
+	// client configuration updates, use GetClientConfigurationEtag instead
 	ClientConfigurationEtag int64
+	etagsMu                 sync.Mutex
 	conventions             *DocumentConventions
 
 	disableTopologyUpdates            bool
@@ -74,17 +86,33 @@ type RequestExecutor struct {
 	firstTopologyUpdateFuture *completableFuture
 
 	readBalanceBehavior ReadBalanceBehavior
+	// spreads reads not tied to a session under ReadBalanceBehaviorRoundRobin
+	roundRobinCounter uint32 // atomic
 	// TODO: mulit-threaded access, protect
 	Cache                 *httpCache
 	httpClient            *http.Client
 	topologyTakenFromNode *ServerNode
 
+	// clients created with conventions.HTTPClientFactory, by node url
+	nodeHTTPClients sync.Map
+
+	// version of the server, as reported in Raven-Server-Version header
+	serverVersion atomic.Value // string
+
 	lastKnownUrls []string
 
 	mu sync.Mutex
 
 	disposed int32 // atomic
 
+	// tracks commands being executed so that DocumentStore.Close()
+	// can wait for them to finish. sync.WaitGroup can't be used because
+	// new commands can start while we wait. Protected by inFlightMu
+	inFlightMu sync.Mutex
+	inFlight   int
+	// closed when inFlight drops to 0, nil if nobody waits for it
+	inFlightIdle chan struct{}
+
 	// those are needed to implement ClusterRequestExecutor logic
 	isCluster                bool
 	clusterTopologySemaphore *Semaphore
@@ -152,6 +180,13 @@ func (re *RequestExecutor) GetURL() (string, error) {
 	return "", nil
 }
 
+// GetServerVersion returns version of the server as reported in the most
+// recent response. Returns "" if it's not known yet
+func (re *RequestExecutor) GetServerVersion() string {
+	v, _ := re.serverVersion.Load().(string)
+	return v
+}
+
 func (re *RequestExecutor) GetConventions() *DocumentConventions {
 	return re.conventions
 }
@@ -219,7 +254,13 @@ func RequestExecutorCreateForSingleNodeWithConfigurationUpdates(url string, data
 }
 
 func RequestExecutorCreateForSingleNodeWithoutConfigurationUpdates(url string, databaseName string, certificate *tls.Certificate, trustStore *x509.Certificate, conventions *DocumentConventions) *RequestExecutor {
-	initialUrls := requestExecutorValidateUrls([]string{url}, certificate)
+	initialUrls, err := requestExecutorValidateUrls([]string{url}, certificate)
+	if err != nil {
+		// DocumentStore.Initialize rejects invalid urls so we only get
+		// here when creating the executor directly. Sending requests to
+		// the url as given fails with a more specific error
+		initialUrls = []string{url}
+	}
 	executor := NewRequestExecutor(databaseName, certificate, trustStore, conventions, initialUrls)
 
 	topology := &Topology{
@@ -232,7 +273,7 @@ func RequestExecutorCreateForSingleNodeWithoutConfigurationUpdates(url string, d
 	// TODO: is Collections.singletonList in Java code subtly significant?
 	topology.Nodes = []*ServerNode{serverNode}
 
-	executor.setNodeSelector(NewNodeSelector(topology))
+	executor.setNodeSelector(executor.newNodeSelector(topology))
 	executor.TopologyEtag = -2
 	executor.disableTopologyUpdates = true
 	executor.disableClientConfigurationUpdates = true
@@ -243,7 +284,9 @@ func RequestExecutorCreateForSingleNodeWithoutConfigurationUpdates(url string, d
 func ClusterRequestExecutorCreateForSingleNode(url string, certificate *tls.Certificate, trustStore *x509.Certificate, conventions *DocumentConventions) *RequestExecutor {
 
 	initialUrls := []string{url}
-	url = requestExecutorValidateUrls(initialUrls, certificate)[0]
+	if cleanUrls, err := requestExecutorValidateUrls(initialUrls, certificate); err == nil {
+		url = cleanUrls[0]
+	}
 
 	if conventions == nil {
 		conventions = getDefaultConventions()
@@ -259,7 +302,7 @@ func ClusterRequestExecutorCreateForSingleNode(url string, certificate *tls.Cert
 		Nodes: []*ServerNode{serverNode},
 	}
 
-	nodeSelector := NewNodeSelector(topology)
+	nodeSelector := executor.newNodeSelector(topology)
 
 	executor.setNodeSelector(nodeSelector)
 	executor.TopologyEtag = -2
@@ -342,7 +385,9 @@ func (re *RequestExecutor) updateClientConfigurationAsync() *completableFuture {
 		}
 
 		re.conventions.UpdateFrom(result.Configuration)
+		re.etagsMu.Lock()
 		re.ClientConfigurationEtag = result.Etag
+		re.etagsMu.Unlock()
 
 		if re.isDisposed() {
 			return
@@ -473,11 +518,12 @@ func (re *RequestExecutor) clusterUpdateTopologyAsyncWithForceUpdate(node *Serve
 		}
 		newTopology := &Topology{
 			Nodes: nodes,
+			Etag:  int64(command.Response.Etag),
 		}
 
 		nodeSelector := re.getNodeSelector()
 		if nodeSelector == nil {
-			nodeSelector = NewNodeSelector(newTopology)
+			nodeSelector = re.newNodeSelector(newTopology)
 			re.setNodeSelector(nodeSelector)
 
 			if re.readBalanceBehavior == ReadBalanceBehaviorFastestNode {
@@ -490,6 +536,8 @@ func (re *RequestExecutor) clusterUpdateTopologyAsyncWithForceUpdate(node *Serve
 				nodeSelector.scheduleSpeedTest()
 			}
 		}
+		re.setTopologyEtag(nodeSelector.getTopology().Etag)
+		res = true
 	}
 
 	go f()
@@ -529,7 +577,7 @@ func (re *RequestExecutor) updateTopologyAsyncWithForceUpdate(node *ServerNode,
 		dbgPrintTopology(result)
 		nodeSelector := re.getNodeSelector()
 		if nodeSelector == nil {
-			nodeSelector = NewNodeSelector(result)
+			nodeSelector = re.newNodeSelector(result)
 			re.setNodeSelector(nodeSelector)
 			if re.readBalanceBehavior == ReadBalanceBehaviorFastestNode {
 				nodeSelector.scheduleSpeedTest()
@@ -540,7 +588,7 @@ func (re *RequestExecutor) updateTopologyAsyncWithForceUpdate(node *ServerNode,
 				nodeSelector.scheduleSpeedTest()
 			}
 		}
-		re.TopologyEtag = nodeSelector.getTopology().Etag
+		re.setTopologyEtag(nodeSelector.getTopology().Etag)
 		res = true
 	}
 
@@ -548,14 +596,38 @@ func (re *RequestExecutor) updateTopologyAsyncWithForceUpdate(node *ServerNode,
 	return future
 }
 
+// GetTopologyEtag returns etag of the topology known to the executor.
+// It's safe to call while the topology is updated in the background
+func (re *RequestExecutor) GetTopologyEtag() int64 {
+	re.etagsMu.Lock()
+	defer re.etagsMu.Unlock()
+	return re.TopologyEtag
+}
+
+// GetClientConfigurationEtag returns etag of the client configuration
+// known to the executor. It's safe to call while the client configuration
+// is updated in the background
+func (re *RequestExecutor) GetClientConfigurationEtag() int64 {
+	re.etagsMu.Lock()
+	defer re.etagsMu.Unlock()
+	return re.ClientConfigurationEtag
+}
+
+func (re *RequestExecutor) setTopologyEtag(etag int64) {
+	re.etagsMu.Lock()
+	re.TopologyEtag = etag
+	re.etagsMu.Unlock()
+}
+
 func (re *RequestExecutor) disposeAllFailedNodesTimers() {
 	f := func(key, val interface{}) bool {
 		status := val.(*NodeStatus)
 		status.Close()
+		// Note: re-assigning failedNodesTimers would race with concurrent access
+		re.failedNodesTimers.Delete(key)
 		return true
 	}
 	re.failedNodesTimers.Range(f)
-	re.failedNodesTimers = sync.Map{}
 }
 
 // sessionInfo can be nil
@@ -563,8 +635,11 @@ func (re *RequestExecutor) ExecuteCommand(command RavenCommand, sessionInfo *Ses
 	redbg("RequestExector.ExecuteCommand: %T\n", command)
 	if re.isDisposed() {
 		// can happen if e.g. we create BulkInsertOperation, close the store and then call Close() on BulkInsertOperation
-		return newIllegalStateError("RequestExecutor has been disposed")
+		return newStoreDisposedError("RequestExecutor has been disposed")
 	}
+	re.beginInFlightRequest()
+	defer re.endInFlightRequest()
+
 	topologyUpdate := re.firstTopologyUpdateFuture
 	isDone := topologyUpdate != nil && topologyUpdate.IsDone() && !topologyUpdate.IsCompletedExceptionally() && !topologyUpdate.isCancelled()
 	if isDone || re.disableTopologyUpdates {
@@ -587,9 +662,14 @@ func (re *RequestExecutor) chooseNodeForRequest(cmd RavenCommand, sessionInfo *S
 	case ReadBalanceBehaviorNone:
 		return re.getPreferredNode()
 	case ReadBalanceBehaviorRoundRobin:
-		sessionID := 0
+		// reads within a session stick to the same node. Other reads
+		// rotate through the nodes
+		var sessionID int
 		if sessionInfo != nil {
 			sessionID = sessionInfo.SessionID
+		} else {
+			n := atomic.AddUint32(&re.roundRobinCounter, 1) - 1
+			sessionID = int(n & math.MaxInt32)
 		}
 		return re.getNodeBySessionID(sessionID)
 	case ReadBalanceBehaviorFastestNode:
@@ -640,10 +720,18 @@ func (re *RequestExecutor) unlikelyExecute(command RavenCommand, topologyUpdate
 	return err
 }
 
+// topologyUpdateInterval returns how often the topology is re-fetched
+func (re *RequestExecutor) topologyUpdateInterval() time.Duration {
+	if interval := re.conventions.TopologyUpdateInterval; interval > 0 {
+		return interval
+	}
+	return time.Minute
+}
+
 func (re *RequestExecutor) updateTopologyCallback() {
 	last := re.lastReturnedResponse.Load().(time.Time)
 	dur := time.Since(last)
-	if dur < time.Minute {
+	if dur < re.topologyUpdateInterval() {
 		return
 	}
 
@@ -668,9 +756,12 @@ type tupleStringError struct {
 }
 
 func (re *RequestExecutor) firstTopologyUpdate(inputUrls []string) *completableFuture {
-	initialUrls := requestExecutorValidateUrls(inputUrls, re.Certificate)
-
 	future := newCompletableFuture()
+	initialUrls, err := requestExecutorValidateUrls(inputUrls, re.Certificate)
+	if err != nil {
+		future.completeWithError(err)
+		return future
+	}
 	var list []*tupleStringError
 	f := func() {
 		var err error
@@ -713,7 +804,7 @@ func (re *RequestExecutor) firstTopologyUpdate(inputUrls []string) *completableF
 			list = append(list, &tupleStringError{url, err})
 		}
 		topology := &Topology{
-			Etag: re.TopologyEtag,
+			Etag: re.GetTopologyEtag(),
 		}
 		topologyNodes := re.GetTopologyNodes()
 		if len(topologyNodes) == 0 {
@@ -726,7 +817,7 @@ func (re *RequestExecutor) firstTopologyUpdate(inputUrls []string) *completableF
 			}
 		}
 		topology.Nodes = topologyNodes
-		re.setNodeSelector(NewNodeSelector(topology))
+		re.setNodeSelector(re.newNodeSelector(topology))
 		if len(initialUrls) > 0 {
 			re.initializeUpdateTopologyTimer()
 			return
@@ -752,16 +843,11 @@ func (re *RequestExecutor) throwError(details string) error {
 	return err
 }
 
-func requestExecutorValidateUrls(initialUrls []string, certificate *tls.Certificate) []string {
-	// TODO: implement me
-	return initialUrls
-}
-
 func (re *RequestExecutor) initializeUpdateTopologyTimer() {
 	re.mu.Lock()
 	defer re.mu.Unlock()
 
-	if re.updateTopologyTimer != nil {
+	if re.updateTopologyTimer != nil || re.isDisposed() {
 		return
 	}
 	// TODO: make it into an infinite goroutine instead
@@ -773,23 +859,136 @@ func (re *RequestExecutor) initializeUpdateTopologyTimer() {
 		re.mu.Unlock()
 		re.initializeUpdateTopologyTimer()
 	}
-	re.updateTopologyTimer = time.AfterFunc(time.Minute, f)
+	re.updateTopologyTimer = time.AfterFunc(re.topologyUpdateInterval(), f)
+}
+
+// newNodeSelector creates a node selector for topology. With
+// ReadBalanceBehaviorFastestNode, nodes are pinged whenever the selector
+// enters the speed test phase
+func (re *RequestExecutor) newNodeSelector(topology *Topology) *NodeSelector {
+	res := NewNodeSelector(topology)
+	if re.readBalanceBehavior == ReadBalanceBehaviorFastestNode {
+		res.onSpeedTestPhase = func() {
+			re.runSpeedTest(res)
+		}
+	}
+	return res
+}
+
+// runSpeedTest pings all nodes of the topology, records their latency
+// and selects the fastest one, which ends the speed test phase
+func (re *RequestExecutor) runSpeedTest(nodeSelector *NodeSelector) {
+	if re.isDisposed() {
+		return
+	}
+	nodes := nodeSelector.getTopology().Nodes
+	if len(nodes) < 2 {
+		return
+	}
+
+	var wg sync.WaitGroup
+	for idx, node := range nodes {
+		re.NumberOfServerRequests.incrementAndGet()
+		wg.Add(1)
+		go func(nodeIndex int, node *ServerNode) {
+			defer wg.Done()
+			command := NewGetNodeInfoCommand()
+			request, err := re.createRequest(node, command)
+			if err != nil {
+				return
+			}
+			start := time.Now()
+			response, err := command.Send(re.getHTTPClientForCommand(node, command), request)
+			if err != nil {
+				return
+			}
+			_ = response.Body.Close()
+			if response.StatusCode >= 400 {
+				return
+			}
+			nodeSelector.recordLatency(nodeIndex, node, time.Since(start))
+		}(idx, node)
+	}
+	wg.Wait()
+	nodeSelector.selectFastestByLatency()
+}
+
+// GetFastestNode returns the node that reads are sent to when using
+// ReadBalanceBehaviorFastestNode. The fastest node is picked in the speed
+// test phase, which starts when the topology is updated, when the fastest
+// node fails and a minute after the fastest node was picked. Until it's
+// picked the first node is returned. If it's failing, the preferred node
+// is returned
+func (re *RequestExecutor) GetFastestNode() (*ServerNode, error) {
+	currentIndexAndNode, err := re.getFastestNode()
+	if err != nil {
+		return nil, err
+	}
+	return currentIndexAndNode.currentNode, nil
 }
 
 func isNetworkTimeoutError(err error) bool {
-	// TODO: implement me
-	// can test it by setting very low timeout in http.Client
-	return false
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// sendWithRetries sends request to node, repeating it according to
+// conventions.RetryPolicy if it fails with a transient network error
+func (re *RequestExecutor) sendWithRetries(node *ServerNode, command RavenCommand, request *http.Request) (*http.Response, error) {
+	client := re.getHTTPClientForCommand(node, command)
+	policy := re.conventions.RetryPolicy
+	for attempt := 1; ; attempt++ {
+		response, err := command.Send(client, request)
+		if err == nil || !policy.shouldRetry(attempt, command, request, err) {
+			return response, err
+		}
+		if request.GetBody != nil {
+			body, bodyErr := request.GetBody()
+			if bodyErr != nil {
+				return nil, err
+			}
+			request.Body = body
+		}
+		time.Sleep(policy.delay(attempt))
+	}
+}
+
+// getHTTPClientForCommand returns http client for sending command to a node,
+// with timeout set to command's timeout or, if not set, conventions.Timeout
+func (re *RequestExecutor) getHTTPClientForCommand(node *ServerNode, command RavenCommand) *http.Client {
+	client := re.getHTTPClientForNode(node)
+	timeout := command.GetBase().Timeout
+	if timeout == 0 {
+		timeout = re.conventions.Timeout
+	}
+	if timeout == 0 || timeout == client.Timeout {
+		return client
+	}
+	// a shallow copy shares the transport and its connections
+	clientCopy := *client
+	clientCopy.Timeout = timeout
+	return &clientCopy
 }
 
 // Execute executes a command on a given node
 // If nodeIndex is -1, we don't know the index
 func (re *RequestExecutor) Execute(chosenNode *ServerNode, nodeIndex int, command RavenCommand, shouldRetry bool, sessionInfo *SessionInfo) error {
 	// nodeIndex -1 is equivalent to Java's null
+	err := checkServerFeatures(command, re.GetServerVersion())
+	if err != nil {
+		return err
+	}
 	request, err := re.createRequest(chosenNode, command)
 	if err != nil {
 		return err
 	}
+	if sessionInfo != nil && sessionInfo.LastClusterTransactionIndex > 0 {
+		request.Header.Set(headersKnownRaftIndex, i64toa(sessionInfo.LastClusterTransactionIndex))
+	}
+	if sessionInfo != nil && sessionInfo.NoCaching {
+		command.GetBase().CanCache = false
+		command.GetBase().CanCacheAggressively = false
+	}
 	urlRef := request.URL.String()
 
 	cachedItem, cachedChangeVector, cachedValue := re.getFromCache(command, urlRef)
@@ -809,13 +1008,19 @@ func (re *RequestExecutor) Execute(chosenNode *ServerNode, nodeIndex int, comman
 		request.Header.Set(headersIfNoneMatch, "\""+*cachedChangeVector+"\"")
 	}
 
+	re.etagsMu.Lock()
+	topologyEtag, clientConfigurationEtag := re.TopologyEtag, re.ClientConfigurationEtag
+	re.etagsMu.Unlock()
+
 	if !re.disableClientConfigurationUpdates {
-		etag := `"` + i64toa(re.ClientConfigurationEtag) + `"`
+		etag := `"` + i64toa(clientConfigurationEtag) + `"`
 		request.Header.Set(headersClientConfigurationEtag, etag)
 	}
 
+	// if our topology is out of date, the server responds with
+	// Refresh-Topology header and we update it below
 	if !re.disableTopologyUpdates {
-		etag := `"` + i64toa(re.TopologyEtag) + `"`
+		etag := `"` + i64toa(topologyEtag) + `"`
 		request.Header.Set(headersTopologyEtag, etag)
 	}
 
@@ -825,12 +1030,16 @@ func (re *RequestExecutor) Execute(chosenNode *ServerNode, nodeIndex int, comman
 	if re.shouldExecuteOnAll(chosenNode, command) {
 		response, err = re.executeOnAllToFigureOutTheFastest(chosenNode, command)
 	} else {
-		response, err = command.Send(re.httpClient, request)
+		response, err = re.sendWithRetries(chosenNode, command, request)
 	}
 
 	if err != nil {
-		if !shouldRetry && isNetworkTimeoutError(err) {
-			return err
+		var timeoutErr error
+		if isNetworkTimeoutError(err) {
+			if !shouldRetry {
+				return err
+			}
+			timeoutErr = err
 		}
 		// Note: Java here re-throws if err is IOException and !shouldRetry
 		// but for us that propagates the wrong error to RequestExecutorTest_failsWhenServerIsOffline
@@ -841,12 +1050,15 @@ func (re *RequestExecutor) Execute(chosenNode *ServerNode, nodeIndex int, comman
 			return err
 		}
 		if !ok {
-			return re.throwFailedToContactAllNodes(command, request, err, nil)
+			return re.throwFailedToContactAllNodes(command, request, err, timeoutErr)
 		}
 		return nil
 	}
 
 	command.GetBase().StatusCode = response.StatusCode
+	if serverVersion := response.Header.Get(headersServerVersion); serverVersion != "" {
+		re.serverVersion.Store(serverVersion)
+	}
 
 	refreshTopology := httpExtensionsGetBooleanHeader(response, headersRefreshTopology)
 	refreshClientConfiguration := httpExtensionsGetBooleanHeader(response, headersRefreshClientConfiguration)
@@ -962,6 +1174,9 @@ func (re *RequestExecutor) throwFailedToContactAllNodes(command RavenCommand, re
 		message += "\nI was able to fetch " + re.topologyTakenFromNode.Database + " topology from " + re.topologyTakenFromNode.URL + ".\n" + "Fetched topology: " + nodesStr
 	}
 
+	if timeoutException != nil {
+		return newAllTopologyNodesDownError("%s", message, timeoutException)
+	}
 	return newAllTopologyNodesDownError("%s", message)
 }
 
@@ -1010,7 +1225,7 @@ func (re *RequestExecutor) executeOnAllToFigureOutTheFastest(chosenNode *ServerN
 			var response *http.Response
 			request, err := re.createRequest(node, command)
 			if err == nil {
-				response, err = command.Send(re.httpClient, request)
+				response, err = command.Send(re.getHTTPClientForCommand(node, command), request)
 				n := atomic.AddInt32(&fastestWasRecorded, 1)
 				if n == 1 {
 					// this is the first one, so record as fastest
@@ -1242,6 +1457,7 @@ func (re *RequestExecutor) addFailedResponseToCommand(chosenNode *ServerNode, co
 
 			failedNodes[chosenNode] = exceptionToUse
 		}
+		return
 	}
 
 	// this would be connections that didn't have response, such as "couldn't connect to remote server"
@@ -1261,6 +1477,44 @@ func (re *RequestExecutor) addFailedResponseToCommand(chosenNode *ServerNode, co
 	failedNodes[chosenNode] = exceptionToUse
 }
 
+func (re *RequestExecutor) beginInFlightRequest() {
+	re.inFlightMu.Lock()
+	re.inFlight++
+	re.inFlightMu.Unlock()
+}
+
+func (re *RequestExecutor) endInFlightRequest() {
+	re.inFlightMu.Lock()
+	defer re.inFlightMu.Unlock()
+	re.inFlight--
+	if re.inFlight == 0 && re.inFlightIdle != nil {
+		close(re.inFlightIdle)
+		re.inFlightIdle = nil
+	}
+}
+
+// waitForInFlightRequests waits until commands currently being executed
+// finish. Returns false if they didn't finish within timeout.
+func (re *RequestExecutor) waitForInFlightRequests(timeout time.Duration) bool {
+	re.inFlightMu.Lock()
+	if re.inFlight == 0 {
+		re.inFlightMu.Unlock()
+		return true
+	}
+	if re.inFlightIdle == nil {
+		re.inFlightIdle = make(chan struct{})
+	}
+	done := re.inFlightIdle
+	re.inFlightMu.Unlock()
+
+	select {
+	case <-done:
+		return true
+	case <-time.After(timeout):
+		return false
+	}
+}
+
 // Close should be called when deleting executor
 func (re *RequestExecutor) Close() {
 	if re.isDisposed() {
@@ -1283,24 +1537,53 @@ func (re *RequestExecutor) Close() {
 		re.updateTopologyTimer.Stop()
 		re.updateTopologyTimer = nil
 	}
+	if nodeSelector := re.getNodeSelector(); nodeSelector != nil {
+		nodeSelector.Close()
+	}
 	re.disposeAllFailedNodesTimers()
+
+	if re.httpClient != nil {
+		if transport, ok := re.httpClient.Transport.(*http.Transport); ok {
+			transport.CloseIdleConnections()
+		}
+	}
+}
+
+// newHTTPTransport returns a transport that keeps idle connections open
+// so that they can be re-used by subsequent requests to the same server.
+// Connections idle for longer than IdleConnTimeout are closed.
+func newHTTPTransport(tlsConfig *tls.Config) *http.Transport {
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+	}
+	return &http.Transport{
+		Proxy:                 http.ProxyFromEnvironment,
+		DialContext:           dialer.DialContext,
+		TLSClientConfig:       tlsConfig,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          100,
+		MaxIdleConnsPerHost:   32,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+	}
 }
 
 // TODO: create a different client if settings like compression
 // or certificate differ
 func (re *RequestExecutor) createClient() (*http.Client, error) {
-	client := &http.Client{
-		Timeout:   time.Second * 30,
-		Transport: http.DefaultTransport,
-	}
+	var tlsConfig *tls.Config
 	if re.Certificate != nil || re.TrustStore != nil {
-		tlsConfig, err := newTLSConfig(re.Certificate, re.TrustStore)
+		var err error
+		tlsConfig, err = newTLSConfig(re.Certificate, re.TrustStore)
 		if err != nil {
 			return nil, err
 		}
-		client.Transport = &http.Transport{
-			TLSClientConfig: tlsConfig,
-		}
+	}
+	client := &http.Client{
+		Timeout:   time.Second * 30,
+		Transport: newHTTPTransport(tlsConfig),
 	}
 	if HTTPClientPostProcessor != nil {
 		HTTPClientPostProcessor(client)
@@ -1308,6 +1591,25 @@ func (re *RequestExecutor) createClient() (*http.Client, error) {
 	return client, nil
 }
 
+// getHTTPClientForNode returns a client created by
+// conventions.HTTPClientFactory for a given node, if set, and the
+// executor's client otherwise
+func (re *RequestExecutor) getHTTPClientForNode(node *ServerNode) *http.Client {
+	factory := re.conventions.HTTPClientFactory
+	if factory == nil || node == nil {
+		return re.httpClient
+	}
+	if client, ok := re.nodeHTTPClients.Load(node.URL); ok {
+		return client.(*http.Client)
+	}
+	client := factory(node)
+	if client == nil {
+		return re.httpClient
+	}
+	actual, _ := re.nodeHTTPClients.LoadOrStore(node.URL, client)
+	return actual.(*http.Client)
+}
+
 func (re *RequestExecutor) getPreferredNode() (*CurrentIndexAndNode, error) {
 	ns, err := re.ensureNodeSelector()
 	if err != nil {
@@ -1336,6 +1638,10 @@ func (re *RequestExecutor) getFastestNode() (*CurrentIndexAndNode, error) {
 }
 
 func (re *RequestExecutor) ensureNodeSelector() (*NodeSelector, error) {
+	if re.isDisposed() {
+		return nil, newStoreDisposedError("RequestExecutor has been disposed")
+	}
+
 	re.mu.Lock()
 	firstTopologyUpdate := re.firstTopologyUpdateFuture
 	re.mu.Unlock()
@@ -1351,10 +1657,10 @@ func (re *RequestExecutor) ensureNodeSelector() (*NodeSelector, error) {
 	if nodeSelector == nil {
 		topology := &Topology{
 			Nodes: re.GetTopologyNodes(),
-			Etag:  re.TopologyEtag,
+			Etag:  re.GetTopologyEtag(),
 		}
 
-		nodeSelector = NewNodeSelector(topology)
+		nodeSelector = re.newNodeSelector(topology)
 		re.setNodeSelector(nodeSelector)
 	}
 	return nodeSelector, nil
@@ -1366,7 +1672,10 @@ type NodeStatus struct {
 	requestExecutor *RequestExecutor
 	nodeIndex       int
 	node            *ServerNode
-	timer           *time.Timer
+
+	// protects timer which is accessed from timer callback and Close()
+	mu    sync.Mutex
+	timer *time.Timer
 }
 
 func NewNodeStatus(requestExecutor *RequestExecutor, nodeIndex int, node *ServerNode) *NodeStatus {
@@ -1390,12 +1699,18 @@ func (s *NodeStatus) startTimer() {
 	f := func() {
 		s.timerCallback()
 	}
+	s.mu.Lock()
 	s.timer = time.AfterFunc(s.timerPeriod, f)
+	s.mu.Unlock()
 }
 
 func (s *NodeStatus) updateTimer() {
-	// TODO: not sure if Reset
-	s.timer.Reset(s.nextTimerPeriod())
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// timer is nil if we were closed
+	if s.timer != nil {
+		s.timer.Reset(s.nextTimerPeriod())
+	}
 }
 
 func (s *NodeStatus) timerCallback() {
@@ -1405,6 +1720,8 @@ func (s *NodeStatus) timerCallback() {
 }
 
 func (s *NodeStatus) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	if s.timer != nil {
 		s.timer.Stop()
 		s.timer = nil
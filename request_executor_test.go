@@ -0,0 +1,497 @@
+package ravendb
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newStatsServer returns a server that answers every request with empty
+// database statistics and counts opened connections
+func newStatsServer(nConnections *int32) *httptest.Server {
+	fn := func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{}`))
+	}
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(fn))
+	srv.Config.ConnState = func(conn net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			atomic.AddInt32(nConnections, 1)
+		}
+	}
+	srv.Start()
+	return srv
+}
+
+func executeStatsCommands(t testing.TB, re *RequestExecutor, n int) {
+	for i := 0; i < n; i++ {
+		cmd := NewGetStatisticsCommand("")
+		err := re.ExecuteCommand(cmd, nil)
+		require.NoError(t, err)
+	}
+}
+
+func TestRequestExecutorReusesConnections(t *testing.T) {
+	var nConnections int32
+	srv := newStatsServer(&nConnections)
+	defer srv.Close()
+
+	re := RequestExecutorCreateForSingleNodeWithoutConfigurationUpdates(srv.URL, "db", nil, nil, nil)
+	defer re.Close()
+
+	executeStatsCommands(t, re, 1000)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&nConnections))
+}
+
+func TestRequestExecutorHTTPClientFactory(t *testing.T) {
+	var nConnections int32
+	srv := newStatsServer(&nConnections)
+	defer srv.Close()
+
+	var nodes []*ServerNode
+	conventions := NewDocumentConventions()
+	conventions.HTTPClientFactory = func(node *ServerNode) *http.Client {
+		nodes = append(nodes, node)
+		return &http.Client{}
+	}
+	re := RequestExecutorCreateForSingleNodeWithoutConfigurationUpdates(srv.URL, "db", nil, nil, conventions)
+	defer re.Close()
+
+	executeStatsCommands(t, re, 10)
+	// the client is created once and re-used
+	require.Equal(t, 1, len(nodes))
+	assert.Equal(t, srv.URL, nodes[0].URL)
+}
+
+// newReadBalancingRequestExecutor returns an executor for a topology with n
+// fake nodes. Each node counts the requests it received in hits and
+// answers after delays[i], if given
+func newReadBalancingRequestExecutor(t *testing.T, behavior ReadBalanceBehavior, hits []int32, delays []time.Duration) *RequestExecutor {
+	topology := &Topology{Etag: -1}
+	for i := range hits {
+		i := i
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&hits[i], 1)
+			if i < len(delays) {
+				time.Sleep(delays[i])
+			}
+			_, _ = w.Write([]byte(`{}`))
+		}
+		srv := httptest.NewServer(http.HandlerFunc(fn))
+		t.Cleanup(srv.Close)
+		node := NewServerNode()
+		node.URL = srv.URL
+		node.Database = "db"
+		node.ClusterTag = string(rune('A' + i))
+		node.ServerRole = ServerNodeRoleMember
+		topology.Nodes = append(topology.Nodes, node)
+	}
+
+	conventions := NewDocumentConventions()
+	conventions.ReadBalanceBehavior = behavior
+	re := RequestExecutorCreateForSingleNodeWithoutConfigurationUpdates(topology.Nodes[0].URL, "db", nil, nil, conventions)
+	re.setNodeSelector(re.newNodeSelector(topology))
+	t.Cleanup(re.Close)
+	return re
+}
+
+func TestRequestExecutorRoundRobinReads(t *testing.T) {
+	hits := make([]int32, 3)
+	re := newReadBalancingRequestExecutor(t, ReadBalanceBehaviorRoundRobin, hits, nil)
+
+	executeStatsCommands(t, re, 9)
+	assert.Equal(t, []int32{3, 3, 3}, hits)
+
+	// reads within a session always go to the same node
+	for i := range hits {
+		hits[i] = 0
+	}
+	sessionInfo := &SessionInfo{SessionID: 4}
+	for i := 0; i < 3; i++ {
+		err := re.ExecuteCommand(NewGetStatisticsCommand(""), sessionInfo)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, []int32{0, 3, 0}, hits)
+
+	// writes always go to the first node
+	for i := range hits {
+		hits[i] = 0
+	}
+	for i := 0; i < 3; i++ {
+		cmd := NewPutDocumentCommand("users/1", nil, map[string]interface{}{})
+		err := re.ExecuteCommand(cmd, nil)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, []int32{3, 0, 0}, hits)
+}
+
+func TestRequestExecutorNoReadBalancing(t *testing.T) {
+	hits := make([]int32, 3)
+	re := newReadBalancingRequestExecutor(t, ReadBalanceBehaviorNone, hits, nil)
+
+	executeStatsCommands(t, re, 6)
+	assert.Equal(t, []int32{6, 0, 0}, hits)
+}
+
+func TestRequestExecutorFastestNode(t *testing.T) {
+	hits := make([]int32, 3)
+	delays := []time.Duration{80 * time.Millisecond, 5 * time.Millisecond, 40 * time.Millisecond}
+	re := newReadBalancingRequestExecutor(t, ReadBalanceBehaviorFastestNode, hits, delays)
+	nodes := re.GetTopologyNodes()
+
+	// not measured yet
+	node, err := re.GetFastestNode()
+	require.NoError(t, err)
+	assert.Equal(t, nodes[0], node)
+
+	// as after a topology update, nodes are pinged right away
+	re.getNodeSelector().scheduleSpeedTest()
+	deadline := time.Now().Add(time.Second * 5)
+	for re.inSpeedTestPhase() {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for the speed test")
+		}
+		time.Sleep(time.Millisecond * 10)
+	}
+	node, err = re.GetFastestNode()
+	require.NoError(t, err)
+	assert.Equal(t, nodes[1], node)
+	for i := range hits {
+		assert.Equal(t, int32(1), atomic.LoadInt32(&hits[i]))
+	}
+
+	// reads go to the fastest node, writes to the first one
+	executeStatsCommands(t, re, 2)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&hits[1]))
+	err = re.ExecuteCommand(NewPutDocumentCommand("users/1", nil, map[string]interface{}{}), nil)
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&hits[0]))
+}
+
+func TestNodeSelectorRollingLatency(t *testing.T) {
+	topology := &Topology{}
+	for _, tag := range []string{"A", "B"} {
+		topology.Nodes = append(topology.Nodes, &ServerNode{URL: "http://" + tag, ClusterTag: tag, ServerRole: ServerNodeRoleMember})
+	}
+	s := NewNodeSelector(topology)
+	defer s.Close()
+	assert.False(t, s.selectFastestByLatency())
+
+	s.recordLatency(0, topology.Nodes[0], 10*time.Millisecond)
+	s.recordLatency(1, topology.Nodes[1], 20*time.Millisecond)
+	assert.True(t, s.selectFastestByLatency())
+	assert.Equal(t, 0, s.getState().fastest)
+
+	// a single slow sample doesn't outweigh the history
+	s.recordLatency(0, topology.Nodes[0], 30*time.Millisecond)
+	assert.Equal(t, 16*time.Millisecond, s.getState().latencies[0])
+	assert.True(t, s.selectFastestByLatency())
+	assert.Equal(t, 0, s.getState().fastest)
+
+	s.recordLatency(0, topology.Nodes[0], 40*time.Millisecond)
+	assert.True(t, s.selectFastestByLatency())
+	assert.Equal(t, 1, s.getState().fastest)
+
+	// failing nodes are skipped
+	s.onFailedRequest(1)
+	assert.True(t, s.selectFastestByLatency())
+	assert.Equal(t, 0, s.getState().fastest)
+
+	// samples for a node that is no longer in the topology are ignored
+	s.recordLatency(1, &ServerNode{URL: "http://C"}, time.Millisecond)
+	assert.Equal(t, 20*time.Millisecond, s.getState().latencies[1])
+}
+
+// newTopologyServer returns a server for database "db" whose topology
+// has the first *nNodes of nodeURLs and etag equal to the number of nodes.
+// Like RavenDB, it responds with Refresh-Topology header if the client sent
+// an out of date Topology-Etag header. It counts topology requests
+func newTopologyServer(nodeURLs []string, nNodes *int32, nTopologyRequests *int32) *httptest.Server {
+	fn := func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.LoadInt32(nNodes)
+		switch r.URL.Path {
+		case "/topology":
+			atomic.AddInt32(nTopologyRequests, 1)
+			topology := &Topology{Etag: int64(n)}
+			for i, url := range nodeURLs[:n] {
+				node := NewServerNode()
+				node.URL = url
+				node.Database = "db"
+				node.ClusterTag = string(rune('A' + i))
+				node.ServerRole = ServerNodeRoleMember
+				topology.Nodes = append(topology.Nodes, node)
+			}
+			d, _ := jsonMarshal(topology)
+			_, _ = w.Write(d)
+			return
+		case "/cluster/topology":
+			atomic.AddInt32(nTopologyRequests, 1)
+			var members []string
+			for i, url := range nodeURLs[:n] {
+				members = append(members, `"`+string(rune('A'+i))+`":"`+url+`"`)
+			}
+			etag := strconv.Itoa(int(n))
+			_, _ = w.Write([]byte(`{"Leader":"A","NodeTag":"A","Topology":{"Members":{` + strings.Join(members, ",") + `},"Etag":` + etag + `},"Etag":` + etag + `}`))
+			return
+		}
+		etag := r.Header.Get(headersTopologyEtag)
+		if etag != "" && etag != `"`+strconv.Itoa(int(n))+`"` {
+			w.Header().Set(headersRefreshTopology, "true")
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}
+	return httptest.NewServer(http.HandlerFunc(fn))
+}
+
+func topologyNodeURLs(re *RequestExecutor) []string {
+	var res []string
+	for _, node := range re.GetTopologyNodes() {
+		res = append(res, node.URL)
+	}
+	return res
+}
+
+func TestRequestExecutorPicksUpTopologyChanges(t *testing.T) {
+	nodeURLs := []string{"", "http://127.0.0.1:1", "http://127.0.0.1:2"}
+	nNodes := int32(1)
+	var nTopologyRequests int32
+	srv := newTopologyServer(nodeURLs, &nNodes, &nTopologyRequests)
+	defer srv.Close()
+	nodeURLs[0] = srv.URL
+
+	conventions := NewDocumentConventions()
+	conventions.TopologyUpdateInterval = 50 * time.Millisecond
+	re := RequestExecutorCreate([]string{srv.URL}, "db", nil, nil, conventions)
+	defer re.Close()
+
+	executeStatsCommands(t, re, 1)
+	assert.Equal(t, nodeURLs[:1], topologyNodeURLs(re))
+
+	// a node was added and the server tells us about it in a response header
+	atomic.StoreInt32(&nNodes, 2)
+	executeStatsCommands(t, re, 1)
+	assert.Equal(t, nodeURLs[:2], topologyNodeURLs(re))
+
+	// without requests, the topology is re-fetched periodically
+	atomic.StoreInt32(&nNodes, 3)
+	deadline := time.Now().Add(5 * time.Second)
+	for len(re.GetTopologyNodes()) != 3 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.Equal(t, nodeURLs, topologyNodeURLs(re))
+}
+
+func TestRequestExecutorRefreshesTopologyWhenEtagIsOutOfDate(t *testing.T) {
+	nodeURLs := []string{"", "http://127.0.0.1:1"}
+	nNodes := int32(1)
+	var nTopologyRequests int32
+	srv := newTopologyServer(nodeURLs, &nNodes, &nTopologyRequests)
+	defer srv.Close()
+	nodeURLs[0] = srv.URL
+
+	re := RequestExecutorCreate([]string{srv.URL}, "db", nil, nil, nil)
+	defer re.Close()
+
+	// the topology is fetched only once while it's up to date
+	executeStatsCommands(t, re, 3)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&nTopologyRequests))
+	assert.Equal(t, int64(1), re.GetTopologyEtag())
+
+	atomic.StoreInt32(&nNodes, 2)
+	executeStatsCommands(t, re, 3)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&nTopologyRequests))
+	assert.Equal(t, int64(2), re.GetTopologyEtag())
+	assert.Equal(t, nodeURLs, topologyNodeURLs(re))
+}
+
+func TestClusterRequestExecutorRefreshesTopologyWhenEtagIsOutOfDate(t *testing.T) {
+	nodeURLs := []string{"", "http://127.0.0.1:1"}
+	nNodes := int32(1)
+	var nTopologyRequests int32
+	srv := newTopologyServer(nodeURLs, &nNodes, &nTopologyRequests)
+	defer srv.Close()
+	nodeURLs[0] = srv.URL
+
+	re := ClusterRequestExecutorCreate([]string{srv.URL}, nil, nil, nil)
+	defer re.Close()
+
+	execute := func(n int) {
+		for i := 0; i < n; i++ {
+			err := re.ExecuteCommand(NewGetBuildNumberCommand(), nil)
+			require.NoError(t, err)
+		}
+	}
+
+	execute(3)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&nTopologyRequests))
+	assert.Equal(t, int64(1), re.GetTopologyEtag())
+
+	atomic.StoreInt32(&nNodes, 2)
+	execute(3)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&nTopologyRequests))
+	assert.Equal(t, int64(2), re.GetTopologyEtag())
+	assert.Equal(t, 2, len(re.GetTopologyNodes()))
+}
+
+// newSlowServer returns a server that answers every request with empty
+// database statistics after delay
+func newSlowServer(delay time.Duration) *httptest.Server {
+	fn := func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(delay):
+			_, _ = w.Write([]byte(`{}`))
+		case <-r.Context().Done():
+		}
+	}
+	return httptest.NewServer(http.HandlerFunc(fn))
+}
+
+func TestRequestExecutorTimeout(t *testing.T) {
+	srv := newSlowServer(time.Millisecond * 300)
+	defer srv.Close()
+
+	conventions := NewDocumentConventions()
+	conventions.Timeout = time.Millisecond * 100
+	re := RequestExecutorCreateForSingleNodeWithoutConfigurationUpdates(srv.URL, "db", nil, nil, conventions)
+	defer re.Close()
+
+	timeStart := time.Now()
+	err := re.ExecuteCommand(NewGetStatisticsCommand(""), nil)
+	assert.True(t, time.Since(timeStart) < time.Millisecond*250)
+	require.Error(t, err)
+	_, ok := err.(*AllTopologyNodesDownError)
+	assert.True(t, ok, "expected *AllTopologyNodesDownError, got %T", err)
+	var netErr net.Error
+	require.True(t, errors.As(err, &netErr))
+	assert.True(t, netErr.Timeout())
+
+	// per-command timeout overrides the conventions
+	cmd := NewGetStatisticsCommand("")
+	cmd.Timeout = time.Second * 5
+	err = re.ExecuteCommand(cmd, nil)
+	assert.NoError(t, err)
+}
+
+func TestRequestExecutorReturnsServerError(t *testing.T) {
+	fn := func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte(`{"Type":"Raven.Client.Exceptions.Database.DatabaseDisabledException","Message":"The database db has been disabled.","Error":"The database db has been disabled."}`))
+	}
+	srv := httptest.NewServer(http.HandlerFunc(fn))
+	defer srv.Close()
+
+	re := RequestExecutorCreateForSingleNodeWithoutConfigurationUpdates(srv.URL, "db", nil, nil, nil)
+	defer re.Close()
+
+	err := re.ExecuteCommand(NewGetStatisticsCommand(""), nil)
+	_, ok := err.(*DatabaseDisabledError)
+	assert.True(t, ok, "expected *DatabaseDisabledError, got %T (%v)", err, err)
+}
+
+// flakyTransport fails the first nFailures requests with a connection error
+type flakyTransport struct {
+	nFailures int32
+	nAttempts int32
+}
+
+func (t *flakyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	n := atomic.AddInt32(&t.nAttempts, 1)
+	if n <= t.nFailures {
+		return nil, &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}
+	}
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func newFlakyRequestExecutor(url string, transport *flakyTransport) *RequestExecutor {
+	conventions := NewDocumentConventions()
+	conventions.HTTPClientFactory = func(*ServerNode) *http.Client {
+		return &http.Client{Transport: transport}
+	}
+	conventions.RetryPolicy = NewRetryPolicy(3, time.Millisecond)
+	return RequestExecutorCreateForSingleNodeWithoutConfigurationUpdates(url, "db", nil, nil, conventions)
+}
+
+func TestRequestExecutorRetriesTransientErrors(t *testing.T) {
+	var nConnections int32
+	srv := newStatsServer(&nConnections)
+	defer srv.Close()
+
+	transport := &flakyTransport{nFailures: 2}
+	re := newFlakyRequestExecutor(srv.URL, transport)
+	defer re.Close()
+
+	err := re.ExecuteCommand(NewGetStatisticsCommand(""), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&transport.nAttempts))
+
+	// gives up after MaxAttempts
+	transport = &flakyTransport{nFailures: 3}
+	re2 := newFlakyRequestExecutor(srv.URL, transport)
+	defer re2.Close()
+	err = re2.ExecuteCommand(NewGetStatisticsCommand(""), nil)
+	assert.Error(t, err)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&transport.nAttempts))
+
+	// requests that are not idempotent are not retried
+	transport = &flakyTransport{nFailures: 1}
+	re3 := newFlakyRequestExecutor(srv.URL, transport)
+	defer re3.Close()
+	err = re3.ExecuteCommand(NewPutDocumentCommand("users/1", nil, map[string]interface{}{}), nil)
+	assert.Error(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&transport.nAttempts))
+}
+
+func TestRetryPolicyDelay(t *testing.T) {
+	p := &RetryPolicy{MaxAttempts: 5, BaseDelay: time.Millisecond * 100, MaxDelay: time.Millisecond * 300}
+	assert.Equal(t, time.Millisecond*100, p.delay(1))
+	assert.Equal(t, time.Millisecond*200, p.delay(2))
+	assert.Equal(t, time.Millisecond*300, p.delay(3))
+	assert.Equal(t, time.Millisecond*300, p.delay(10))
+
+	p.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		d := p.delay(2)
+		assert.True(t, d > time.Millisecond*100 && d <= time.Millisecond*200, "delay: %s", d)
+	}
+}
+
+// measures throughput of sequential requests with and without re-using
+// connections
+func BenchmarkRequestExecutorSequentialGets(b *testing.B) {
+	var nConnections int32
+	srv := newStatsServer(&nConnections)
+	defer srv.Close()
+
+	b.Run("KeepAlive", func(b *testing.B) {
+		re := RequestExecutorCreateForSingleNodeWithoutConfigurationUpdates(srv.URL, "db", nil, nil, nil)
+		defer re.Close()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			executeStatsCommands(b, re, 1000)
+		}
+	})
+
+	b.Run("NoKeepAlive", func(b *testing.B) {
+		conventions := NewDocumentConventions()
+		conventions.HTTPClientFactory = func(*ServerNode) *http.Client {
+			return &http.Client{
+				Transport: &http.Transport{DisableKeepAlives: true},
+			}
+		}
+		re := RequestExecutorCreateForSingleNodeWithoutConfigurationUpdates(srv.URL, "db", nil, nil, conventions)
+		defer re.Close()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			executeStatsCommands(b, re, 1000)
+		}
+	})
+}
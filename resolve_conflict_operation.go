@@ -0,0 +1,41 @@
+package ravendb
+
+var (
+	_ IOperation = &ResolveConflictOperation{}
+)
+
+// ResolveConflictOperation resolves a conflict on a document by storing
+// a given version of it. changeVector must be the change vector of one of
+// the conflicting versions, as returned by GetConflictsCommand, otherwise
+// the server returns ConcurrencyError
+type ResolveConflictOperation struct {
+	Command *PutDocumentCommand
+
+	id           string
+	document     map[string]interface{}
+	changeVector string
+}
+
+// NewResolveConflictOperation returns new ResolveConflictOperation
+func NewResolveConflictOperation(id string, document map[string]interface{}, changeVector string) (*ResolveConflictOperation, error) {
+	if id == "" {
+		return nil, newIllegalArgumentError("id cannot be empty")
+	}
+	if document == nil {
+		return nil, newIllegalArgumentError("document cannot be nil")
+	}
+	if changeVector == "" {
+		return nil, newIllegalArgumentError("changeVector cannot be empty")
+	}
+	return &ResolveConflictOperation{
+		id:           id,
+		document:     document,
+		changeVector: changeVector,
+	}, nil
+}
+
+func (o *ResolveConflictOperation) GetCommand(store *DocumentStore, conventions *DocumentConventions, cache *httpCache) (RavenCommand, error) {
+	changeVector := o.changeVector
+	o.Command = NewPutDocumentCommand(o.id, &changeVector, o.document)
+	return o.Command, nil
+}
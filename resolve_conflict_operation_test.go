@@ -0,0 +1,110 @@
+package ravendb
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const docConflictsJSON = `{
+	"Id": "users/1",
+	"LargestEtag": 12,
+	"Results": [
+		{"LastModified": "2020-01-01T10:00:00.0000000Z", "ChangeVector": "A:1-aaa", "Doc": {"name": "John", "@metadata": {"@collection": "Users"}}},
+		{"LastModified": "2020-01-01T10:00:01.0000000Z", "ChangeVector": "B:1-bbb", "Doc": {"name": "Jane", "@metadata": {"@collection": "Users"}}}
+	]
+}`
+
+func TestResolveConflict(t *testing.T) {
+	var putBody map[string]interface{}
+	var putIfMatch string
+	fn := func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/databases/db/replication/conflicts":
+			assert.Equal(t, "users/1", r.URL.Query().Get("docId"))
+			_, _ = w.Write([]byte(docConflictsJSON))
+		case r.Method == http.MethodPut && r.URL.Path == "/databases/db/docs":
+			putIfMatch = r.Header.Get("If-Match")
+			d, _ := ioutil.ReadAll(r.Body)
+			_ = json.Unmarshal(d, &putBody)
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"Id": "users/1", "ChangeVector": "A:2-aaa, B:1-bbb"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+	store := newFakeServerStore(t, fn)
+
+	cmd := NewGetConflictsCommand("users/1")
+	err := store.GetRequestExecutor("").ExecuteCommand(cmd, nil)
+	require.NoError(t, err)
+	res := cmd.Result
+	assert.Equal(t, "users/1", res.ID)
+	assert.Equal(t, int64(12), res.LargestEtag)
+	require.Equal(t, 2, len(res.Results))
+	assert.Equal(t, "B:1-bbb", res.Results[1].ChangeVector)
+	assert.Equal(t, "Jane", res.Results[1].Doc["name"])
+
+	_, err = NewResolveConflictOperation("users/1", res.Results[1].Doc, "")
+	assert.Error(t, err)
+
+	winner := res.Results[1]
+	op, err := NewResolveConflictOperation("users/1", winner.Doc, winner.ChangeVector)
+	require.NoError(t, err)
+	err = store.Operations().Send(op, nil)
+	require.NoError(t, err)
+	assert.Equal(t, `"B:1-bbb"`, putIfMatch)
+	assert.Equal(t, "Jane", putBody["name"])
+	assert.Equal(t, "A:2-aaa, B:1-bbb", *op.Command.Result.ChangeVector)
+}
+
+func TestSessionLoadReturnsConflictingVersions(t *testing.T) {
+	fn := func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/databases/db/docs":
+			w.WriteHeader(http.StatusConflict)
+			_, _ = w.Write([]byte(`{"Type": "Raven.Client.Exceptions.Documents.DocumentConflictException", "Message": "Conflict detected on users/1", "Error": "Conflict detected on users/1", "DocId": "users/1", "LargestEtag": 12}`))
+		case "/databases/db/replication/conflicts":
+			_, _ = w.Write([]byte(docConflictsJSON))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+	store := newFakeServerStore(t, fn)
+	session, err := store.OpenSession("")
+	require.NoError(t, err)
+	defer session.Close()
+
+	var user *User
+	err = session.Load(&user, "users/1")
+	conflictErr, ok := err.(*DocumentConflictError)
+	require.True(t, ok, "expected *DocumentConflictError, got %T (%v)", err, err)
+	assert.Equal(t, "users/1", conflictErr.DocID)
+	assert.Equal(t, int64(12), conflictErr.LargestEtag)
+	require.Equal(t, 2, len(conflictErr.Conflicts))
+	assert.Equal(t, "A:1-aaa", conflictErr.Conflicts[0].ChangeVector)
+	assert.Equal(t, "Jane", conflictErr.Conflicts[1].Doc["name"])
+	// loading the document and its conflicts
+	assert.Equal(t, 2, session.GetNumberOfRequests())
+}
+
+func TestModifyConflictSolverCommandSetResponse(t *testing.T) {
+	scripts := map[string]*ScriptResolver{
+		"Users": {Script: "return docs[0];"},
+	}
+	op, err := NewModifyConflictSolverOperation("my db", scripts, true)
+	require.NoError(t, err)
+	cmd, err := op.GetCommand(nil)
+	require.NoError(t, err)
+
+	js := `{"Key": "my db", "RaftCommandIndex": 7, "Solver": {"ResolveByCollection": {"Users": {"Script": "return docs[0];"}}, "ResolveToLatest": true}}`
+	require.NoError(t, cmd.SetResponse([]byte(js), false))
+	res := op.Command.Result
+	assert.Equal(t, int64(7), res.RaftCommandIndex)
+	assert.True(t, res.Solver.ResolveToLatest)
+	assert.Equal(t, "return docs[0];", res.Solver.ResolveByCollection["Users"].Script)
+}
@@ -3,19 +3,25 @@ package ravendb
 import "time"
 
 // ResponseTimeInformation describes timing information of server requests
+// made when executing lazy operations
 type ResponseTimeInformation struct {
-	totalServerDuration time.Duration
-	totalClientDuration time.Duration
+	// TotalServerDuration is a sum of durations reported by the server
+	// for each request
+	TotalServerDuration time.Duration
+	// TotalClientDuration is how long it took to execute all requests,
+	// as measured by the client
+	TotalClientDuration time.Duration
 
-	durationBreakdown []ResponseTimeItem
+	// DurationBreakdown has server-reported duration of each request
+	DurationBreakdown []ResponseTimeItem
 }
 
 func (i *ResponseTimeInformation) computeServerTotal() {
 	var total time.Duration
-	for _, rti := range i.durationBreakdown {
+	for _, rti := range i.DurationBreakdown {
 		total += rti.Duration
 	}
-	i.totalServerDuration = total
+	i.TotalServerDuration = total
 }
 
 // ResponseTimeItem represents a duration for executing a given url
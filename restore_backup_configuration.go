@@ -0,0 +1,18 @@
+package ravendb
+
+// RestoreBackupConfiguration describes restoring a database from a backup
+// located on a local disk of the server
+type RestoreBackupConfiguration struct {
+	// DatabaseName is the name of the database created by the restore
+	DatabaseName string `json:"DatabaseName"`
+	// BackupLocation is a directory on the server with backup files
+	BackupLocation string `json:"BackupLocation"`
+	// LastFileNameToRestore optionally limits restore of incremental
+	// backups up to (and including) a given file
+	LastFileNameToRestore string `json:"LastFileNameToRestore,omitempty"`
+	// DataDirectory optionally overrides the directory of the restored database
+	DataDirectory       string `json:"DataDirectory,omitempty"`
+	EncryptionKey       string `json:"EncryptionKey,omitempty"`
+	DisableOngoingTasks bool   `json:"DisableOngoingTasks"`
+	SkipIndexes         bool   `json:"SkipIndexes"`
+}
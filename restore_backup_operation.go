@@ -0,0 +1,86 @@
+package ravendb
+
+import (
+	"net/http"
+)
+
+var (
+	_ IServerOperation = &RestoreBackupOperation{}
+)
+
+// RestoreBackupOperation restores a backup into a new database.
+// Use ServerOperationExecutor.SendAsync to wait for the restore to finish.
+type RestoreBackupOperation struct {
+	configuration *RestoreBackupConfiguration
+
+	Command *RestoreBackupCommand
+}
+
+func NewRestoreBackupOperation(configuration *RestoreBackupConfiguration) *RestoreBackupOperation {
+	return &RestoreBackupOperation{
+		configuration: configuration,
+	}
+}
+
+func (o *RestoreBackupOperation) GetCommand(conventions *DocumentConventions) (RavenCommand, error) {
+	var err error
+	o.Command, err = NewRestoreBackupCommand(o.configuration)
+	if err != nil {
+		return nil, err
+	}
+	return o.Command, nil
+}
+
+var _ RavenCommand = &RestoreBackupCommand{}
+
+type RestoreBackupCommand struct {
+	RavenCommandBase
+
+	configuration *RestoreBackupConfiguration
+
+	Result *OperationIDResult
+}
+
+func NewRestoreBackupCommand(configuration *RestoreBackupConfiguration) (*RestoreBackupCommand, error) {
+	if configuration == nil {
+		return nil, newIllegalArgumentError("Configuration cannot be null")
+	}
+	if configuration.DatabaseName == "" {
+		return nil, newIllegalArgumentError("DatabaseName cannot be empty")
+	}
+	if configuration.BackupLocation == "" {
+		return nil, newIllegalArgumentError("BackupLocation cannot be empty")
+	}
+	cmd := &RestoreBackupCommand{
+		RavenCommandBase: NewRavenCommandBase(),
+
+		configuration: configuration,
+	}
+	return cmd, nil
+}
+
+func (c *RestoreBackupCommand) CreateRequest(node *ServerNode) (*http.Request, error) {
+	url := node.URL + "/admin/restore/database"
+
+	// the server picks restore source based on Type
+	body := struct {
+		*RestoreBackupConfiguration
+		Type string `json:"Type"`
+	}{
+		RestoreBackupConfiguration: c.configuration,
+		Type:                       "Local",
+	}
+	d, err := jsonMarshal(body)
+	if err != nil {
+		return nil, err
+	}
+	return NewHttpPost(url, d)
+}
+
+func (c *RestoreBackupCommand) SetResponse(response []byte, fromCache bool) error {
+	if len(response) == 0 {
+		return throwInvalidResponse()
+	}
+
+	return jsonUnmarshal(response, &c.Result)
+}
@@ -0,0 +1,85 @@
+package ravendb
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+// RetryPolicy describes how RequestExecutor retries a request that failed
+// with a transient network error before giving up on a node (and failing
+// over to other nodes in the cluster).
+// Only idempotent requests are retried: connection errors are retried for
+// read requests and GET/HEAD requests, timeouts only for read requests
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts, including the first one
+	MaxAttempts int
+	// BaseDelay is the delay before the first retry. It doubles with
+	// every subsequent retry
+	BaseDelay time.Duration
+	// MaxDelay, if > 0, limits the delay between attempts
+	MaxDelay time.Duration
+	// Jitter is a fraction (from 0 to 1) of the delay that is randomized,
+	// so that many clients don't retry at the same time
+	Jitter float64
+}
+
+// NewRetryPolicy returns a policy that makes up to maxAttempts attempts
+// with exponential backoff starting at baseDelay
+func NewRetryPolicy(maxAttempts int, baseDelay time.Duration) *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: maxAttempts,
+		BaseDelay:   baseDelay,
+		Jitter:      0.2,
+	}
+}
+
+// shouldRetry returns true if attempt number attempt (counting from 1)
+// failed with err and should be retried
+func (p *RetryPolicy) shouldRetry(attempt int, command RavenCommand, request *http.Request, err error) bool {
+	if p == nil || attempt >= p.MaxAttempts {
+		return false
+	}
+	isReadRequest := command.GetBase().IsReadRequest
+	if isNetworkTimeoutError(err) {
+		return isReadRequest
+	}
+	if !isTransientNetworkError(err) {
+		return false
+	}
+	return isReadRequest || request.Method == http.MethodGet || request.Method == http.MethodHead
+}
+
+// delay returns how long to wait after attempt number attempt failed
+func (p *RetryPolicy) delay(attempt int) time.Duration {
+	d := p.BaseDelay
+	for i := 1; i < attempt && (p.MaxDelay <= 0 || d < p.MaxDelay); i++ {
+		d *= 2
+	}
+	if p.MaxDelay > 0 && d > p.MaxDelay {
+		d = p.MaxDelay
+	}
+	if p.Jitter > 0 {
+		d -= time.Duration(rand.Float64() * p.Jitter * float64(d))
+	}
+	return d
+}
+
+// isTransientNetworkError returns true if err is a connection error that
+// might not happen again if the request is repeated
+func isTransientNetworkError(err error) bool {
+	if errors.Is(err, context.Canceled) {
+		return false
+	}
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) {
+		return true
+	}
+	var opErr *net.OpError
+	return errors.As(err, &opErr)
+}
@@ -1,11 +1,25 @@
 package ravendb
 
+import "strings"
+
 type ServerOperationExecutor struct {
+	store           *DocumentStore
 	requestExecutor *ClusterRequestExecutor
 }
 
+// databaseTopologyOperation is implemented by server operations that change
+// topology of a database. Send validates them against current topology of
+// the database and refreshes the store's cached topology afterwards.
+type databaseTopologyOperation interface {
+	IServerOperation
+	getDatabaseName() string
+	validateTopology(topology *DatabaseTopology) error
+}
+
 func NewServerOperationExecutor(store *DocumentStore) *ServerOperationExecutor {
-	res := &ServerOperationExecutor{}
+	res := &ServerOperationExecutor{
+		store: store,
+	}
 	urls := store.GetUrls()
 	cert := store.Certificate
 	trustStore := store.TrustStore
@@ -23,11 +37,55 @@ func NewServerOperationExecutor(store *DocumentStore) *ServerOperationExecutor {
 }
 
 func (e *ServerOperationExecutor) Send(operation IServerOperation) error {
+	topologyOperation, isTopologyOperation := operation.(databaseTopologyOperation)
+	if isTopologyOperation {
+		if err := e.validateTopologyOperation(topologyOperation); err != nil {
+			return err
+		}
+	}
 	command, err := operation.GetCommand(e.requestExecutor.GetConventions())
 	if err != nil {
 		return err
 	}
-	return e.requestExecutor.ExecuteCommand(command, nil)
+	if err = e.requestExecutor.ExecuteCommand(command, nil); err != nil {
+		return err
+	}
+	if isTopologyOperation {
+		e.refreshDatabaseTopology(topologyOperation.getDatabaseName())
+	}
+	return nil
+}
+
+func (e *ServerOperationExecutor) validateTopologyOperation(operation databaseTopologyOperation) error {
+	database := operation.getDatabaseName()
+	recordOperation := NewGetDatabaseRecordOperation(database)
+	if err := e.Send(recordOperation); err != nil {
+		return err
+	}
+	record := recordOperation.Command.Result
+	if record == nil {
+		return newDatabaseDoesNotExistError("Database '%s' does not exist", database)
+	}
+	return operation.validateTopology(record.DatabaseTopology)
+}
+
+// refreshDatabaseTopology updates topology of the database cached by the
+// store so that following requests use the new topology
+func (e *ServerOperationExecutor) refreshDatabaseTopology(database string) {
+	if e.store == nil || e.store.GetConventions().IsDisableTopologyUpdates() {
+		return
+	}
+	e.store.mu.Lock()
+	re := e.store.requestsExecutors[strings.ToLower(database)]
+	e.store.mu.Unlock()
+	if re == nil {
+		return
+	}
+	node, err := re.getPreferredNode()
+	if err != nil {
+		return
+	}
+	<-re.updateTopologyAsyncWithForceUpdate(node.currentNode, 0, true)
 }
 
 func (e *ServerOperationExecutor) SendAsync(operation IServerOperation) (*Operation, error) {
@@ -36,10 +94,16 @@ func (e *ServerOperationExecutor) SendAsync(operation IServerOperation) (*Operat
 	if err != nil {
 		return nil, err
 	}
+	if err = assertCommandReturnsOperationID(command); err != nil {
+		return nil, err
+	}
 	if err = requestExecutor.ExecuteCommand(command, nil); err != nil {
 		return nil, err
 	}
-	result := getCommandOperationIDResult(command)
+	result, err := getCommandOperationIDResult(command)
+	if err != nil {
+		return nil, err
+	}
 	return NewServerWideOperation(requestExecutor, requestExecutor.GetConventions(), result.OperationID), nil
 }
 
@@ -0,0 +1,109 @@
+package ravendb
+
+import (
+	"strconv"
+	"strings"
+)
+
+// serverFeature describes a feature that is only supported by servers
+// starting with a given version
+type serverFeature struct {
+	name       string
+	minVersion string
+}
+
+var (
+	serverFeatureCounters            = &serverFeature{name: "counters", minVersion: "4.1"}
+	serverFeatureClusterTransactions = &serverFeature{name: "cluster transactions", minVersion: "4.1"}
+	serverFeatureTimeSeries          = &serverFeature{name: "time series", minVersion: "5.0"}
+)
+
+// serverFeatureCommand is implemented by commands that use features not
+// supported by all server versions. RequestExecutor uses it to fail early
+// when it knows the server is too old
+type serverFeatureCommand interface {
+	requiredServerFeatures() []*serverFeature
+}
+
+// UnsupportedServerFeatureError is returned when a command uses a feature
+// that the server doesn't support
+type UnsupportedServerFeatureError struct {
+	errorBase
+
+	Feature         string
+	RequiredVersion string
+	ServerVersion   string
+}
+
+func newUnsupportedServerFeatureError(feature *serverFeature, serverVersion string) *UnsupportedServerFeatureError {
+	res := &UnsupportedServerFeatureError{
+		Feature:         feature.name,
+		RequiredVersion: feature.minVersion,
+		ServerVersion:   serverVersion,
+	}
+	res.setErrorf("Server version %s doesn't support %s, version %s or newer is required", serverVersion, feature.name, feature.minVersion)
+	return res
+}
+
+// parseServerVersion returns numeric parts of a version like "5.4.107".
+// Parsing stops at the first part that doesn't start with a number and
+// suffixes are ignored so e.g. "5.2-nightly" is [5, 2]
+func parseServerVersion(v string) []int {
+	var res []int
+	for _, part := range strings.Split(v, ".") {
+		digits := part
+		if end := strings.IndexFunc(part, func(r rune) bool { return r < '0' || r > '9' }); end >= 0 {
+			digits = part[:end]
+		}
+		n, err := strconv.Atoi(digits)
+		if err != nil {
+			break
+		}
+		res = append(res, n)
+		if digits != part {
+			break
+		}
+	}
+	return res
+}
+
+// compareServerVersions returns -1, 0 or 1 if v1 is older, the same or newer
+// than v2. Missing parts are treated as 0
+func compareServerVersions(v1, v2 string) int {
+	p1 := parseServerVersion(v1)
+	p2 := parseServerVersion(v2)
+	for i := 0; i < len(p1) || i < len(p2); i++ {
+		var n1, n2 int
+		if i < len(p1) {
+			n1 = p1[i]
+		}
+		if i < len(p2) {
+			n2 = p2[i]
+		}
+		if n1 < n2 {
+			return -1
+		}
+		if n1 > n2 {
+			return 1
+		}
+	}
+	return 0
+}
+
+// checkServerFeatures returns an error if serverVersion is known and is
+// older than required by one of the features used by command
+func checkServerFeatures(command RavenCommand, serverVersion string) error {
+	if serverVersion == "" || len(parseServerVersion(serverVersion)) == 0 {
+		return nil
+	}
+	cmd, ok := command.(serverFeatureCommand)
+	if !ok {
+		return nil
+	}
+	for _, feature := range cmd.requiredServerFeatures() {
+		if compareServerVersions(serverVersion, feature.minVersion) < 0 {
+			return newUnsupportedServerFeatureError(feature, serverVersion)
+		}
+	}
+	return nil
+}
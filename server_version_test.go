@@ -0,0 +1,86 @@
+package ravendb
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompareServerVersions(t *testing.T) {
+	tests := []struct {
+		v1  string
+		v2  string
+		exp int
+	}{
+		{"4.0.11", "4.1", -1},
+		{"4.1", "4.1.0", 0},
+		{"5.4.107", "5.0", 1},
+		{"10.0", "9.9", 1},
+		{"5.2-nightly", "5.2", 0},
+		{"nightly", "4.0", -1},
+	}
+	for _, test := range tests {
+		got := compareServerVersions(test.v1, test.v2)
+		assert.Equal(t, test.exp, got, "compareServerVersions(%s, %s)", test.v1, test.v2)
+	}
+}
+
+// newVersionTestHandler reports the server as a given version
+// and answers documents and build number requests
+func newVersionTestHandler(version string, paths *[]string) http.HandlerFunc {
+	fn := func(w http.ResponseWriter, r *http.Request) {
+		*paths = append(*paths, r.URL.Path)
+		w.Header().Set("Raven-Server-Version", version)
+		switch r.URL.Path {
+		case "/build/version":
+			_, _ = w.Write([]byte(`{"BuildVersion":40,"ProductVersion":"4.0","CommitHash":"a1b2c3","FullVersion":"` + version + `"}`))
+		case "/databases/db/docs":
+			_, _ = w.Write([]byte(`{"Results": [{"name": "John", "@metadata": {"@id": "users/1", "@collection": "Users", "@change-vector": "A:1"}}], "Includes": {}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+	return fn
+}
+
+func TestUnsupportedServerFeature(t *testing.T) {
+	var paths []string
+	store := newFakeServerStore(t, newVersionTestHandler("4.0.11", &paths))
+
+	version, err := store.GetServerVersion()
+	require.NoError(t, err)
+	assert.Equal(t, "4.0.11", version)
+	assert.Equal(t, []string{"/build/version"}, paths)
+
+	session, err := store.OpenSession("")
+	require.NoError(t, err)
+	defer session.Close()
+
+	var user *User
+	err = session.Include("Friend").IncludeCounters("likes").Load(&user, "users/1")
+	featureErr, ok := err.(*UnsupportedServerFeatureError)
+	require.True(t, ok, "expected *UnsupportedServerFeatureError, got %T (%v)", err, err)
+	assert.Equal(t, "counters", featureErr.Feature)
+	assert.Equal(t, "4.1", featureErr.RequiredVersion)
+	assert.Equal(t, "4.0.11", featureErr.ServerVersion)
+	// the request was not sent
+	assert.Equal(t, 1, len(paths))
+
+	err = session.Load(&user, "users/1")
+	require.NoError(t, err)
+	assert.Equal(t, 2, len(paths))
+
+	clusterSession, err := store.OpenSessionWithOptions(&SessionOptions{
+		TransactionMode: TransactionModeClusterWide,
+	})
+	require.NoError(t, err)
+	defer clusterSession.Close()
+	require.NoError(t, clusterSession.StoreWithID(&User{}, "users/2"))
+	err = clusterSession.SaveChanges()
+	featureErr, ok = err.(*UnsupportedServerFeatureError)
+	require.True(t, ok, "expected *UnsupportedServerFeatureError, got %T (%v)", err, err)
+	assert.Equal(t, "cluster transactions", featureErr.Feature)
+	assert.Equal(t, 2, len(paths))
+}
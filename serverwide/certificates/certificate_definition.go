@@ -1,5 +1,7 @@
 package certificates
 
+import "fmt"
+
 type SecurityClearance int
 
 const (
@@ -24,3 +26,47 @@ func (da DatabaseAccess) String() string {
 func (sa SecurityClearance) String() string {
 	return []string{"ClusterAdmin", "ClusterNode", "Operator", "ValidUser"}[sa]
 }
+
+func (da DatabaseAccess) MarshalText() ([]byte, error) {
+	if da < ReadWrite || da > Read {
+		return nil, fmt.Errorf("unknown database access %d", int(da))
+	}
+	return []byte(da.String()), nil
+}
+
+func (da *DatabaseAccess) UnmarshalText(text []byte) error {
+	for _, v := range []DatabaseAccess{ReadWrite, Admin, Read} {
+		if v.String() == string(text) {
+			*da = v
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown database access '%s'", text)
+}
+
+func (sa SecurityClearance) MarshalText() ([]byte, error) {
+	if sa < ClusterAdmin || sa > ValidUser {
+		return nil, fmt.Errorf("unknown security clearance %d", int(sa))
+	}
+	return []byte(sa.String()), nil
+}
+
+func (sa *SecurityClearance) UnmarshalText(text []byte) error {
+	for _, v := range []SecurityClearance{ClusterAdmin, ClusterNode, Operator, ValidUser} {
+		if v.String() == string(text) {
+			*sa = v
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown security clearance '%s'", text)
+}
+
+// CertificateDefinition describes a certificate registered on the server
+type CertificateDefinition struct {
+	Name              string                    `json:"Name"`
+	Thumbprint        string                    `json:"Thumbprint"`
+	Certificate       string                    `json:"Certificate"` // base64 encoded DER
+	NotAfter          string                    `json:"NotAfter"`
+	SecurityClearance SecurityClearance         `json:"SecurityClearance"`
+	Permissions       map[string]DatabaseAccess `json:"Permissions"`
+}
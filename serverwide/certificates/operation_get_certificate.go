@@ -0,0 +1,65 @@
+package certificates
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+
+	"github.com/ravendb/ravendb-go-client"
+)
+
+// OperationGetCertificate gets a certificate registered on the server.
+// Certificate is nil if there's no certificate with a given thumbprint.
+type OperationGetCertificate struct {
+	Thumbprint  string
+	Certificate *CertificateDefinition
+}
+
+func NewOperationGetCertificate(thumbprint string) *OperationGetCertificate {
+	return &OperationGetCertificate{
+		Thumbprint: thumbprint,
+	}
+}
+
+func (operation *OperationGetCertificate) GetCommand(conventions *ravendb.DocumentConventions) (ravendb.RavenCommand, error) {
+	if operation.Thumbprint == "" {
+		return nil, errors.New("thumbprint cannot be empty")
+	}
+	return &getCertificateCommand{
+		RavenCommandBase: ravendb.RavenCommandBase{
+			ResponseType:  ravendb.RavenCommandResponseTypeObject,
+			IsReadRequest: true,
+		},
+		parent: operation,
+	}, nil
+}
+
+type getCertificateCommand struct {
+	ravendb.RavenCommandBase
+	parent *OperationGetCertificate
+}
+
+func (c *getCertificateCommand) CreateRequest(node *ravendb.ServerNode) (*http.Request, error) {
+	uri := node.URL + "/admin/certificates?thumbprint=" + url.QueryEscape(c.parent.Thumbprint)
+	return http.NewRequest(http.MethodGet, uri, nil)
+}
+
+func (c *getCertificateCommand) SetResponse(response []byte, fromCache bool) error {
+	c.parent.Certificate = nil
+	if len(response) == 0 {
+		// certificate not found
+		return nil
+	}
+
+	var res struct {
+		Results []*CertificateDefinition `json:"Results"`
+	}
+	if err := json.Unmarshal(response, &res); err != nil {
+		return err
+	}
+	if len(res.Results) > 0 {
+		c.parent.Certificate = res.Results[0]
+	}
+	return nil
+}
@@ -4,10 +4,14 @@ import (
 	"bytes"
 	"encoding/base64"
 	"encoding/json"
-	"github.com/ravendb/ravendb-go-client"
+	"errors"
 	"net/http"
+
+	"github.com/ravendb/ravendb-go-client"
 )
 
+// OperationPutCertificate registers a certificate on the server.
+// CertBytes is DER of the public part of the certificate.
 type OperationPutCertificate struct {
 	CertName          string            `json:"CertName,omitempty"`
 	CertBytes         []byte            `json:"CertBytes,omitempty"`
@@ -15,7 +19,28 @@ type OperationPutCertificate struct {
 	Permissions       map[string]string `json:"Permissions,omitempty"`
 }
 
+// NewOperationPutCertificate returns an operation that registers a client
+// certificate with given clearance and access to given databases
+func NewOperationPutCertificate(name string, certBytes []byte, permissions map[string]DatabaseAccess, clearance SecurityClearance) *OperationPutCertificate {
+	perms := map[string]string{}
+	for database, access := range permissions {
+		perms[database] = access.String()
+	}
+	return &OperationPutCertificate{
+		CertName:          name,
+		CertBytes:         certBytes,
+		SecurityClearance: clearance.String(),
+		Permissions:       perms,
+	}
+}
+
 func (operation *OperationPutCertificate) GetCommand(conventions *ravendb.DocumentConventions) (ravendb.RavenCommand, error) {
+	if operation.CertName == "" {
+		return nil, errors.New("name cannot be empty")
+	}
+	if len(operation.CertBytes) == 0 {
+		return nil, errors.New("certificate cannot be empty")
+	}
 	return &putCertificateCommand{
 		RaftCommandBase: ravendb.RaftCommandBase{
 			RavenCommandBase: ravendb.RavenCommandBase{
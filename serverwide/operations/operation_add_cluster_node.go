@@ -2,7 +2,6 @@ package operations
 
 import (
 	"encoding/json"
-	"fmt"
 	"github.com/ravendb/ravendb-go-client"
 	"net/http"
 	"net/url"
@@ -33,10 +32,11 @@ type addNodeCommand struct {
 }
 
 func (c *addNodeCommand) CreateRequest(node *ravendb.ServerNode) (*http.Request, error) {
+	tag := url.QueryEscape(c.parent.Tag)
 	url := node.URL + "/admin/cluster/node?url=" + url.QueryEscape(c.parent.Url) + "&watcher=" + strconv.FormatBool(c.parent.Watcher)
 
-	if len(strings.TrimSpace(c.parent.Tag)) == 0 {
-		url += fmt.Sprintf("&tag=%s", c.parent.Tag)
+	if len(strings.TrimSpace(c.parent.Tag)) > 0 {
+		url += "&tag=" + tag
 	}
 	return http.NewRequest(http.MethodPut, url, nil)
 }
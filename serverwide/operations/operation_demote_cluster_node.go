@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"github.com/ravendb/ravendb-go-client"
 	"net/http"
+	"net/url"
 )
 
 type OperationDemoteClusterNode struct {
@@ -27,7 +28,7 @@ type demoteNodeCommand struct {
 }
 
 func (c *demoteNodeCommand) CreateRequest(node *ravendb.ServerNode) (*http.Request, error) {
-	url := node.URL + "/admin/cluster/demote?nodeTag=" + c.parent.Node
+	url := node.URL + "/admin/cluster/demote?nodeTag=" + url.QueryEscape(c.parent.Node)
 	return http.NewRequest(http.MethodPost, url, nil)
 }
 
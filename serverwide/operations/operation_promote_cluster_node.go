@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"github.com/ravendb/ravendb-go-client"
 	"net/http"
+	"net/url"
 )
 
 type OperationPromoteClusterNode struct {
@@ -32,7 +33,7 @@ type promoteNodeCommand struct {
 }
 
 func (c *promoteNodeCommand) CreateRequest(node *ravendb.ServerNode) (*http.Request, error) {
-	url := node.URL + "/admin/cluster/promote?nodeTag=" + c.parent.Node
+	url := node.URL + "/admin/cluster/promote?nodeTag=" + url.QueryEscape(c.parent.Node)
 	return http.NewRequest(http.MethodPost, url, nil)
 }
 
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"github.com/ravendb/ravendb-go-client"
 	"net/http"
+	"net/url"
 )
 
 type RemoveClusterNode struct {
@@ -34,7 +35,7 @@ type removeNodeCommand struct {
 }
 
 func (c *removeNodeCommand) CreateRequest(node *ravendb.ServerNode) (*http.Request, error) {
-	url := node.URL + "/admin/cluster/node?nodeTag=" + c.parent.Tag
+	url := node.URL + "/admin/cluster/node?nodeTag=" + url.QueryEscape(c.parent.Tag)
 	return http.NewRequest(http.MethodDelete, url, nil)
 }
 func (c *removeNodeCommand) SetResponse(response []byte, fromCache bool) error {
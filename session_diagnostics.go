@@ -0,0 +1,18 @@
+package ravendb
+
+// SessionDiagnostics is a snapshot of session state, useful when
+// debugging e.g. N+1 query problems
+type SessionDiagnostics struct {
+	// NumberOfRequests is the number of requests sent to the server
+	// by this session so far
+	NumberOfRequests int
+	// UniqueEntitiesInSession is the number of entities tracked
+	// by the session's unit of work
+	UniqueEntitiesInSession int
+	// NumberOfDeferredCommands is the number of commands added via Defer()
+	// that will be sent on the next SaveChanges()
+	NumberOfDeferredCommands int
+	// PendingSessionChanges is true if SaveChanges() would send anything
+	// to the server
+	PendingSessionChanges bool
+}
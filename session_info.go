@@ -3,4 +3,13 @@ package ravendb
 // SessionInfo describes a session
 type SessionInfo struct {
 	SessionID int
+
+	// LastClusterTransactionIndex is the raft index of the last cluster-wide
+	// transaction known to the session. It's sent with requests so that
+	// the server waits until it has applied it. 0 means not known
+	LastClusterTransactionIndex int64
+
+	// NoCaching disables reading and updating HTTP cache for requests
+	// of the session
+	NoCaching bool
 }
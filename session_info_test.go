@@ -0,0 +1,58 @@
+package ravendb
+
+import (
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const clusterBatchResponseJSON = `{
+	"Results": [{"Type": "PUT", "@id": "users/1", "@collection": "Users", "@change-vector": "RAFT:1-abc"}],
+	"TransactionIndex": 42
+}`
+
+func TestSessionSendsLastClusterTransactionIndex(t *testing.T) {
+	var mu sync.Mutex
+	raftIndexes := map[string]string{}
+	fn := func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		raftIndexes[r.Method+" "+r.URL.Path] = r.Header.Get("Known-Raft-Index")
+		mu.Unlock()
+		switch r.URL.Path {
+		case "/databases/db/bulk_docs":
+			_, _ = w.Write([]byte(clusterBatchResponseJSON))
+		case "/databases/db/docs":
+			_, _ = w.Write([]byte(`{"Results": [{"name": "John", "@metadata": {"@id": "users/1", "@collection": "Users", "@change-vector": "RAFT:1-abc"}}], "Includes": {}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+	store := newFakeServerStore(t, fn)
+	assert.Equal(t, int64(0), store.GetLastTransactionIndex(""))
+
+	session, err := store.OpenSessionWithOptions(&SessionOptions{
+		TransactionMode: TransactionModeClusterWide,
+	})
+	require.NoError(t, err)
+	defer session.Close()
+	require.NoError(t, session.StoreWithID(&User{}, "users/1"))
+	require.NoError(t, session.SaveChanges())
+	assert.Equal(t, "", raftIndexes["POST /databases/db/bulk_docs"])
+	assert.Equal(t, int64(42), store.GetLastTransactionIndex("DB"))
+	assert.Equal(t, int64(42), session.sessionInfo.LastClusterTransactionIndex)
+
+	// sessions opened later wait for the transaction
+	session2, err := store.OpenSession("")
+	require.NoError(t, err)
+	defer session2.Close()
+	var user *User
+	require.NoError(t, session2.Load(&user, "users/1"))
+	assert.Equal(t, "42", raftIndexes["GET /databases/db/docs"])
+
+	// older indexes are ignored
+	store.SetLastTransactionIndex("db", 10)
+	assert.Equal(t, int64(42), store.GetLastTransactionIndex(""))
+}
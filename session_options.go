@@ -4,4 +4,11 @@ package ravendb
 type SessionOptions struct {
 	Database        string
 	RequestExecutor *RequestExecutor
+	// TransactionMode is TransactionModeSingleNode if not set
+	TransactionMode TransactionMode
+	// NoTracking opens a read-only session. Loaded and queried entities
+	// are not tracked and storing, deleting or saving changes fails
+	NoTracking bool
+	// NoCaching disables HTTP cache for requests of the session
+	NoCaching bool
 }
@@ -0,0 +1,90 @@
+package ravendb
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newUsersHandler answers loads and queries with users/1.
+// If-None-Match headers of requests are appended to ifNoneMatch
+func newUsersHandler(ifNoneMatch *[]string) http.HandlerFunc {
+	const user = `{"Name": "John", "@metadata": {"@id": "users/1", "@collection": "Users", "@change-vector": "A:1"}}`
+	fn := func(w http.ResponseWriter, r *http.Request) {
+		*ifNoneMatch = append(*ifNoneMatch, r.Header.Get(headersIfNoneMatch))
+		w.Header().Set(headersEtag, `"A:1"`)
+		switch r.URL.Path {
+		case "/databases/db/docs":
+			_, _ = w.Write([]byte(`{"Results":[` + user + `],"Includes":{}}`))
+		case "/databases/db/queries":
+			_, _ = w.Write([]byte(`{"Results":[` + user + `],"Includes":{},"IndexName":"Auto/Users","TotalResults":1}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+	return fn
+}
+
+func TestSessionOptionsNoTracking(t *testing.T) {
+	var ifNoneMatch []string
+	store := newFakeServerStore(t, newUsersHandler(&ifNoneMatch))
+	session, err := store.OpenSessionWithOptions(&SessionOptions{
+		NoTracking: true,
+	})
+	require.NoError(t, err)
+	defer session.Close()
+
+	var user *User
+	err = session.Load(&user, "users/1")
+	require.NoError(t, err)
+	require.NotNil(t, user)
+	assert.Equal(t, "John", user.Name)
+	assert.Equal(t, 0, session.GetNumberOfEntitiesInUnitOfWork())
+
+	// not tracked so loaded again from the server
+	var user2 *User
+	err = session.Load(&user2, "users/1")
+	require.NoError(t, err)
+	assert.Equal(t, 2, session.GetNumberOfRequests())
+	assert.False(t, user == user2)
+
+	var users []*User
+	err = session.QueryCollection("Users").GetResults(&users)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(users))
+	assert.Equal(t, 0, session.GetNumberOfEntitiesInUnitOfWork())
+
+	user.Name = "Jane"
+	assert.False(t, session.HasChanges())
+
+	_, ok := session.Store(&User{}).(*IllegalStateError)
+	assert.True(t, ok)
+	_, ok = session.DeleteByID("users/1", "").(*IllegalStateError)
+	assert.True(t, ok)
+	err = session.SaveChanges()
+	_, ok = err.(*IllegalStateError)
+	require.True(t, ok, "expected *IllegalStateError, got %T (%v)", err, err)
+	assert.Contains(t, err.Error(), "tracking is disabled")
+}
+
+func TestSessionOptionsNoCaching(t *testing.T) {
+	var ifNoneMatch []string
+	store := newFakeServerStore(t, newUsersHandler(&ifNoneMatch))
+
+	load := func(options *SessionOptions) {
+		session, err := store.OpenSessionWithOptions(options)
+		require.NoError(t, err)
+		defer session.Close()
+		var user *User
+		require.NoError(t, session.Load(&user, "users/1"))
+	}
+
+	// the response of a regular session is cached and revalidated with
+	// If-None-Match by the next one
+	load(&SessionOptions{})
+	load(&SessionOptions{})
+	load(&SessionOptions{NoCaching: true})
+	assert.Equal(t, []string{"", `"A:1"`, ""}, ifNoneMatch)
+}
@@ -0,0 +1,83 @@
+package ravendb
+
+import (
+	"net/http"
+)
+
+var (
+	_ IServerOperation = &SetDatabasesLockOperation{}
+)
+
+// SetDatabasesLockParameters are parameters of SetDatabasesLockOperation
+type SetDatabasesLockParameters struct {
+	DatabaseNames []string         `json:"DatabaseNames"`
+	Mode          DatabaseLockMode `json:"Mode"`
+}
+
+// SetDatabasesLockOperation sets lock mode of databases
+type SetDatabasesLockOperation struct {
+	parameters *SetDatabasesLockParameters
+
+	Command *SetDatabasesLockCommand
+}
+
+func NewSetDatabasesLockOperation(databaseName string, mode DatabaseLockMode) *SetDatabasesLockOperation {
+	p := &SetDatabasesLockParameters{
+		DatabaseNames: []string{databaseName},
+		Mode:          mode,
+	}
+	return NewSetDatabasesLockOperationWithParameters(p)
+}
+
+func NewSetDatabasesLockOperationWithParameters(parameters *SetDatabasesLockParameters) *SetDatabasesLockOperation {
+	return &SetDatabasesLockOperation{
+		parameters: parameters,
+	}
+}
+
+func (o *SetDatabasesLockOperation) GetCommand(conventions *DocumentConventions) (RavenCommand, error) {
+	var err error
+	o.Command, err = NewSetDatabasesLockCommand(o.parameters)
+	if err != nil {
+		return nil, err
+	}
+	return o.Command, nil
+}
+
+var _ RavenCommand = &SetDatabasesLockCommand{}
+
+type SetDatabasesLockCommand struct {
+	RavenCommandBase
+
+	parameters []byte
+}
+
+func NewSetDatabasesLockCommand(parameters *SetDatabasesLockParameters) (*SetDatabasesLockCommand, error) {
+	if parameters == nil {
+		return nil, newIllegalArgumentError("parameters cannot be nil")
+	}
+	if len(parameters.DatabaseNames) == 0 {
+		return nil, newIllegalArgumentError("DatabaseNames cannot be empty")
+	}
+	for _, name := range parameters.DatabaseNames {
+		if name == "" {
+			return nil, newIllegalArgumentError("database name cannot be empty")
+		}
+	}
+	d, err := jsonMarshal(parameters)
+	if err != nil {
+		return nil, err
+	}
+	cmd := &SetDatabasesLockCommand{
+		RavenCommandBase: NewRavenCommandBase(),
+
+		parameters: d,
+	}
+	cmd.ResponseType = RavenCommandResponseTypeEmpty
+	return cmd, nil
+}
+
+func (c *SetDatabasesLockCommand) CreateRequest(node *ServerNode) (*http.Request, error) {
+	url := node.URL + "/admin/databases/set-lock"
+	return NewHttpPost(url, c.parameters)
+}
@@ -0,0 +1,31 @@
+package ravendb
+
+import (
+	"strings"
+)
+
+// Note: when definition of an existing static index changes, the server doesn't
+// replace it right away. It builds the new definition side-by-side, as an index
+// named ReplacementOf/<name>, and keeps serving queries from the old index.
+// When the replacement is no longer stale, the server swaps them and raises
+// IndexChangeSideBySideReplace in Changes().
+
+// IndexReplacementName returns the name of a side-by-side index
+// that replaces index with a given name
+func IndexReplacementName(indexName string) string {
+	if IsIndexReplacement(indexName) {
+		return indexName
+	}
+	return IndexingSideBySideIndexNamePrefix + indexName
+}
+
+// IsIndexReplacement returns true if indexName is a name of a side-by-side index
+func IsIndexReplacement(indexName string) bool {
+	return strings.HasPrefix(indexName, IndexingSideBySideIndexNamePrefix)
+}
+
+// OriginalIndexName returns the name of an index replaced by a side-by-side
+// index with a given name. Other names are returned unchanged
+func OriginalIndexName(indexName string) string {
+	return strings.TrimPrefix(indexName, IndexingSideBySideIndexNamePrefix)
+}
@@ -0,0 +1,31 @@
+package ravendb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIndexReplacementName(t *testing.T) {
+	tests := []struct {
+		name        string
+		replacement string
+		original    string
+	}{
+		{"Users/ByName", "ReplacementOf/Users/ByName", "Users/ByName"},
+		{"ReplacementOf/Users/ByName", "ReplacementOf/Users/ByName", "Users/ByName"},
+		// the prefix is case-sensitive and must be at the start
+		{"replacementof/Users", "ReplacementOf/replacementof/Users", "replacementof/Users"},
+		{"Users/ReplacementOf/X", "ReplacementOf/Users/ReplacementOf/X", "Users/ReplacementOf/X"},
+		{"ReplacementOf", "ReplacementOf/ReplacementOf", "ReplacementOf"},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.replacement, IndexReplacementName(test.name), "name: %s", test.name)
+		assert.Equal(t, test.original, OriginalIndexName(test.name), "name: %s", test.name)
+		assert.Equal(t, test.original != test.name, IsIndexReplacement(test.name), "name: %s", test.name)
+		assert.Equal(t, test.replacement, IndexReplacementName(IndexReplacementName(test.name)))
+	}
+
+	assert.Error(t, indexCreationValidateName("ReplacementOf/Users"))
+	assert.NoError(t, indexCreationValidateName("Users/ReplacementOf/X"))
+}
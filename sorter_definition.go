@@ -0,0 +1,9 @@
+package ravendb
+
+// SorterDefinition describes a custom sorter deployed to a database.
+// Code is C# source of a class deriving from Lucene's FieldComparator,
+// Name is used to refer to it in queries (see DocumentQuery.OrderByWithSorter)
+type SorterDefinition struct {
+	Name string `json:"Name"`
+	Code string `json:"Code"`
+}
@@ -0,0 +1,66 @@
+package ravendb
+
+import (
+	"net/http"
+	"strconv"
+)
+
+var (
+	_ IMaintenanceOperation = &StartBackupOperation{}
+)
+
+// StartBackupOperation runs a periodic backup task immediately.
+// Use MaintenanceOperationExecutor.SendAsync to wait for the backup to finish.
+type StartBackupOperation struct {
+	isFullBackup bool
+	taskID       int64
+
+	Command *StartBackupCommand
+}
+
+func NewStartBackupOperation(isFullBackup bool, taskID int64) *StartBackupOperation {
+	return &StartBackupOperation{
+		isFullBackup: isFullBackup,
+		taskID:       taskID,
+	}
+}
+
+func (o *StartBackupOperation) GetCommand(conventions *DocumentConventions) (RavenCommand, error) {
+	o.Command = NewStartBackupCommand(o.isFullBackup, o.taskID)
+	return o.Command, nil
+}
+
+var _ RavenCommand = &StartBackupCommand{}
+
+type StartBackupCommand struct {
+	RavenCommandBase
+
+	isFullBackup bool
+	taskID       int64
+
+	Result *OperationIDResult
+}
+
+func NewStartBackupCommand(isFullBackup bool, taskID int64) *StartBackupCommand {
+	cmd := &StartBackupCommand{
+		RavenCommandBase: NewRavenCommandBase(),
+
+		isFullBackup: isFullBackup,
+		taskID:       taskID,
+	}
+	return cmd
+}
+
+func (c *StartBackupCommand) CreateRequest(node *ServerNode) (*http.Request, error) {
+	url := node.URL + "/databases/" + node.Database + "/admin/backup/database?isFullBackup=" + strconv.FormatBool(c.isFullBackup) + "&taskId=" + strconv.FormatInt(c.taskID, 10)
+
+	return NewHttpPost(url, nil)
+}
+
+func (c *StartBackupCommand) SetResponse(response []byte, fromCache bool) error {
+	if len(response) == 0 {
+		return throwInvalidResponse()
+	}
+
+	return jsonUnmarshal(response, &c.Result)
+}
@@ -0,0 +1,29 @@
+package ravendb
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStopStartIndexing(t *testing.T) {
+	var requests []string
+	fn := func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	}
+	store := newFakeServerStore(t, fn)
+
+	err := store.Maintenance().Send(NewStopIndexingOperation())
+	require.NoError(t, err)
+	err = store.Maintenance().Send(NewStartIndexingOperation())
+	require.NoError(t, err)
+
+	exp := []string{
+		"POST /databases/db/admin/indexes/stop",
+		"POST /databases/db/admin/indexes/start",
+	}
+	assert.Equal(t, exp, requests)
+}
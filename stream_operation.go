@@ -102,13 +102,14 @@ func (o *StreamOperation) setResult(response *StreamResultResponse) (*yieldStrea
 			o.statistics = &StreamQueryStatistics{}
 		}
 		err = handleStreamQueryStats(dec, o.statistics)
-		if err != nil {
-			return nil, err
-		}
+	} else {
+		err = skipToObjectKey(dec, "Results")
+	}
+	if err != nil {
+		return nil, err
 	}
 
-	// expecting object with a single field "Results" that is array of values
-	tok, err = getTokenAfterObjectKey(dec, "Results")
+	tok, err = dec.Token()
 	if err != nil {
 		return nil, err
 	}
@@ -124,7 +125,7 @@ func getNextDelimToken(dec *json.Decoder, delimStr string) error {
 	if err != nil {
 		return err
 	}
-	if delim, ok := tok.(json.Delim); ok || delim.String() == delimStr {
+	if isDelimToken(tok, delimStr) {
 		return nil
 	}
 	return fmt.Errorf("Expected delim token '%s', got %T %s", delimStr, tok, tok)
@@ -141,76 +142,58 @@ func getNextStringToken(dec *json.Decoder) (string, error) {
 	return "", fmt.Errorf("Expected string token, got %T %s", tok, tok)
 }
 
-func getTokenAfterObjectKey(dec *json.Decoder, name string) (json.Token, error) {
-	s, err := getNextStringToken(dec)
-	if err == nil {
-		if s != name {
-			return nil, fmt.Errorf("Expected string token named '%s', got '%s'", name, s)
+// skipToObjectKey reads object keys, skipping their values, until it finds
+// the key with a given name
+func skipToObjectKey(dec *json.Decoder, name string) error {
+	for {
+		key, err := getNextStringToken(dec)
+		if err != nil {
+			return err
+		}
+		if key == name {
+			return nil
+		}
+		var ignored json.RawMessage
+		if err = dec.Decode(&ignored); err != nil {
+			return err
 		}
 	}
-	return dec.Token()
-}
-
-func getNextObjectStringValue(dec *json.Decoder, name string) (string, error) {
-	tok, err := getTokenAfterObjectKey(dec, name)
-	if err != nil {
-		return "", err
-	}
-	s, ok := tok.(string)
-	if !ok {
-		return "", fmt.Errorf("Expected string token, got %T %s", tok, tok)
-	}
-	return s, nil
-}
-
-func getNextObjectBoolValue(dec *json.Decoder, name string) (bool, error) {
-	tok, err := getTokenAfterObjectKey(dec, name)
-	if err != nil {
-		return false, err
-	}
-	v, ok := tok.(bool)
-	if !ok {
-		return false, fmt.Errorf("Expected bool token, got %T %s", tok, tok)
-	}
-	return v, nil
-}
-
-func getNextObjectInt64Value(dec *json.Decoder, name string) (int64, error) {
-	tok, err := getTokenAfterObjectKey(dec, name)
-	if err != nil {
-		return 0, err
-	}
-	if v, ok := tok.(float64); ok {
-		return int64(v), nil
-	}
-	if v, ok := tok.(json.Number); ok {
-		return v.Int64()
-	}
-	return 0, fmt.Errorf("Expected number token, got %T %s", tok, tok)
 }
 
+// handleStreamQueryStats reads header fields that precede "Results" array
+// in a query stream response. Fields can come in any order and unknown
+// fields are skipped
 func handleStreamQueryStats(dec *json.Decoder, stats *StreamQueryStatistics) error {
-	var err error
-	var n int64
-	stats.ResultEtag, err = getNextObjectInt64Value(dec, "ResultEtag")
-	if err == nil {
-		stats.IsStale, err = getNextObjectBoolValue(dec, "IsStale")
-	}
-	if err == nil {
-		stats.IndexName, err = getNextObjectStringValue(dec, "IndexName")
-	}
-	if err == nil {
-		n, err = getNextObjectInt64Value(dec, "TotalResults")
-		stats.TotalResults = int(n)
-	}
-	if err == nil {
-		var s string
-		s, err = getNextObjectStringValue(dec, "IndexTimestamp")
-		if err == nil {
-			stats.IndexTimestamp, err = ParseTime(s)
+	for {
+		key, err := getNextStringToken(dec)
+		if err != nil {
+			return err
+		}
+		switch key {
+		case "Results":
+			return nil
+		case "ResultEtag":
+			err = dec.Decode(&stats.ResultEtag)
+		case "IsStale":
+			err = dec.Decode(&stats.IsStale)
+		case "IndexName":
+			err = dec.Decode(&stats.IndexName)
+		case "TotalResults":
+			err = dec.Decode(&stats.TotalResults)
+		case "IndexTimestamp":
+			var s string
+			err = dec.Decode(&s)
+			if err == nil && s != "" {
+				stats.IndexTimestamp, err = ParseTime(s)
+			}
+		default:
+			var ignored json.RawMessage
+			err = dec.Decode(&ignored)
+		}
+		if err != nil {
+			return err
 		}
 	}
-	return err
 }
 
 type yieldStreamResults struct {
@@ -0,0 +1,118 @@
+package ravendb
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readAllStreamResults(t *testing.T, res *yieldStreamResults) []map[string]interface{} {
+	var all []map[string]interface{}
+	for {
+		v, err := res.nextJSONObject()
+		if err == io.EOF {
+			return all
+		}
+		require.NoError(t, err)
+		all = append(all, v)
+	}
+}
+
+func TestStreamOperationParsesQueryStats(t *testing.T) {
+	// header fields can come in any order and unknown fields are skipped
+	body := `{"IsStale":true,"IndexName":"Auto/Users","SkippedResults":0,"TotalResults":2,` +
+		`"IndexTimestamp":"2018-04-26T11:23:20.0000000","ResultEtag":-5,` +
+		`"Results":[{"name":"a"},{"name":"b"}]}`
+	stats := &StreamQueryStatistics{}
+	op := NewStreamOperation(nil, stats)
+	op.isQueryStream = true
+	res, err := op.setResult(&StreamResultResponse{Stream: strings.NewReader(body)})
+	require.NoError(t, err)
+
+	assert.True(t, stats.IsStale)
+	assert.Equal(t, "Auto/Users", stats.IndexName)
+	assert.Equal(t, 2, stats.TotalResults)
+	assert.Equal(t, int64(-5), stats.ResultEtag)
+	assert.Equal(t, 2018, stats.IndexTimestamp.Year())
+
+	all := readAllStreamResults(t, res)
+	require.Equal(t, 2, len(all))
+	assert.Equal(t, "b", all[1]["name"])
+}
+
+func TestStreamOperationDocumentStream(t *testing.T) {
+	op := NewStreamOperation(nil, nil)
+	res, err := op.setResult(&StreamResultResponse{Stream: strings.NewReader(`{"Results":[{"name":"a"}]}`)})
+	require.NoError(t, err)
+	assert.Equal(t, 1, len(readAllStreamResults(t, res)))
+
+	_, err = op.setResult(&StreamResultResponse{Stream: strings.NewReader(`{"Results":{}}`)})
+	assert.Error(t, err)
+
+	// truncated results are reported as an error
+	res, err = op.setResult(&StreamResultResponse{Stream: strings.NewReader(`{"Results":[{"name":"a"}}`)})
+	require.NoError(t, err)
+	_, err = res.nextJSONObject()
+	require.NoError(t, err)
+	_, err = res.nextJSONObject()
+	assert.Error(t, err)
+	assert.NotEqual(t, io.EOF, err)
+}
+
+func TestDocumentSessionStreamQueryInto(t *testing.T) {
+	body := `{"ResultEtag":1,"IsStale":false,"IndexName":"Users","TotalResults":2,"IndexTimestamp":null,` +
+		`"Results":[{"name":"a","@metadata":{"@id":"users/1"}},{"name":"b","@metadata":{"@id":"users/2"}}]}`
+	fn := func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/databases/db/streams/queries" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(body))
+	}
+	store := newFakeServerStore(t, fn)
+	session, err := store.OpenSession("")
+	require.NoError(t, err)
+	defer session.Close()
+
+	var buf bytes.Buffer
+	err = session.StreamQueryInto(session.QueryIndex("Users"), &buf)
+	require.NoError(t, err)
+
+	// the response is copied as is
+	assert.Equal(t, body, buf.String())
+	var res struct {
+		Results []map[string]interface{}
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &res))
+	assert.Equal(t, 2, len(res.Results))
+}
+
+func TestDocumentSessionStreamQueryAsCSV(t *testing.T) {
+	var query string
+	fn := func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/databases/db/streams/queries" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		query = r.URL.RawQuery
+		_, _ = w.Write([]byte("Name,Age\r\nJohn,3\r\n"))
+	}
+	store := newFakeServerStore(t, fn)
+	session, err := store.OpenSession("")
+	require.NoError(t, err)
+	defer session.Close()
+
+	var buf bytes.Buffer
+	fields := []string{"Name", "Age"}
+	err = session.Advanced().StreamQueryAsCSV(session.QueryCollection("Users"), &buf, fields)
+	require.NoError(t, err)
+	assert.Equal(t, "format=csv&field=Name&field=Age", query)
+	// CSV returned by the server is copied unchanged
+	assert.Equal(t, "Name,Age\r\nJohn,3\r\n", buf.String())
+}
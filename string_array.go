@@ -151,3 +151,23 @@ func stringArrayRemoveDuplicatesNoCase(a []string) []string {
 	}
 	return stringArrayRemoveAtIndexes(a, toRemove)
 }
+
+// stringArrayEq returns true if arrays have the same content, ignoring order
+func stringArrayEq(a1, a2 []string) bool {
+	if len(a1) != len(a2) {
+		return false
+	}
+	if len(a1) == 0 {
+		return true
+	}
+	a1c := stringArrayCopy(a1)
+	a2c := stringArrayCopy(a2)
+	sort.Strings(a1c)
+	sort.Strings(a2c)
+	for i, s := range a1c {
+		if s != a2c[i] {
+			return false
+		}
+	}
+	return true
+}
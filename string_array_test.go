@@ -7,26 +7,6 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
-// stringArrayEq returns true if arrays have the same content, ignoring order
-func stringArrayEq(a1, a2 []string) bool {
-	if len(a1) != len(a2) {
-		return false
-	}
-	if len(a1) == 0 {
-		return true
-	}
-	a1c := stringArrayCopy(a1)
-	a2c := stringArrayCopy(a2)
-	sort.Strings(a1c)
-	sort.Strings(a2c)
-	for i, s := range a1c {
-		if s != a2c[i] {
-			return false
-		}
-	}
-	return true
-}
-
 func TestStringArraySubtract(t *testing.T) {
 	var tests = []struct {
 		a1, a2 []string
@@ -123,7 +123,7 @@ func (b *SubscriptionBatch) initialize(batch []*subscriptionConnectionServerMess
 					//c := b._requestExecutor.GetConventions()
 					if current != nil {
 						doc := current.(map[string]interface{})
-						v, err := entityToJSONConvertToEntity(b.clazz, id, doc)
+						v, err := entityToJSONConvertToEntity(b.requestExecutor.GetConventions(), b.clazz, id, doc)
 						if err != nil {
 							return "", err
 						}
@@ -131,7 +131,7 @@ func (b *SubscriptionBatch) initialize(batch []*subscriptionConnectionServerMess
 					}
 					if previous != nil {
 						doc := previous.(map[string]interface{})
-						v, err := entityToJSONConvertToEntity(b.clazz, id, doc)
+						v, err := entityToJSONConvertToEntity(b.requestExecutor.GetConventions(), b.clazz, id, doc)
 						if err != nil {
 							return "", err
 						}
@@ -140,7 +140,7 @@ func (b *SubscriptionBatch) initialize(batch []*subscriptionConnectionServerMess
 					instance = revision
 				} else {
 					var err error
-					instance, err = entityToJSONConvertToEntity(b.clazz, id, curDoc)
+					instance, err = entityToJSONConvertToEntity(b.requestExecutor.GetConventions(), b.clazz, id, curDoc)
 					if err != nil {
 						return "", err
 					}
@@ -0,0 +1,235 @@
+// Package testdriver helps writing integration tests against a running
+// RavenDB server. Every store it creates is backed by its own database,
+// so tests don't see each other's data.
+package testdriver
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ravendb/ravendb-go-client"
+)
+
+const debugDoneDocumentID = "Debug/Done"
+
+// TestDocumentDriver creates document stores for a test, each pointing
+// to a freshly created database. Call Cleanup when the test is done to close
+// the stores and delete the databases
+type TestDocumentDriver struct {
+	// Certificate and TrustStore are passed to every store created by the
+	// driver. They must be set before the first call to CreateDocumentStore
+	Certificate *tls.Certificate
+	TrustStore  *x509.Certificate
+
+	// ReplicationFactor is used when creating databases. Defaults to 1
+	ReplicationFactor int
+
+	t    testing.TB
+	urls []string
+
+	mu         sync.Mutex
+	adminStore *ravendb.DocumentStore
+	stores     []*ravendb.DocumentStore
+	lastStamp  int64
+}
+
+// NewTestDocumentDriver returns a driver for test t that creates databases
+// on the server(s) at urls
+func NewTestDocumentDriver(t testing.TB, urls ...string) *TestDocumentDriver {
+	return &TestDocumentDriver{
+		ReplicationFactor: 1,
+		t:                 t,
+		urls:              urls,
+	}
+}
+
+// CreateDocumentStore creates a new database named <TestName>_<timestamp>
+// and returns an initialized store for it. It fails the test if the
+// database can't be created
+func (d *TestDocumentDriver) CreateDocumentStore() *ravendb.DocumentStore {
+	d.t.Helper()
+
+	admin, err := d.getAdminStore()
+	if err != nil {
+		d.t.Fatalf("testdriver: failed to initialize admin store: %s", err)
+		return nil
+	}
+
+	name := d.newDatabaseName()
+	record := ravendb.NewDatabaseRecord()
+	record.DatabaseName = name
+	op := ravendb.NewCreateDatabaseOperation(record, d.ReplicationFactor)
+	if err = admin.Maintenance().Server().Send(op); err != nil {
+		d.t.Fatalf("testdriver: failed to create database '%s': %s", name, err)
+		return nil
+	}
+
+	store := d.newStore(name)
+	if err = store.Initialize(); err != nil {
+		d.deleteDatabase(name)
+		d.t.Fatalf("testdriver: failed to initialize store for database '%s': %s", name, err)
+		return nil
+	}
+
+	d.mu.Lock()
+	d.stores = append(d.stores, store)
+	d.mu.Unlock()
+	return store
+}
+
+// WaitForIndexing waits until all indexes of a given database are
+// non-stale or until timeout expires
+func (d *TestDocumentDriver) WaitForIndexing(store *ravendb.DocumentStore, database string, timeout time.Duration) error {
+	if database == "" {
+		database = store.GetDatabase()
+	}
+	return store.Maintenance().ForDatabase(database).WaitForIndexesToBecomeNonStale(timeout)
+}
+
+// WaitForUserToContinueTheTest pauses the test so that the database can be
+// inspected in the studio. It creates a "Debug/Done" document and blocks
+// until it's deleted
+func (d *TestDocumentDriver) WaitForUserToContinueTheTest(store *ravendb.DocumentStore) {
+	d.t.Helper()
+
+	session, err := store.OpenSession("")
+	if err != nil {
+		d.t.Fatalf("testdriver: OpenSession() failed: %s", err)
+		return
+	}
+	err = session.StoreWithID(&map[string]interface{}{}, debugDoneDocumentID)
+	if err == nil {
+		err = session.SaveChanges()
+	}
+	session.Close()
+	if err != nil {
+		d.t.Fatalf("testdriver: failed to store '%s': %s", debugDoneDocumentID, err)
+		return
+	}
+
+	studioURL := store.GetUrls()[0] + "/studio/index.html#databases/documents?&database=" + url.QueryEscape(store.GetDatabase())
+	fmt.Printf("Test paused. Delete document '%s' in %s to continue\n", debugDoneDocumentID, studioURL)
+
+	for {
+		time.Sleep(500 * time.Millisecond)
+		session, err = store.OpenSession("")
+		if err != nil {
+			d.t.Fatalf("testdriver: OpenSession() failed: %s", err)
+			return
+		}
+		exists, err := session.Exists(debugDoneDocumentID)
+		session.Close()
+		if err != nil {
+			d.t.Fatalf("testdriver: Exists('%s') failed: %s", debugDoneDocumentID, err)
+			return
+		}
+		if !exists {
+			return
+		}
+	}
+}
+
+// Cleanup closes all stores created by the driver and deletes their
+// databases. It's safe to call more than once
+func (d *TestDocumentDriver) Cleanup() {
+	d.mu.Lock()
+	stores := d.stores
+	admin := d.adminStore
+	d.stores = nil
+	d.adminStore = nil
+	d.mu.Unlock()
+
+	for _, store := range stores {
+		name := store.GetDatabase()
+		store.Close()
+		if admin != nil {
+			op := ravendb.NewDeleteDatabasesOperation(name, true)
+			if err := admin.Maintenance().Server().Send(op); err != nil {
+				d.t.Logf("testdriver: failed to delete database '%s': %s", name, err)
+			}
+		}
+	}
+	if admin != nil {
+		admin.Close()
+	}
+}
+
+func (d *TestDocumentDriver) getAdminStore() (*ravendb.DocumentStore, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.adminStore != nil {
+		return d.adminStore, nil
+	}
+	if len(d.urls) == 0 {
+		return nil, fmt.Errorf("no server urls given")
+	}
+
+	// admin store is only used to create and delete databases
+	// so we don't want cluster behavior
+	store := d.newStore("")
+	store.GetConventions().SetDisableTopologyUpdates(true)
+	if err := store.Initialize(); err != nil {
+		store.Close()
+		return nil, err
+	}
+	d.adminStore = store
+	return store, nil
+}
+
+func (d *TestDocumentDriver) newStore(database string) *ravendb.DocumentStore {
+	store := ravendb.NewDocumentStore(d.urls, database)
+	store.Certificate = d.Certificate
+	store.TrustStore = d.TrustStore
+	return store
+}
+
+func (d *TestDocumentDriver) deleteDatabase(name string) {
+	d.mu.Lock()
+	admin := d.adminStore
+	d.mu.Unlock()
+	if admin == nil {
+		return
+	}
+	op := ravendb.NewDeleteDatabasesOperation(name, true)
+	_ = admin.Maintenance().Server().Send(op)
+}
+
+// newDatabaseName returns <TestName>_<timestamp>. Timestamps are
+// nanoseconds and are bumped if two databases are created within
+// the same tick, so names are unique per driver
+func (d *TestDocumentDriver) newDatabaseName() string {
+	d.mu.Lock()
+	stamp := time.Now().UnixNano()
+	if stamp <= d.lastStamp {
+		stamp = d.lastStamp + 1
+	}
+	d.lastStamp = stamp
+	d.mu.Unlock()
+
+	return databaseNameForTest(d.t.Name()) + "_" + strconv.FormatInt(stamp, 10)
+}
+
+// databaseNameForTest converts a test name to a valid database name.
+// Sub-test names contain '/' and spaces are turned into '_' by go test,
+// neither of which RavenDB accepts, so everything outside of
+// letters, digits, '_', '-' and '.' is replaced with '_'
+func databaseNameForTest(testName string) string {
+	var sb strings.Builder
+	for _, c := range testName {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9', c == '_', c == '-', c == '.':
+			sb.WriteRune(c)
+		default:
+			sb.WriteRune('_')
+		}
+	}
+	return sb.String()
+}
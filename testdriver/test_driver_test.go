@@ -0,0 +1,24 @@
+package testdriver
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDatabaseNameForTest(t *testing.T) {
+	assert.Equal(t, "TestFoo", databaseNameForTest("TestFoo"))
+	assert.Equal(t, "TestFoo_sub_test_1.2-x", databaseNameForTest("TestFoo/sub_test#1.2-x"))
+}
+
+func TestNewDatabaseNameIsUnique(t *testing.T) {
+	d := NewTestDocumentDriver(t, "http://127.0.0.1:8080")
+	seen := map[string]bool{}
+	for i := 0; i < 100; i++ {
+		name := d.newDatabaseName()
+		assert.True(t, strings.HasPrefix(name, "TestNewDatabaseNameIsUnique_"))
+		assert.False(t, seen[name], "duplicate name %s", name)
+		seen[name] = true
+	}
+}
@@ -627,6 +627,65 @@ type ItemsOrder struct {
 	At    ravendb.Time `json:"at"`
 }
 
+func goAggregationExecuteMultiFacets(t *testing.T, driver *RavenTestDriver) {
+	var err error
+	store := driver.getDocumentStoreMust(t)
+	defer store.Close()
+
+	index := NewOrdersAll()
+	err = index.Execute(store, nil, "")
+	assert.NoError(t, err)
+
+	{
+		session := openSessionMust(t, store)
+		orders := []*AggOrder{
+			{Currency: EUR, Product: "Milk", Total: 3, Region: 1},
+			{Currency: NIS, Product: "Milk", Total: 9, Region: 1},
+			{Currency: EUR, Product: "iPhone", Total: 3333, Region: 2},
+		}
+		for _, order := range orders {
+			err = session.Store(order)
+			assert.NoError(t, err)
+		}
+		err = session.SaveChanges()
+		assert.NoError(t, err)
+		session.Close()
+	}
+
+	err = driver.waitForIndexing(store, "", 0)
+	assert.NoError(t, err)
+
+	{
+		session := openSessionMust(t, store)
+
+		f1 := ravendb.NewFacetBuilder()
+		f1.ByField("product")
+		q1 := session.QueryIndex(index.IndexName).AggregateByFacet(f1.GetFacet())
+
+		f2 := ravendb.NewFacetBuilder()
+		f2.ByField("currency").SumOn("total")
+		q2 := session.QueryIndex(index.IndexName).WhereEquals("region", 1).AggregateByFacet(f2.GetFacet())
+
+		nRequests := session.Advanced().GetNumberOfRequests()
+		results, err := session.Advanced().ExecuteMultiFacets(q1, q2)
+		assert.NoError(t, err)
+		assert.Equal(t, nRequests+1, session.Advanced().GetNumberOfRequests())
+		assert.Equal(t, 2, len(results))
+
+		products := results[0]["product"]
+		assert.Equal(t, 2, len(products.Values))
+		assert.Equal(t, 2, getFirstFacetValueOfRange(products.Values, "milk").Count)
+		assert.Equal(t, 1, getFirstFacetValueOfRange(products.Values, "iphone").Count)
+
+		currencies := results[1]["currency"]
+		assert.Equal(t, 2, len(currencies.Values))
+		assert.Equal(t, float64(3), *getFirstFacetValueOfRange(currencies.Values, "eur").Sum)
+		assert.Equal(t, float64(9), *getFirstFacetValueOfRange(currencies.Values, "nis").Sum)
+
+		session.Close()
+	}
+}
+
 func NewItemsOrdersAll() *ravendb.IndexCreationTask {
 	res := ravendb.NewIndexCreationTask("ItemsOrders_All")
 	res.Map = "docs.ItemsOrders.Select(order => new { order.at,\n" +
@@ -649,4 +708,5 @@ func TestAggregation(t *testing.T) {
 
 	// tests unique to go
 	goAggregationIsGreaterThanAndIsLessThanOrEqualTo(t, driver)
+	goAggregationExecuteMultiFacets(t, driver)
 }
@@ -0,0 +1,89 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// waitAsync returns the result of an async operation, failing the test
+// if it doesn't arrive in time
+func waitAsync(t *testing.T, ch <-chan error) error {
+	select {
+	case err := <-ch:
+		return err
+	case <-time.After(30 * time.Second):
+		t.Fatal("async operation didn't finish")
+		return nil
+	}
+}
+
+func asyncDocumentSessionTestStoreAndLoad(t *testing.T, driver *RavenTestDriver) {
+	store := driver.getDocumentStoreMust(t)
+	defer store.Close()
+	ctx := context.Background()
+	var id string
+
+	{
+		session, err := store.OpenAsyncSession("")
+		assert.NoError(t, err)
+
+		user := &User{}
+		user.setName("John")
+		user.Age = 33
+		storeCh := session.StoreAsync(ctx, user)
+		saveCh := session.SaveChangesAsync(ctx)
+
+		assert.NoError(t, waitAsync(t, storeCh))
+		assert.NoError(t, waitAsync(t, saveCh))
+		assert.NotEmpty(t, user.ID)
+		id = user.ID
+
+		var loaded *User
+		err = waitAsync(t, session.LoadAsync(ctx, user.ID, &loaded))
+		assert.NoError(t, err)
+		// loading entity already tracked by the session
+		assert.True(t, loaded == user)
+		session.Close()
+	}
+
+	{
+		session, err := store.OpenAsyncSession("")
+		assert.NoError(t, err)
+
+		var loaded *User
+		err = waitAsync(t, session.LoadAsync(ctx, id, &loaded))
+		assert.NoError(t, err)
+		assert.Equal(t, "John", *loaded.Name)
+		assert.Equal(t, 33, loaded.Age)
+
+		var users []*User
+		var count int
+		q := session.QueryAsync(userType)
+		q.WaitForNonStaleResults(0)
+		q.WhereEquals("age", 33)
+		resultsCh := q.GetResultsAsync(ctx, &users)
+		countCh := q.CountAsync(ctx, &count)
+		assert.NoError(t, waitAsync(t, resultsCh))
+		assert.NoError(t, waitAsync(t, countCh))
+		assert.Equal(t, 1, len(users))
+		assert.Equal(t, 1, count)
+
+		cancelled, cancel := context.WithCancel(ctx)
+		cancel()
+		err = waitAsync(t, session.LoadAsync(cancelled, id, &loaded))
+		assert.Equal(t, context.Canceled, err)
+
+		session.Close()
+	}
+}
+
+func TestAsyncDocumentSession(t *testing.T) {
+	driver := createTestDriver(t)
+	destroy := func() { destroyDriver(t, driver) }
+	defer recoverTest(t, destroy)
+
+	asyncDocumentSessionTestStoreAndLoad(t, driver)
+}
@@ -0,0 +1,161 @@
+package tests
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ravendb/ravendb-go-client"
+	"github.com/stretchr/testify/assert"
+)
+
+func backupTestCanStartBackupAndGetStatus(t *testing.T, driver *RavenTestDriver) {
+	store := driver.getDocumentStoreMust(t)
+	defer store.Close()
+
+	{
+		session := openSessionMust(t, store)
+		user := &User{}
+		user.setName("John")
+		err := session.Store(user)
+		assert.NoError(t, err)
+		err = session.SaveChanges()
+		assert.NoError(t, err)
+		session.Close()
+	}
+
+	dir, err := ioutil.TempDir("", "ravendb-backup")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	config := &ravendb.PeriodicBackupConfiguration{
+		Name:       "manual",
+		BackupType: ravendb.BackupTypeBackup,
+		LocalSettings: &ravendb.LocalSettings{
+			FolderPath: dir,
+		},
+		// effectively never runs on its own
+		FullBackupFrequency: "0 0 1 1 *",
+	}
+	updateOp := ravendb.NewUpdatePeriodicBackupOperation(config)
+	err = store.Maintenance().Send(updateOp)
+	assert.NoError(t, err)
+	taskID := updateOp.Command.Result.TaskID
+	assert.True(t, taskID > 0)
+
+	statusOp := ravendb.NewGetPeriodicBackupStatusOperation(taskID)
+	err = store.Maintenance().Send(statusOp)
+	assert.NoError(t, err)
+	assert.Nil(t, statusOp.Command.Result)
+
+	op, err := store.Maintenance().SendAsync(ravendb.NewStartBackupOperation(true, taskID))
+	assert.NoError(t, err)
+	err = op.WaitForCompletion()
+	assert.NoError(t, err)
+
+	// status is updated after the backup operation completes
+	var status *ravendb.PeriodicBackupStatus
+	for i := 0; i < 50; i++ {
+		statusOp = ravendb.NewGetPeriodicBackupStatusOperation(taskID)
+		err = store.Maintenance().Send(statusOp)
+		assert.NoError(t, err)
+		status = statusOp.Command.Result
+		if status != nil && status.LastFullBackup != nil {
+			break
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	assert.NotNil(t, status)
+	assert.NotNil(t, status.LastFullBackup)
+	assert.Equal(t, taskID, status.TaskID)
+	assert.Empty(t, status.Error)
+}
+
+func backupTestCanRestoreBackup(t *testing.T, driver *RavenTestDriver) {
+	store := driver.getDocumentStoreMust(t)
+	defer store.Close()
+
+	{
+		session := openSessionMust(t, store)
+		for i := 0; i < 3; i++ {
+			user := &User{}
+			user.setName("John")
+			err := session.Store(user)
+			assert.NoError(t, err)
+		}
+		err := session.SaveChanges()
+		assert.NoError(t, err)
+		session.Close()
+	}
+
+	dir, err := ioutil.TempDir("", "ravendb-backup")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	config := &ravendb.PeriodicBackupConfiguration{
+		Name:       "manual",
+		BackupType: ravendb.BackupTypeBackup,
+		LocalSettings: &ravendb.LocalSettings{
+			FolderPath: dir,
+		},
+		FullBackupFrequency: "0 0 1 1 *",
+	}
+	updateOp := ravendb.NewUpdatePeriodicBackupOperation(config)
+	err = store.Maintenance().Send(updateOp)
+	assert.NoError(t, err)
+
+	op, err := store.Maintenance().SendAsync(ravendb.NewStartBackupOperation(true, updateOp.Command.Result.TaskID))
+	assert.NoError(t, err)
+	err = op.WaitForCompletion()
+	assert.NoError(t, err)
+
+	// each backup run creates a sub-directory of FolderPath
+	entries, err := ioutil.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(entries))
+	backupLocation := filepath.Join(dir, entries[0].Name())
+
+	restoredName := store.GetDatabase() + "_restored"
+	restoreOp := ravendb.NewRestoreBackupOperation(&ravendb.RestoreBackupConfiguration{
+		DatabaseName:   restoredName,
+		BackupLocation: backupLocation,
+	})
+	op, err = store.Maintenance().Server().SendAsync(restoreOp)
+	assert.NoError(t, err)
+	err = op.WaitForCompletion()
+	assert.NoError(t, err)
+	defer func() {
+		_ = store.Maintenance().Server().Send(ravendb.NewDeleteDatabasesOperation(restoredName, true))
+	}()
+
+	{
+		session, err := store.OpenSessionWithOptions(&ravendb.SessionOptions{
+			Database: restoredName,
+		})
+		assert.NoError(t, err)
+		var users []*User
+		err = session.QueryCollectionForType(reflect.TypeOf(&User{})).GetResults(&users)
+		assert.NoError(t, err)
+		assert.Equal(t, 3, len(users))
+		session.Close()
+	}
+
+	// restoring into an existing database fails
+	op, err = store.Maintenance().Server().SendAsync(restoreOp)
+	if err == nil {
+		err = op.WaitForCompletion()
+	}
+	assert.Error(t, err)
+}
+
+func TestBackup(t *testing.T) {
+	driver := createTestDriver(t)
+	destroy := func() { destroyDriver(t, driver) }
+	defer recoverTest(t, destroy)
+
+	backupTestCanStartBackupAndGetStatus(t, driver)
+	backupTestCanRestoreBackup(t, driver)
+}
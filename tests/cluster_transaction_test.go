@@ -0,0 +1,123 @@
+package tests
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ravendb/ravendb-go-client"
+	"github.com/stretchr/testify/assert"
+)
+
+func openClusterWideSessionMust(t *testing.T, store *ravendb.DocumentStore) *ravendb.DocumentSession {
+	session, err := store.OpenSessionWithOptions(&ravendb.SessionOptions{
+		TransactionMode: ravendb.TransactionModeClusterWide,
+	})
+	assert.NoError(t, err)
+	return session
+}
+
+func clusterTransactionTestCanCreateClusterTransactionRequest(t *testing.T, driver *RavenTestDriver) {
+	store := driver.getDocumentStoreMust(t)
+	defer store.Close()
+
+	{
+		session := openClusterWideSessionMust(t, store)
+		user := &User{}
+		user.setName("Karmel")
+		err := session.StoreWithID(user, "users/1")
+		assert.NoError(t, err)
+		err = session.Advanced().ClusterTransaction().CreateCompareExchangeValue("usernames/ayende", "users/1")
+		assert.NoError(t, err)
+		err = session.SaveChanges()
+		assert.NoError(t, err)
+		assert.Equal(t, 0, session.Advanced().ClusterTransaction().NumberOfStagedOperations())
+
+		value, err := session.Advanced().ClusterTransaction().GetCompareExchangeValue(reflect.TypeOf(""), "usernames/ayende")
+		assert.NoError(t, err)
+		assert.Equal(t, "users/1", value.Value)
+		session.Close()
+	}
+
+	{
+		op, err := ravendb.NewGetCompareExchangeValueOperation(reflect.TypeOf(""), "usernames/ayende")
+		assert.NoError(t, err)
+		err = store.Operations().Send(op, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, "users/1", op.Command.Result.Value)
+	}
+
+	{
+		// the key is already taken so the whole transaction must fail
+		session := openClusterWideSessionMust(t, store)
+		user := &User{}
+		user.setName("Grisha")
+		err := session.StoreWithID(user, "users/2")
+		assert.NoError(t, err)
+		err = session.Advanced().ClusterTransaction().CreateCompareExchangeValue("usernames/ayende", "users/2")
+		assert.NoError(t, err)
+		err = session.SaveChanges()
+		assert.Error(t, err)
+		session.Close()
+	}
+
+	{
+		session := openSessionMust(t, store)
+		var user *User
+		err := session.Load(&user, "users/2")
+		assert.NoError(t, err)
+		assert.Nil(t, user)
+		session.Close()
+	}
+}
+
+func clusterTransactionTestCanIncludeCompareExchangeValuesInQuery(t *testing.T, driver *RavenTestDriver) {
+	store := driver.getDocumentStoreMust(t)
+	defer store.Close()
+
+	{
+		session := openClusterWideSessionMust(t, store)
+		user := &User{}
+		user.setName("Karmel")
+		user.setLastName("usernames/karmel")
+		err := session.StoreWithID(user, "users/1")
+		assert.NoError(t, err)
+		err = session.Advanced().ClusterTransaction().CreateCompareExchangeValue("usernames/karmel", "users/1")
+		assert.NoError(t, err)
+		err = session.SaveChanges()
+		assert.NoError(t, err)
+		session.Close()
+	}
+
+	{
+		session := openClusterWideSessionMust(t, store)
+		var users []*User
+		q := session.QueryCollectionForType(reflect.TypeOf(&User{}))
+		q = q.WaitForNonStaleResults(0).IncludeCounters("likes").IncludeCompareExchangeValue("lastName")
+		err := q.GetResults(&users)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(users))
+		assert.Equal(t, 1, session.Advanced().GetNumberOfRequests())
+
+		// included values are read without going to the server
+		value, err := session.Advanced().ClusterTransaction().GetCompareExchangeValue(reflect.TypeOf(""), "usernames/karmel")
+		assert.NoError(t, err)
+		assert.NotNil(t, value)
+		assert.Equal(t, "users/1", value.Value)
+		counters, err := session.Advanced().GetIncludedCountersFor(users[0])
+		assert.NoError(t, err)
+		assert.Empty(t, counters)
+		assert.Equal(t, 1, session.Advanced().GetNumberOfRequests())
+		session.Close()
+	}
+}
+
+func TestClusterTransaction(t *testing.T) {
+	driver := createTestDriver(t)
+	destroy := func() { destroyDriver(t, driver) }
+	defer recoverTest(t, destroy)
+
+	clusterTransactionTestCanCreateClusterTransactionRequest(t, driver)
+
+	// tests not ported from Java
+	clusterTransactionTestCanIncludeCompareExchangeValuesInQuery(t, driver)
+}
@@ -712,6 +712,86 @@ func crudTestCrudCanUpdatePropertyFromNullToObject(t *testing.T, driver *RavenTe
 	}
 }
 
+func crudTestStoredDocumentHasDefaultMetadata(t *testing.T, driver *RavenTestDriver) {
+	var err error
+	store := driver.getDocumentStoreMust(t)
+	defer store.Close()
+
+	{
+		session := openSessionMust(t, store)
+		user := &User{}
+		user.setName("John")
+		err = session.StoreWithID(user, "users/1")
+		assert.NoError(t, err)
+		err = session.SaveChanges()
+		assert.NoError(t, err)
+		session.Close()
+	}
+
+	{
+		session := openSessionMust(t, store)
+		var user *User
+		err = session.Load(&user, "users/1")
+		assert.NoError(t, err)
+		metadata, err := session.GetMetadataFor(user)
+		assert.NoError(t, err)
+		collection, _ := metadata.Get(ravendb.MetadataCollection)
+		assert.Equal(t, "Users", collection)
+		id, _ := metadata.Get(ravendb.MetadataID)
+		assert.Equal(t, "users/1", id)
+		changeVector, _ := metadata.Get(ravendb.MetadataChangeVector)
+		assert.NotEmpty(t, changeVector)
+		session.Close()
+	}
+}
+
+func crudTestCanModifyRawDocument(t *testing.T, driver *RavenTestDriver) {
+	var err error
+	store := driver.getDocumentStoreMust(t)
+	defer store.Close()
+
+	{
+		session := openSessionMust(t, store)
+		user := &User{}
+		user.setName("John")
+		user.Age = 30
+		err = session.StoreWithID(user, "users/1")
+		assert.NoError(t, err)
+		err = session.SaveChanges()
+		assert.NoError(t, err)
+		session.Close()
+	}
+
+	{
+		session := openSessionMust(t, store)
+		var user *User
+		err = session.Load(&user, "users/1")
+		assert.NoError(t, err)
+
+		raw, ok := session.Advanced().GetRawEntityByID("users/1")
+		assert.True(t, ok)
+		assert.Equal(t, "John", raw["name"])
+		raw["name"] = "Jane"
+		err = session.Advanced().SetRawEntityByID("users/1", raw)
+		assert.NoError(t, err)
+		assert.Equal(t, "Jane", *user.Name)
+
+		err = session.SaveChanges()
+		assert.NoError(t, err)
+		session.Close()
+	}
+
+	{
+		session := openSessionMust(t, store)
+		var user *User
+		err = session.Load(&user, "users/1")
+		assert.NoError(t, err)
+		assert.Equal(t, "Jane", *user.Name)
+		assert.Equal(t, 30, user.Age)
+		session.Close()
+	}
+}
+
 func TestCrud(t *testing.T) {
 	driver := createTestDriver(t)
 	destroy := func() { destroyDriver(t, driver) }
@@ -731,4 +811,8 @@ func TestCrud(t *testing.T) {
 	crudTestCrudOperationsWithArrayInObject3(t, driver)
 	crudTestCrudOperationsWithArrayInObject4(t, driver)
 	crudTestCrudOperationsWithArrayOfArrays(t, driver)
+
+	// tests not ported from Java
+	crudTestStoredDocumentHasDefaultMetadata(t, driver)
+	crudTestCanModifyRawDocument(t, driver)
 }
@@ -1,11 +1,13 @@
 package tests
 
 import (
+	"encoding/json"
 	"fmt"
 	"reflect"
 	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	ravendb "github.com/ravendb/ravendb-go-client"
 	"github.com/stretchr/testify/assert"
@@ -118,6 +120,92 @@ func (m *Money) UnmarshalJSON(d []byte) error {
 	return nil
 }
 
+type TimedEvent struct {
+	ID   string
+	Name string
+	At   time.Time
+}
+
+// unixTimeSerializer stores TimedEvent.At as Unix timestamp
+type unixTimeSerializer struct{}
+
+type timedEventJSON struct {
+	Name string `json:"name"`
+	At   int64  `json:"at"`
+}
+
+func (unixTimeSerializer) Marshal(v interface{}) ([]byte, error) {
+	if e, ok := v.(*TimedEvent); ok {
+		return json.Marshal(timedEventJSON{Name: e.Name, At: e.At.Unix()})
+	}
+	return json.Marshal(v)
+}
+
+func (unixTimeSerializer) Unmarshal(d []byte, v interface{}) error {
+	if e, ok := v.(*TimedEvent); ok {
+		var ej timedEventJSON
+		if err := json.Unmarshal(d, &ej); err != nil {
+			return err
+		}
+		e.Name = ej.Name
+		e.At = time.Unix(ej.At, 0).UTC()
+		return nil
+	}
+	return json.Unmarshal(d, v)
+}
+
+func customSerializationTestCustomJSONSerializer(t *testing.T, driver *RavenTestDriver) {
+	var err error
+	mainStore := driver.getDocumentStoreMust(t)
+	defer mainStore.Close()
+
+	// conventions must be set before the store is initialized
+	store := ravendb.NewDocumentStore(mainStore.GetUrls(), mainStore.GetDatabase())
+	store.GetConventions().JSONSerializer = unixTimeSerializer{}
+	err = store.Initialize()
+	assert.NoError(t, err)
+	defer store.Close()
+
+	at := time.Date(2019, 3, 4, 5, 6, 7, 0, time.UTC)
+
+	{
+		session := openSessionMust(t, store)
+		event := &TimedEvent{
+			Name: "launch",
+			At:   at,
+		}
+		err = session.StoreWithID(event, "timedEvents/1")
+		assert.NoError(t, err)
+		err = session.SaveChanges()
+		assert.NoError(t, err)
+		session.Close()
+	}
+
+	// verify if value was serialized with custom serializer
+	{
+		command, err := ravendb.NewGetDocumentsCommand([]string{"timedEvents/1"}, nil, false)
+		assert.NoError(t, err)
+		err = store.GetRequestExecutor("").ExecuteCommand(command, nil)
+		assert.NoError(t, err)
+		eventJSON := command.Result.Results[0]
+		assert.Equal(t, float64(at.Unix()), eventJSON["at"])
+	}
+
+	{
+		session := openSessionMust(t, store)
+		var event *TimedEvent
+		err = session.Load(&event, "timedEvents/1")
+		assert.NoError(t, err)
+		assert.Equal(t, "launch", event.Name)
+		assert.True(t, at.Equal(event.At))
+
+		// de-serialization and serialization must round-trip
+		// or the entity would be considered modified
+		assert.False(t, session.Advanced().HasChanges())
+		session.Close()
+	}
+}
+
 func TestCustomSerialization(t *testing.T) {
 	driver := createTestDriver(t)
 	destroy := func() { destroyDriver(t, driver) }
@@ -125,4 +213,7 @@ func TestCustomSerialization(t *testing.T) {
 
 	// matches the order of Java tests
 	customSerializationTestSerialization(t, driver)
+
+	// tests not ported from Java
+	customSerializationTestCustomJSONSerializer(t, driver)
 }
@@ -0,0 +1,98 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/ravendb/ravendb-go-client"
+	"github.com/stretchr/testify/assert"
+)
+
+func deferredCommandsTestPutAndDelete(t *testing.T, driver *RavenTestDriver) {
+	var err error
+	store := driver.getDocumentStoreMust(t)
+	defer store.Close()
+
+	{
+		session := openSessionMust(t, store)
+		user := &User{}
+		user.setName("John")
+		err = session.StoreWithID(user, "users/1")
+		assert.NoError(t, err)
+		err = session.SaveChanges()
+		assert.NoError(t, err)
+		session.Close()
+	}
+
+	newUserDoc := func(name string) map[string]interface{} {
+		return map[string]interface{}{
+			"name": name,
+			"@metadata": map[string]interface{}{
+				"@collection": "Users",
+			},
+		}
+	}
+
+	{
+		// a put with a mismatched change vector fails the whole batch
+		session := openSessionMust(t, store)
+		session.Advanced().Defer(
+			ravendb.NewPutCommandData("users/2", "A:1-bogus", newUserDoc("Jane")),
+			ravendb.NewDeleteCommandData("users/1", ""),
+		)
+		err = session.SaveChanges()
+		assert.Error(t, err)
+		_, ok := err.(*ravendb.ConcurrencyError)
+		assert.True(t, ok)
+		session.Close()
+	}
+
+	{
+		session := openSessionMust(t, store)
+		var user *User
+		err = session.Load(&user, "users/1")
+		assert.NoError(t, err)
+		assert.NotNil(t, user)
+		session.Close()
+	}
+
+	{
+		session := openSessionMust(t, store)
+		patch := &ravendb.PatchRequest{
+			Script: "this.age = args.age",
+			Values: map[string]interface{}{"age": 31},
+		}
+		session.Advanced().Defer(
+			ravendb.NewPutCommandData("users/2", "", newUserDoc("Jane")),
+			ravendb.NewDeleteCommandData("users/1", ""),
+			ravendb.NewPatchCommandDataSimple("users/2", "", patch),
+		)
+		assert.Equal(t, 3, session.GetDeferredCommandsCount())
+		err = session.SaveChanges()
+		assert.NoError(t, err)
+		assert.Equal(t, 1, session.Advanced().GetNumberOfRequests())
+		session.Close()
+	}
+
+	{
+		session := openSessionMust(t, store)
+		var user *User
+		err = session.Load(&user, "users/1")
+		assert.NoError(t, err)
+		assert.Nil(t, user)
+
+		err = session.Load(&user, "users/2")
+		assert.NoError(t, err)
+		assert.NotNil(t, user)
+		assert.Equal(t, "Jane", *user.Name)
+		assert.Equal(t, 31, user.Age)
+		session.Close()
+	}
+}
+
+func TestDeferredCommands(t *testing.T) {
+	driver := createTestDriver(t)
+	destroy := func() { destroyDriver(t, driver) }
+	defer recoverTest(t, destroy)
+
+	deferredCommandsTestPutAndDelete(t, driver)
+}
@@ -114,6 +114,46 @@ func deleteByQueryCanDeleteByQueryWaitUsingChanges(t *testing.T, driver *RavenTe
 	}
 }
 
+func deleteByQueryCanDeleteByJSQuery(t *testing.T, driver *RavenTestDriver) {
+	var err error
+	store := driver.getDocumentStoreMust(t)
+	defer store.Close()
+
+	{
+		session := openSessionMust(t, store)
+		for i := 0; i < 10; i++ {
+			user := &User{
+				Age:   25 + i*2,
+				Count: i % 2,
+			}
+			err = session.Store(user)
+			assert.NoError(t, err)
+		}
+		err = session.SaveChanges()
+		assert.NoError(t, err)
+		session.Close()
+	}
+
+	// deletes users aged 33, 37 and 41
+	operation, err := ravendb.NewDeleteByJSQueryOperation("Users", "this.age > 30 && this.count === 0", nil)
+	assert.NoError(t, err)
+	asyncOp, err := store.Operations().SendAsync(operation, nil)
+	assert.NoError(t, err)
+	assert.NotNil(t, operation.Command.Result)
+
+	err = asyncOp.WaitForCompletion()
+	assert.NoError(t, err)
+
+	{
+		session := openSessionMust(t, store)
+		q := session.QueryCollectionForType(reflect.TypeOf(&User{}))
+		count, err := q.Count()
+		assert.NoError(t, err)
+		assert.Equal(t, 7, count)
+		session.Close()
+	}
+}
+
 func TestDeleteByQuery(t *testing.T) {
 	driver := createTestDriver(t)
 	destroy := func() { destroyDriver(t, driver) }
@@ -124,4 +164,7 @@ func TestDeleteByQuery(t *testing.T) {
 	deleteByQueryCanDeleteByQuery(t, driver)
 
 	deleteByQueryCanDeleteByQueryWaitUsingChanges(t, driver)
+
+	// tests not ported from Java
+	deleteByQueryCanDeleteByJSQuery(t, driver)
 }
@@ -237,6 +237,116 @@ func documentReplication_shouldCreateConflictThenResolveIt(t *testing.T, driver
 	}
 }
 
+// createConflict stores different versions of document id in source and
+// destination, then starts replication from source to destination
+func documentReplication_createConflict(t *testing.T, driver *RavenTestDriver, source, destination *ravendb.DocumentStore, id string) {
+	for i, store := range []*ravendb.DocumentStore{source, destination} {
+		session := openSessionMust(t, store)
+		user := &User{}
+		user.setName(fmt.Sprintf("Value%d", i))
+		err := session.StoreWithID(user, id)
+		assert.NoError(t, err)
+		err = session.SaveChanges()
+		assert.NoError(t, err)
+		session.Close()
+	}
+
+	driver.setupReplication(source, destination)
+
+	{
+		session := openSessionMust(t, source)
+		err := session.StoreWithID(&User{}, "marker")
+		assert.NoError(t, err)
+		err = session.SaveChanges()
+		assert.NoError(t, err)
+		session.Close()
+	}
+
+	var user *User
+	err := driver.waitForDocumentToReplicate(destination, &user, "marker", time.Second*10)
+	assert.NoError(t, err)
+}
+
+func documentReplication_canResolveConflictFromSession(t *testing.T, driver *RavenTestDriver) {
+	driver.customize = func(r *ravendb.DatabaseRecord) {
+		r.ConflictSolverConfig = &ravendb.ConflictSolver{
+			ResolveByCollection: map[string]*ravendb.ScriptResolver{},
+		}
+	}
+	defer func() {
+		driver.customize = nil
+	}()
+
+	var err error
+	source := driver.getDocumentStoreMust(t)
+	defer source.Close()
+
+	destination := driver.getDocumentStoreMust(t)
+	defer destination.Close()
+
+	documentReplication_createConflict(t, driver, source, destination, "docs/1")
+
+	{
+		session := openSessionMust(t, destination)
+
+		var user *User
+		err = session.Load(&user, "docs/1")
+		conflictErr, ok := err.(*ravendb.DocumentConflictError)
+		assert.True(t, ok)
+		if ok {
+			assert.Equal(t, "docs/1", conflictErr.DocID)
+			assert.Equal(t, 2, len(conflictErr.Conflicts))
+		}
+		session.Close()
+	}
+
+	{
+		// storing a merged version resolves the conflict
+		session := openSessionMust(t, destination)
+		merged := &User{}
+		merged.setName("Value0 and Value1")
+		err = session.StoreWithID(merged, "docs/1")
+		assert.NoError(t, err)
+		err = session.SaveChanges()
+		assert.NoError(t, err)
+		session.Close()
+	}
+
+	{
+		session := openSessionMust(t, destination)
+		var user *User
+		err = session.Load(&user, "docs/1")
+		assert.NoError(t, err)
+		assert.Equal(t, "Value0 and Value1", *user.Name)
+		session.Close()
+	}
+}
+
+func documentReplication_canModifyConflictSolver(t *testing.T, driver *RavenTestDriver) {
+	var err error
+	source := driver.getDocumentStoreMust(t)
+	defer source.Close()
+
+	destination := driver.getDocumentStoreMust(t)
+	defer destination.Close()
+
+	op, err := ravendb.NewModifyConflictSolverOperation(destination.GetDatabase(), nil, true)
+	assert.NoError(t, err)
+	err = destination.Maintenance().Server().Send(op)
+	assert.NoError(t, err)
+	assert.True(t, op.Command.Result.Solver.ResolveToLatest)
+
+	documentReplication_createConflict(t, driver, source, destination, "docs/1")
+
+	// the conflict is resolved by the server
+	session := openSessionMust(t, destination)
+	var user *User
+	err = session.Load(&user, "docs/1")
+	assert.NoError(t, err)
+	assert.NotNil(t, user)
+	session.Close()
+}
+
 func enableReplicationTests() bool {
 	if os.Getenv("RAVEN_License") != "" {
 		return true
@@ -261,4 +371,8 @@ func TestDocumentReplication(t *testing.T) {
 	documentReplication_canReplicateDocument(t, driver)
 	documentReplication_getConflictsResult_command_should_work_properly(t, driver)
 	documentReplication_shouldCreateConflictThenResolveIt(t, driver)
+
+	// tests not ported from Java
+	documentReplication_canResolveConflictFromSession(t, driver)
+	documentReplication_canModifyConflictSolver(t, driver)
 }
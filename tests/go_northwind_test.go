@@ -1,6 +1,7 @@
 package tests
 
 import (
+	"errors"
 	"github.com/ravendb/ravendb-go-client"
 	"github.com/ravendb/ravendb-go-client/examples/northwind"
 	"github.com/stretchr/testify/assert"
@@ -63,7 +64,7 @@ func goNorthwindWhereBetween(t *testing.T, driver *RavenTestDriver) {
 	assert.True(t, len(results) > 5) // it's 35 currently
 }
 
-// First() should allow zero results
+// FirstOrDefault() should allow zero results, First() returns ErrNoResults
 // https://github.com/ravendb/ravendb-go-client/issues/148
 func goNorthwindIssue148(t *testing.T, driver *RavenTestDriver) {
 	var err error
@@ -79,9 +80,14 @@ func goNorthwindIssue148(t *testing.T, driver *RavenTestDriver) {
 	query := session.QueryCollectionForType(queriedType)
 	query = query.Where("FirstName", "==", "name-that-doesn't exists")
 	var result *northwind.Employee
-	err = query.First(&result)
+	found, err := query.FirstOrDefault(&result)
 	// no error, result not set
 	assert.NoError(t, err)
+	assert.False(t, found)
+	assert.Nil(t, result)
+
+	err = query.First(&result)
+	assert.True(t, errors.Is(err, ravendb.ErrNoResults))
 	assert.Nil(t, result)
 
 }
@@ -2,6 +2,7 @@ package tests
 
 import (
 	"testing"
+	"time"
 
 	ravendb "github.com/ravendb/ravendb-go-client"
 	"github.com/stretchr/testify/assert"
@@ -384,6 +385,96 @@ func testIndexCanGetIndexStatistics(t *testing.T, driver *RavenTestDriver) {
 	assert.Equal(t, len(indexStats), 1)
 }
 
+func testIndexDisabledIndexStatistics(t *testing.T, driver *RavenTestDriver) {
+	var err error
+	store := driver.getDocumentStoreMust(t)
+	defer store.Close()
+
+	index := NewUsersIndex()
+	err = index.Execute(store, nil, "")
+	assert.NoError(t, err)
+
+	getState := func() ravendb.IndexState {
+		op := ravendb.NewGetIndexStatisticsOperation("UsersIndex")
+		err := store.Maintenance().Send(op)
+		assert.NoError(t, err)
+		return op.Command.Result.State
+	}
+
+	disableOp, err := ravendb.NewDisableIndexOperation("UsersIndex")
+	assert.NoError(t, err)
+	err = store.Maintenance().Send(disableOp)
+	assert.NoError(t, err)
+	assert.Equal(t, ravendb.IndexStateDisabled, getState())
+
+	enableOp, err := ravendb.NewEnableIndexOperation("UsersIndex")
+	assert.NoError(t, err)
+	err = store.Maintenance().Send(enableOp)
+	assert.NoError(t, err)
+	assert.NotEqual(t, ravendb.IndexStateDisabled, getState())
+}
+
+func testIndexCanWaitForIndexesToBecomeNonStale(t *testing.T, driver *RavenTestDriver) {
+	var err error
+	store := driver.getDocumentStoreMust(t)
+	defer store.Close()
+
+	index := NewUsers_Index()
+	err = index.Execute(store, nil, "")
+	assert.NoError(t, err)
+
+	{
+		session := openSessionMust(t, store)
+		for i := 0; i < 10; i++ {
+			user := &User{}
+			user.setName("John")
+			err = session.Store(user)
+			assert.NoError(t, err)
+		}
+		err = session.SaveChanges()
+		assert.NoError(t, err)
+		session.Close()
+	}
+
+	err = store.Maintenance().WaitForIndexesToBecomeNonStale(time.Minute, "Users_Index")
+	assert.NoError(t, err)
+
+	{
+		session := openSessionMust(t, store)
+		var users []*User
+		var stats *ravendb.QueryStatistics
+		q := session.QueryIndex("Users_Index").Statistics(&stats).WhereEquals("name", "John")
+		err = q.GetResults(&users)
+		assert.NoError(t, err)
+		assert.Equal(t, 10, len(users))
+		assert.False(t, stats.IsStale)
+		session.Close()
+	}
+}
+
+func testIndexCanPutIndexFromDefinitionBuilder(t *testing.T, driver *RavenTestDriver) {
+	var err error
+	store := driver.getDocumentStoreMust(t)
+	defer store.Close()
+
+	def, err := ravendb.NewIndexDefinitionBuilder("Users/ByName").
+		Map("from u in docs.Users select new { u.name }").
+		Index("name", ravendb.FieldIndexingSearch).
+		Store("name", ravendb.FieldStorageYes).
+		ToIndexDefinition(store.GetConventions())
+	assert.NoError(t, err)
+	err = store.Maintenance().Send(ravendb.NewPutIndexesOperation(def))
+	assert.NoError(t, err)
+
+	op := ravendb.NewGetIndexOperation("Users/ByName")
+	err = store.Maintenance().Send(op)
+	assert.NoError(t, err)
+	field := op.Command.Result.Fields["name"]
+	assert.NotNil(t, field)
+	assert.Equal(t, ravendb.FieldIndexing(ravendb.FieldIndexingSearch), field.Indexing)
+	assert.Equal(t, ravendb.FieldStorageYes, field.Storage)
+}
+
 func TestIndexOperations(t *testing.T) {
 	driver := createTestDriver(t)
 	destroy := func() { destroyDriver(t, driver) }
@@ -402,4 +493,9 @@ func TestIndexOperations(t *testing.T) {
 	testIndexCanStopStartIndex(t, driver)
 	testIndexCanSetIndexLockMode(t, driver)
 	testIndexGetTerms(t, driver)
+
+	// tests not ported from Java
+	testIndexDisabledIndexStatistics(t, driver)
+	testIndexCanWaitForIndexesToBecomeNonStale(t, driver)
+	testIndexCanPutIndexFromDefinitionBuilder(t, driver)
 }
@@ -584,6 +584,121 @@ func PostsByTitleAndDesc() *ravendb.IndexCreationTask {
 	return res
 }
 
+func indexesFromClientTestCreateIndexesWithFullDefinition(t *testing.T, driver *RavenTestDriver) {
+	var err error
+	store := driver.getDocumentStoreMust(t)
+	defer store.Close()
+
+	index := ravendb.NewIndexCreationTask("Users_ByNameFull")
+	index.Map = "from u in docs.Users select new { u.name, u.lastName, u.age }"
+	index.Index("name", ravendb.FieldIndexingSearch)
+	index.Store("name", ravendb.FieldStorageYes)
+	index.Analyze("name", "StandardAnalyzer")
+	index.TermVector("name", ravendb.FieldTermVectorWithPositions)
+	index.Store("lastName", ravendb.FieldStorageYes)
+	index.Configuration = ravendb.IndexConfiguration{
+		"Indexing.MapTimeoutInSec": "30",
+	}
+
+	err = ravendb.CreateIndexes(store, index, NewUsers_ByName())
+	assert.NoError(t, err)
+
+	op := ravendb.NewGetIndexOperation("Users_ByNameFull")
+	err = store.Maintenance().Send(op)
+	assert.NoError(t, err)
+	def := op.Command.Result
+	name := def.Fields["name"]
+	assert.Equal(t, ravendb.FieldIndexingSearch, name.Indexing)
+	assert.Equal(t, ravendb.FieldStorageYes, name.Storage)
+	assert.Equal(t, "StandardAnalyzer", name.Analyzer)
+	assert.Equal(t, ravendb.FieldTermVectorWithPositions, name.TermVector)
+	assert.Equal(t, ravendb.FieldStorageYes, def.Fields["lastName"].Storage)
+	assert.Equal(t, "30", def.Configuration["Indexing.MapTimeoutInSec"])
+
+	// the server considers deployed definition unchanged
+	// so deploying again is a no-op
+	hasChanged := ravendb.NewIndexHasChangedOperation(index.CreateIndexDefinition())
+	err = store.Maintenance().Send(hasChanged)
+	assert.NoError(t, err)
+	assert.False(t, hasChanged.Command.Result)
+
+	statsOp := ravendb.NewGetIndexStatisticsOperation("Users_ByNameFull")
+	err = store.Maintenance().Send(statsOp)
+	assert.NoError(t, err)
+	created := statsOp.Command.Result.CreatedTimestamp
+
+	err = ravendb.CreateIndexes(store, index, NewUsers_ByName())
+	assert.NoError(t, err)
+	err = store.ExecuteIndex(index, "")
+	assert.NoError(t, err)
+
+	// the index was not re-created
+	statsOp = ravendb.NewGetIndexStatisticsOperation("Users_ByNameFull")
+	err = store.Maintenance().Send(statsOp)
+	assert.NoError(t, err)
+	assert.Equal(t, created, statsOp.Command.Result.CreatedTimestamp)
+}
+
+func indexesFromClientTestSideBySideReplacement(t *testing.T, driver *RavenTestDriver) {
+	var err error
+	store := driver.getDocumentStoreMust(t)
+	defer store.Close()
+
+	index := NewUsers_ByName()
+	err = store.ExecuteIndex(index, "")
+	assert.NoError(t, err)
+
+	{
+		session := openSessionMust(t, store)
+		for i := 0; i < 10; i++ {
+			user := &User{}
+			user.setName("John")
+			user.Age = i
+			err = session.Store(user)
+			assert.NoError(t, err)
+		}
+		err = session.SaveChanges()
+		assert.NoError(t, err)
+		session.Close()
+	}
+	err = driver.waitForIndexing(store, "", 0)
+	assert.NoError(t, err)
+
+	// changing definition of an existing index makes the server build
+	// ReplacementOf/NewUsers_ByName and swap it in when it's not stale
+	index.Map = "from u in docs.Users select new { u.name, u.age }"
+	err = store.ExecuteIndex(index, "")
+	assert.NoError(t, err)
+
+	err = store.Maintenance().WaitForIndexReplacement(index.IndexName, time.Minute)
+	assert.NoError(t, err)
+
+	namesOp := ravendb.NewGetIndexNamesOperation(0, 10)
+	err = store.Maintenance().Send(namesOp)
+	assert.NoError(t, err)
+	assert.Contains(t, namesOp.Command.Result, index.IndexName)
+	assert.NotContains(t, namesOp.Command.Result, ravendb.IndexReplacementName(index.IndexName))
+
+	getOp := ravendb.NewGetIndexOperation(index.IndexName)
+	err = store.Maintenance().Send(getOp)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{index.Map}, getOp.Command.Result.Maps)
+
+	// not being replaced
+	err = store.Maintenance().WaitForIndexReplacement(index.IndexName, time.Second)
+	assert.NoError(t, err)
+
+	// names of replacement indexes are reserved for the server
+	replacement := NewUsers_ByName()
+	replacement.IndexName = ravendb.IndexReplacementName(replacement.IndexName)
+	err = store.ExecuteIndex(replacement, "")
+	_, ok := err.(*ravendb.IllegalArgumentError)
+	assert.True(t, ok)
+	err = ravendb.CreateIndexes(store, replacement)
+	_, ok = err.(*ravendb.IllegalArgumentError)
+	assert.True(t, ok)
+}
+
 func TestIndexesFromClient(t *testing.T) {
 	driver := createTestDriver(t)
 	destroy := func() { destroyDriver(t, driver) }
@@ -602,4 +717,8 @@ func TestIndexesFromClient(t *testing.T) {
 
 	// TODO: order doesn't match Java
 	indexesFromClientTestCanCreateIndexesUsingIndexCreation(t, driver)
+
+	// tests not ported from Java
+	indexesFromClientTestCreateIndexesWithFullDefinition(t, driver)
+	indexesFromClientTestSideBySideReplacement(t, driver)
 }
@@ -323,6 +323,101 @@ func loadTestLoadStartsWith(t *testing.T, driver *RavenTestDriver) {
 	}
 }
 
+func loadTestLoadMetadataOnly(t *testing.T, driver *RavenTestDriver) {
+	var err error
+	store := driver.getDocumentStoreMust(t)
+	defer store.Close()
+
+	{
+		session := openSessionMust(t, store)
+		for i := 0; i < 1000; i++ {
+			user := &User{}
+			user.setName("Person " + strconv.Itoa(i))
+			err = session.StoreWithID(user, "users/"+strconv.Itoa(i))
+			assert.NoError(t, err)
+		}
+		err = session.SaveChanges()
+		assert.NoError(t, err)
+		session.Close()
+	}
+
+	{
+		// ids of missing documents make it 1500 ids, sent as POST request
+		var ids []string
+		for i := 0; i < 1500; i++ {
+			ids = append(ids, "users/"+strconv.Itoa(i))
+		}
+		session := openSessionMust(t, store)
+		metadata, err := session.Advanced().LoadMetadataOnly(ids)
+		assert.NoError(t, err)
+		assert.Equal(t, 1000, len(metadata))
+		m := metadata["users/77"]
+		assert.NotNil(t, m)
+		collection, _ := m.Get(ravendb.MetadataCollection)
+		assert.Equal(t, "Users", collection)
+		changeVector, _ := m.Get(ravendb.MetadataChangeVector)
+		assert.NotEmpty(t, changeVector)
+		assert.Nil(t, metadata["users/1200"])
+		assert.Equal(t, 0, session.GetNumberOfEntitiesInUnitOfWork())
+		session.Close()
+	}
+}
+
+func loadTestLoadAndDeleteIdsThatNeedEscaping(t *testing.T, driver *RavenTestDriver) {
+	var err error
+	store := driver.getDocumentStoreMust(t)
+	defer store.Close()
+
+	ids := []string{"people/Łukasz Müller/№5", "people/a+b&c=d", "people/50% off?"}
+	{
+		session := openSessionMust(t, store)
+		for _, id := range ids {
+			user := &User{}
+			user.setName(id)
+			err = session.StoreWithID(user, id)
+			assert.NoError(t, err)
+		}
+		err = session.SaveChanges()
+		assert.NoError(t, err)
+		session.Close()
+	}
+
+	{
+		session := openSessionMust(t, store)
+		for _, id := range ids {
+			var user *User
+			err = session.Load(&user, id)
+			assert.NoError(t, err)
+			assert.NotNil(t, user)
+			assert.Equal(t, id, user.ID)
+			assert.Equal(t, id, *user.Name)
+		}
+		err = session.DeleteByID(ids[0], "")
+		assert.NoError(t, err)
+		err = session.SaveChanges()
+		assert.NoError(t, err)
+		session.Close()
+	}
+
+	{
+		// the other documents are deleted without a session
+		for _, id := range ids[1:] {
+			cmd := ravendb.NewDeleteDocumentCommand(id, nil)
+			err = store.GetRequestExecutor("").ExecuteCommand(cmd, nil)
+			assert.NoError(t, err)
+		}
+
+		session := openSessionMust(t, store)
+		for _, id := range ids {
+			var user *User
+			err = session.Load(&user, id)
+			assert.NoError(t, err)
+			assert.Nil(t, user)
+		}
+		session.Close()
+	}
+}
+
 func TestLoad(t *testing.T) {
 	driver := createTestDriver(t)
 	destroy := func() { destroyDriver(t, driver) }
@@ -337,4 +432,8 @@ func TestLoad(t *testing.T) {
 	loadTestLoadMultiIdsWithNullShouldReturnDictionaryWithoutNulls(t, driver)
 	loadTestLoadDocumentWithIntArrayAndLongArray(t, driver)
 	loadTestLoadCanUseCache(t, driver)
+
+	// tests not ported from Java
+	loadTestLoadMetadataOnly(t, driver)
+	loadTestLoadAndDeleteIdsThatNeedEscaping(t, driver)
 }
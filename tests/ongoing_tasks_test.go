@@ -0,0 +1,52 @@
+package tests
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ravendb/ravendb-go-client"
+	"github.com/stretchr/testify/assert"
+)
+
+func ongoingTasksTestCanListSubscriptionTask(t *testing.T, driver *RavenTestDriver) {
+	store := driver.getDocumentStoreMust(t)
+	defer store.Close()
+
+	opts := &ravendb.SubscriptionCreationOptions{
+		Name: "UsersSubscription",
+	}
+	name, err := store.Subscriptions().CreateForType(reflect.TypeOf(&User{}), opts, "")
+	assert.NoError(t, err)
+	assert.Equal(t, "UsersSubscription", name)
+
+	op := ravendb.NewGetOngoingTasksOperation()
+	err = store.Maintenance().Send(op)
+	assert.NoError(t, err)
+	tasks := op.Command.Result
+	assert.Equal(t, 1, len(tasks.Subscriptions))
+	assert.Equal(t, 0, len(tasks.Backups))
+	assert.Equal(t, 0, len(tasks.ExternalReplications))
+
+	task := tasks.Subscriptions[0]
+	assert.Equal(t, name, task.TaskName)
+	assert.Equal(t, ravendb.OngoingTaskTypeSubscription, task.TaskType)
+	assert.Equal(t, ravendb.OngoingTaskStateEnabled, task.TaskState)
+	assert.NotNil(t, task.ResponsibleNode)
+
+	op2 := ravendb.NewGetSubscriptionTaskOperation(task.TaskID, task.TaskName)
+	err = store.Maintenance().Send(op2)
+	assert.NoError(t, err)
+	subscription := op2.Command.Result
+	assert.Equal(t, task.TaskID, subscription.TaskID)
+	assert.Equal(t, name, subscription.SubscriptionName)
+	assert.Contains(t, subscription.Query, "Users")
+	assert.False(t, subscription.Disabled)
+}
+
+func TestOngoingTasks(t *testing.T) {
+	driver := createTestDriver(t)
+	destroy := func() { destroyDriver(t, driver) }
+	defer recoverTest(t, destroy)
+
+	ongoingTasksTestCanListSubscriptionTask(t, driver)
+}
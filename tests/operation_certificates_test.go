@@ -0,0 +1,89 @@
+package tests
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ravendb/ravendb-go-client/serverwide/certificates"
+	"github.com/stretchr/testify/assert"
+)
+
+// newSelfSignedCertificate returns DER of a new self-signed certificate
+func newSelfSignedCertificate(t *testing.T, commonName string) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(24 * time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.NoError(t, err)
+	return der
+}
+
+func certificatesTestCanPutGetAndDeleteClientCertificate(t *testing.T, driver *RavenTestDriver) {
+	var err error
+
+	store := driver.getSecuredDocumentStoreMust(t)
+	defer store.Close()
+	if store.Certificate == nil {
+		t.Skip("This test requires a secured server.")
+	}
+
+	der := newSelfSignedCertificate(t, "go client test")
+	thumbprint := strings.ToUpper(fmt.Sprintf("%x", sha1.Sum(der)))
+
+	permissions := map[string]certificates.DatabaseAccess{
+		store.GetDatabase(): certificates.ReadWrite,
+	}
+	putOp := certificates.NewOperationPutCertificate("Go Client", der, permissions, certificates.ValidUser)
+	err = store.Maintenance().Server().Send(putOp)
+	assert.NoError(t, err)
+
+	getOp := certificates.NewOperationGetCertificate(thumbprint)
+	err = store.Maintenance().Server().Send(getOp)
+	assert.NoError(t, err)
+	cert := getOp.Certificate
+	assert.NotNil(t, cert)
+	assert.Equal(t, "Go Client", cert.Name)
+	assert.Equal(t, thumbprint, cert.Thumbprint)
+	assert.Equal(t, certificates.ValidUser, cert.SecurityClearance)
+	assert.Equal(t, certificates.ReadWrite, cert.Permissions[store.GetDatabase()])
+	assert.NotEmpty(t, cert.NotAfter)
+
+	err = store.Maintenance().Server().Send(certificates.NewOperationDeleteCertificate(thumbprint))
+	assert.NoError(t, err)
+
+	getOp = certificates.NewOperationGetCertificate(thumbprint)
+	err = store.Maintenance().Server().Send(getOp)
+	assert.NoError(t, err)
+	assert.Nil(t, getOp.Certificate)
+}
+
+func TestCertificates(t *testing.T) {
+	// self-signing cert on windows is not added as root ca so
+	// we can't run https tests
+	if isWindows() {
+		t.Skip("Skipping TestCertificates on windows")
+		return
+	}
+
+	driver := createTestDriver(t)
+	destroy := func() { destroyDriver(t, driver) }
+	defer recoverTest(t, destroy)
+
+	certificatesTestCanPutGetAndDeleteClientCertificate(t, driver)
+}
@@ -45,6 +45,68 @@ func patchTestcanPatchSingleDocument(t *testing.T, driver *RavenTestDriver) {
 	}
 }
 
+func patchTestCanPatchWithValuesAndPatchIfMissing(t *testing.T, driver *RavenTestDriver) {
+	var err error
+	store := driver.getDocumentStoreMust(t)
+	defer store.Close()
+
+	{
+		session := openSessionMust(t, store)
+		user := &User{}
+		user.Count = 1
+		err = session.StoreWithID(user, "users/1")
+		assert.NoError(t, err)
+		err = session.SaveChanges()
+		assert.NoError(t, err)
+		session.Close()
+	}
+
+	patchRequest := &ravendb.PatchRequest{
+		Script: `this.count += args.delta`,
+		Values: map[string]interface{}{
+			"delta": 2,
+		},
+	}
+	patchOperation, err := ravendb.NewPatchOperation("users/1", nil, patchRequest, nil, false)
+	assert.NoError(t, err)
+	patchResult, err := store.Operations().SendPatchOperation(patchOperation, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, ravendb.PatchStatusPatched, patchResult.Status)
+	var patched *User
+	err = patchResult.GetResult(&patched)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, patched.Count)
+
+	// missing document, without PatchIfMissing
+	patchOperation, err = ravendb.NewPatchOperation("users/2", nil, patchRequest, nil, false)
+	assert.NoError(t, err)
+	patchResult, err = store.Operations().SendPatchOperation(patchOperation, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, ravendb.PatchStatusDocumentDoesNotExist, patchResult.Status)
+
+	// missing document, with PatchIfMissing
+	patchIfMissing := &ravendb.PatchRequest{
+		Script: `this.count = args.start; this["@metadata"] = { "@collection": "Users" }`,
+		Values: map[string]interface{}{
+			"start": 10,
+		},
+	}
+	patchOperation, err = ravendb.NewPatchOperation("users/2", nil, patchRequest, patchIfMissing, false)
+	assert.NoError(t, err)
+	patchResult, err = store.Operations().SendPatchOperation(patchOperation, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, ravendb.PatchStatusCreated, patchResult.Status)
+
+	{
+		session := openSessionMust(t, store)
+		var loadedUser *User
+		err = session.Load(&loadedUser, "users/2")
+		assert.NoError(t, err)
+		assert.Equal(t, 10, loadedUser.Count)
+		session.Close()
+	}
+}
+
 func patchTestCanWaitForIndexAfterPatch(t *testing.T, driver *RavenTestDriver) {
 	var err error
 	store := driver.getDocumentStoreMust(t)
@@ -175,4 +237,5 @@ func TestPatch(t *testing.T) {
 
 	// TODO: not in order of Java
 	patchTestCanWaitForIndexAfterPatch(t, driver)
+	patchTestCanPatchWithValuesAndPatchIfMissing(t, driver)
 }
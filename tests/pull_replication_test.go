@@ -0,0 +1,48 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ravendb/ravendb-go-client"
+	"github.com/stretchr/testify/assert"
+)
+
+func pullReplicationTestCanDefineHub(t *testing.T, driver *RavenTestDriver) {
+	store := driver.getDocumentStoreMust(t)
+	defer store.Close()
+
+	definition := ravendb.NewPullReplicationDefinition("hub")
+	definition.DelayReplicationFor = ravendb.Duration(time.Minute)
+	err := store.Maintenance().Send(ravendb.NewPutPullReplicationDefinitionOperation(definition))
+	assert.NoError(t, err)
+	assert.True(t, definition.TaskID > 0)
+
+	op := ravendb.NewGetReplicationHubDefinitionsOperation()
+	err = store.Maintenance().Send(op)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(op.Command.Result))
+	hub := op.Command.Result[0]
+	assert.Equal(t, "hub", hub.Name)
+	assert.Equal(t, definition.TaskID, hub.TaskID)
+	assert.Equal(t, ravendb.Duration(time.Minute), hub.DelayReplicationFor)
+
+	// updating keeps the task
+	definition.Disabled = true
+	err = store.Maintenance().Send(ravendb.NewPutPullReplicationDefinitionOperation(definition))
+	assert.NoError(t, err)
+
+	op = ravendb.NewGetReplicationHubDefinitionsOperation()
+	err = store.Maintenance().Send(op)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(op.Command.Result))
+	assert.True(t, op.Command.Result[0].Disabled)
+}
+
+func TestPullReplication(t *testing.T) {
+	driver := createTestDriver(t)
+	destroy := func() { destroyDriver(t, driver) }
+	defer recoverTest(t, destroy)
+
+	pullReplicationTestCanDefineHub(t, driver)
+}
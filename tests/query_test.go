@@ -1,9 +1,11 @@
 package tests
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
 	"sort"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -624,6 +626,372 @@ func queryQuerySkipTake(t *testing.T, driver *RavenTestDriver) {
 	}
 }
 
+func queryQueryGetResultsWithStats(t *testing.T, driver *RavenTestDriver) {
+	var err error
+	store := driver.getDocumentStoreMust(t)
+	defer store.Close()
+
+	{
+		session := openSessionMust(t, store)
+		for i := 0; i < 20; i++ {
+			user := &User{}
+			user.setName(fmt.Sprintf("user%02d", i))
+			err = session.Store(user)
+			assert.NoError(t, err)
+		}
+		err = session.SaveChanges()
+		assert.NoError(t, err)
+		session.Close()
+	}
+
+	{
+		session := openSessionMust(t, store)
+
+		var users []*User
+		var stats *ravendb.QueryStatistics
+		q := session.QueryCollectionForType(userType)
+		q = q.WaitForNonStaleResults(0)
+		q = q.OrderBy("name")
+		q = q.Skip(10)
+		q = q.Take(5)
+		err = q.GetResultsWithStats(&users, &stats)
+		assert.NoError(t, err)
+
+		assert.Equal(t, 5, len(users))
+		assert.Equal(t, "user10", *users[0].Name)
+		assert.Equal(t, 20, stats.TotalResults)
+		assert.Equal(t, 0, stats.SkippedResults)
+
+		session.Close()
+	}
+}
+
+func queryQueryOrderByAlphaNumeric(t *testing.T, driver *RavenTestDriver) {
+	var err error
+	store := driver.getDocumentStoreMust(t)
+	defer store.Close()
+
+	{
+		session := openSessionMust(t, store)
+		for _, name := range []string{"file10", "file2", "file1"} {
+			user := &User{}
+			user.setName(name)
+			err = session.Store(user)
+			assert.NoError(t, err)
+		}
+		err = session.SaveChanges()
+		assert.NoError(t, err)
+		session.Close()
+	}
+
+	{
+		session := openSessionMust(t, store)
+
+		var users []*User
+		q := session.QueryCollectionForType(userType)
+		q = q.WaitForNonStaleResults(0)
+		q = q.OrderByWithOrdering("name", ravendb.OrderingTypeAlphaNumeric)
+		err = q.GetResults(&users)
+		assert.NoError(t, err)
+
+		assert.Equal(t, 3, len(users))
+		assert.Equal(t, "file1", *users[0].Name)
+		assert.Equal(t, "file2", *users[1].Name)
+		assert.Equal(t, "file10", *users[2].Name)
+
+		session.Close()
+	}
+}
+
+func queryQuerySelectJS(t *testing.T, driver *RavenTestDriver) {
+	var err error
+	store := driver.getDocumentStoreMust(t)
+	defer store.Close()
+
+	{
+		session := openSessionMust(t, store)
+		user := &User{}
+		user.setName("John")
+		user.setLastName("Doe")
+		user.Age = 3
+		err = session.Store(user)
+		assert.NoError(t, err)
+		err = session.SaveChanges()
+		assert.NoError(t, err)
+		session.Close()
+	}
+
+	type FullNameProjection struct {
+		FullName string
+		Initials string
+	}
+
+	{
+		session := openSessionMust(t, store)
+
+		var results []*FullNameProjection
+		q := session.QueryCollectionForType(userType)
+		q = q.WaitForNonStaleResults(0)
+		q = q.WhereEquals("age", 3)
+		q = q.SelectJS(reflect.TypeOf(&FullNameProjection{}), `{ FullName: x.name + " " + x.lastName, Initials: initials(x) }`,
+			`function initials(u) { return u.name[0] + u.lastName[0]; }`)
+		err = q.GetResults(&results)
+		assert.NoError(t, err)
+
+		assert.Equal(t, 1, len(results))
+		assert.Equal(t, "John Doe", results[0].FullName)
+		assert.Equal(t, "JD", results[0].Initials)
+
+		session.Close()
+	}
+}
+
+func queryQueryProjectUsing(t *testing.T, driver *RavenTestDriver) {
+	var err error
+	store := driver.getDocumentStoreMust(t)
+	defer store.Close()
+
+	{
+		session := openSessionMust(t, store)
+		user := &User{}
+		user.setName("John")
+		user.setLastName("Doe")
+		err = session.Store(user)
+		assert.NoError(t, err)
+		err = session.SaveChanges()
+		assert.NoError(t, err)
+		session.Close()
+	}
+
+	type FullNameProjection struct {
+		FullName string
+	}
+
+	{
+		session := openSessionMust(t, store)
+
+		var results []*FullNameProjection
+		q := session.QueryCollectionForType(userType)
+		q = q.ProjectUsing(reflect.TypeOf(&FullNameProjection{}), `return { fullName: doc.name + ' ' + doc.lastName };`)
+		err = q.GetResults(&results)
+		assert.NoError(t, err)
+
+		assert.Equal(t, 1, len(results))
+		assert.Equal(t, "John Doe", results[0].FullName)
+
+		session.Close()
+	}
+}
+
+func queryQuerySelectWithLoad(t *testing.T, driver *RavenTestDriver) {
+	var err error
+	store := driver.getDocumentStoreMust(t)
+	defer store.Close()
+
+	{
+		session := openSessionMust(t, store)
+		company := &Company{
+			Name:  "HR",
+			Phone: 1234,
+		}
+		err = session.StoreWithID(company, "companies/1")
+		assert.NoError(t, err)
+
+		order := &Order{
+			Company: "companies/1",
+			Freight: 12,
+		}
+		err = session.Store(order)
+		assert.NoError(t, err)
+		err = session.SaveChanges()
+		assert.NoError(t, err)
+		session.Close()
+	}
+
+	{
+		session := openSessionMust(t, store)
+
+		var names []string
+		q := session.QueryCollectionForType(reflect.TypeOf(&Order{}))
+		q = q.WaitForNonStaleResults(0)
+		q = q.SelectWithLoad(reflect.TypeOf(""), "company", "c", "c.Name")
+		err = q.GetResults(&names)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"HR"}, names)
+
+		type CompanyInfo struct {
+			Name  string
+			Phone int
+		}
+		var infos []*CompanyInfo
+		q = session.QueryCollectionForType(reflect.TypeOf(&Order{}))
+		q = q.WaitForNonStaleResults(0)
+		q = q.SelectWithLoad(reflect.TypeOf(&CompanyInfo{}), "company", "c")
+		err = q.GetResults(&infos)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(infos))
+		assert.Equal(t, "HR", infos[0].Name)
+		assert.Equal(t, 1234, infos[0].Phone)
+
+		session.Close()
+	}
+}
+
+func queryQueryWhereNotSubclause(t *testing.T, driver *RavenTestDriver) {
+	store := driver.getDocumentStoreMust(t)
+	defer store.Close()
+
+	queryAddUsers(t, store, driver)
+
+	{
+		session := openSessionMust(t, store)
+
+		var users []*User
+		q := session.QueryCollectionForType(userType)
+		q = q.WhereNot(func(q *ravendb.DocumentQuery) {
+			q.WhereEquals("name", "Tarzan").OrElse().WhereEquals("age", 5)
+		})
+		err := q.GetResults(&users)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(users))
+		assert.Equal(t, "users/1", users[0].ID)
+
+		users = nil
+		q = session.QueryCollectionForType(userType)
+		q = q.WhereEquals("name", "John").WhereNot(func(q *ravendb.DocumentQuery) {
+			q.WhereEquals("age", 3)
+		})
+		err = q.GetResults(&users)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(users))
+		assert.Equal(t, "users/2", users[0].ID)
+
+		session.Close()
+	}
+}
+
+type personWithAddress struct {
+	ID      string
+	Name    string
+	Address *Address
+}
+
+func queryQueryWhereEqualsNested(t *testing.T, driver *RavenTestDriver) {
+	store := driver.getDocumentStoreMust(t)
+	defer store.Close()
+
+	{
+		session := openSessionMust(t, store)
+		people := []*personWithAddress{
+			{Name: "John", Address: &Address{City: "Redmond", ZipCode: 98052}},
+			{Name: "Jane", Address: &Address{City: "Seattle", ZipCode: 98101}},
+		}
+		for _, p := range people {
+			err := session.Store(p)
+			assert.NoError(t, err)
+		}
+		err := session.SaveChanges()
+		assert.NoError(t, err)
+		session.Close()
+	}
+
+	{
+		session := openSessionMust(t, store)
+
+		var people []*personWithAddress
+		q := session.QueryCollectionForType(reflect.TypeOf(&personWithAddress{}))
+		q = q.WaitForNonStaleResults(0).WhereEqualsNested("Address.zipCode", 98052)
+		iq, err := q.GetIndexQuery()
+		assert.NoError(t, err)
+		assert.Equal(t, "from personWithAddresses where Address.zipCode = $p0", iq.GetQuery())
+		err = q.GetResults(&people)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(people))
+		assert.Equal(t, "John", people[0].Name)
+
+		session.Close()
+	}
+}
+
+func queryQueryStoreWideBeforeQueryCustomization(t *testing.T, driver *RavenTestDriver) {
+	store := driver.getDocumentStoreMust(t)
+	defer store.Close()
+
+	err := store.ExecuteIndex(makeUsersByNameIndex(), "")
+	assert.NoError(t, err)
+
+	nCalled := 0
+	store.AddBeforeQueryListener(func(event *ravendb.BeforeQueryEventArgs) {
+		nCalled++
+		event.QueryCustomization.WaitForNonStaleResults(0)
+	})
+
+	{
+		session := openSessionMust(t, store)
+		for _, name := range []string{"John", "Tarzan"} {
+			u := &User{}
+			u.setName(name)
+			err = session.Store(u)
+			assert.NoError(t, err)
+		}
+		err = session.SaveChanges()
+		assert.NoError(t, err)
+
+		// no explicit WaitForNonStaleResults(), the listener adds it
+		var users []*User
+		q := session.QueryIndex("UsersByName")
+		err = q.GetResults(&users)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, len(users))
+		assert.Equal(t, 1, nCalled)
+
+		// also applies to lazy queries
+		q = session.QueryIndex("UsersByName").WhereEquals("name", "Tarzan")
+		lazyQuery, err := q.Lazily()
+		assert.NoError(t, err)
+		assert.Equal(t, 2, nCalled)
+		users = nil
+		err = lazyQuery.GetValue(&users)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(users))
+
+		session.Close()
+	}
+}
+
+func queryQueryOnEntityMaterialized(t *testing.T, driver *RavenTestDriver) {
+	store := driver.getDocumentStoreMust(t)
+	defer store.Close()
+
+	queryAddUsers(t, store, driver)
+
+	{
+		session := openSessionMust(t, store)
+
+		nCalled := 0
+		session.Advanced().OnEntityMaterialized(func(entity interface{}, metadata map[string]interface{}) {
+			nCalled++
+			user := entity.(*User)
+			assert.Equal(t, user.ID, metadata["@id"])
+			name := strings.ToUpper(*user.Name)
+			user.Name = &name
+		})
+
+		var users []*User
+		q := session.QueryCollectionForType(userType)
+		q = q.OrderBy("name")
+		err := q.GetResults(&users)
+		assert.NoError(t, err)
+
+		assert.Equal(t, 3, nCalled)
+		assert.Equal(t, 3, len(users))
+		assert.Equal(t, "JOHN", *users[0].Name)
+		assert.Equal(t, "TARZAN", *users[2].Name)
+
+		session.Close()
+	}
+}
+
 func queryRawQuerySkipTake(t *testing.T, driver *RavenTestDriver) {
 	store := driver.getDocumentStoreMust(t)
 	defer store.Close()
@@ -932,6 +1300,31 @@ func queryQueryFirst(t *testing.T, driver *RavenTestDriver) {
 			_ = err.(*ravendb.IllegalStateError)
 		}
 
+		// tests not ported from Java
+		{
+			var second *User
+			q := session.QueryCollectionForType(userType)
+			q = q.OrderByDescending("name")
+			err := q.Second(&second)
+			assert.NoError(t, err)
+			assert.Equal(t, "John", *second.Name)
+		}
+
+		{
+			var user *User
+			q := session.QueryCollectionForType(userType)
+			q = q.WhereEquals("name", "Tarzan")
+			err := q.Second(&user)
+			assert.True(t, errors.Is(err, ravendb.ErrInsufficientResults))
+			assert.Nil(t, user)
+
+			q = session.QueryCollectionForType(userType)
+			q = q.WhereEquals("name", "Jane")
+			err = q.Single(&user)
+			_ = err.(*ravendb.IllegalStateError)
+			assert.Nil(t, user)
+		}
+
 		session.Close()
 	}
 }
@@ -984,6 +1377,96 @@ func queryQueryRandomOrder(t *testing.T, driver *RavenTestDriver) {
 	}
 }
 
+func queryQueryRandomOrderWithSeedIsRepeatable(t *testing.T, driver *RavenTestDriver) {
+	store := driver.getDocumentStoreMust(t)
+	defer store.Close()
+
+	{
+		session := openSessionMust(t, store)
+		for i := 0; i < 20; i++ {
+			user := &User{}
+			user.Age = i
+			err := session.Store(user)
+			assert.NoError(t, err)
+		}
+		err := session.SaveChanges()
+		assert.NoError(t, err)
+		session.Close()
+	}
+
+	getIDs := func(seed string) []string {
+		session := openSessionMust(t, store)
+		defer session.Close()
+
+		var res []*User
+		q := session.QueryCollectionForType(userType)
+		q = q.WaitForNonStaleResults(0).RandomOrderingWithSeed(seed)
+		err := q.GetResults(&res)
+		assert.NoError(t, err)
+		assert.Equal(t, 20, len(res))
+		var ids []string
+		for _, u := range res {
+			ids = append(ids, u.ID)
+		}
+		return ids
+	}
+
+	first := getIDs("bucket-a")
+	assert.Equal(t, first, getIDs("bucket-a"))
+	// with 20 documents a different seed is practically guaranteed to
+	// produce a different order
+	assert.NotEqual(t, first, getIDs("bucket-b"))
+}
+
+func queryQueryStaticIndexFacetsAndSuggestions(t *testing.T, driver *RavenTestDriver) {
+	store := driver.getDocumentStoreMust(t)
+	defer store.Close()
+
+	queryAddUsers(t, store, driver)
+
+	{
+		session := openSessionMust(t, store)
+
+		f := ravendb.NewFacetBuilder()
+		f.ByField("name").SumOn("count")
+		facets, err := session.QueryIndex("UsersByName").AggregateByFacet(f.GetFacet()).Execute()
+		assert.NoError(t, err)
+		names := facets["name"]
+		assert.Equal(t, 2, len(names.Values))
+		for _, v := range names.Values {
+			switch v.Range {
+			case "john":
+				assert.Equal(t, float64(2), *v.Sum)
+			case "tarzan":
+				assert.Equal(t, float64(1), *v.Sum)
+			default:
+				t.Fatalf("unexpected facet value %s", v.Range)
+			}
+		}
+
+		session.Close()
+	}
+
+	index := ravendb.NewIndexCreationTask("UsersByNameWithSuggestions")
+	index.Map = "from u in docs.Users select new { u.name }"
+	index.Suggestion("name")
+	err := store.ExecuteIndex(index, "")
+	assert.NoError(t, err)
+	err = driver.waitForIndexing(store, "", 0)
+	assert.NoError(t, err)
+
+	{
+		session := openSessionMust(t, store)
+
+		s := ravendb.NewSuggestionBuilder().ByField("name", "Jon")
+		suggestions, err := session.QueryIndex(index.IndexName).SuggestUsing(s.GetSuggestion()).Execute()
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"john"}, suggestions["name"].Suggestions)
+
+		session.Close()
+	}
+}
+
 func queryQueryWhereExists(t *testing.T, driver *RavenTestDriver) {
 	store := driver.getDocumentStoreMust(t)
 	defer store.Close()
@@ -1367,6 +1850,246 @@ type ReduceResult struct {
 	Age   int    `json:"age"`
 }
 
+func queryQueryGroupByHaving(t *testing.T, driver *RavenTestDriver) {
+	var err error
+	store := driver.getDocumentStoreMust(t)
+	defer store.Close()
+
+	{
+		session := openSessionMust(t, store)
+		countries := []string{"Poland", "Poland", "Poland", "Germany", "Germany", "France"}
+		for _, country := range countries {
+			err = session.Store(&Address{Country: country})
+			assert.NoError(t, err)
+		}
+		err = session.SaveChanges()
+		assert.NoError(t, err)
+		session.Close()
+	}
+
+	{
+		session := openSessionMust(t, store)
+
+		type countryCount struct {
+			Country string `json:"country"`
+			Count   int    `json:"count"`
+		}
+		var results []*countryCount
+		q := session.QueryCollectionForType(reflect.TypeOf(&Address{}))
+		q = q.GroupBy("country").SelectKey().SelectCount()
+		q = q.HavingGreaterThan("count", 2)
+		err = q.GetResults(&results)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(results))
+		if len(results) == 1 {
+			assert.Equal(t, "Poland", results[0].Country)
+			assert.Equal(t, 3, results[0].Count)
+		}
+
+		session.Close()
+	}
+}
+
+type queryAnimal interface {
+	Describe() string
+}
+
+type queryDog struct {
+	ID    string
+	Name  string `json:"name"`
+	Breed string `json:"breed"`
+}
+
+func (d *queryDog) Describe() string {
+	return d.Name + " (" + d.Breed + ")"
+}
+
+type queryCat struct {
+	ID     string
+	Name   string `json:"name"`
+	Indoor bool   `json:"indoor"`
+}
+
+func (c *queryCat) Describe() string {
+	if c.Indoor {
+		return c.Name + " (indoor)"
+	}
+	return c.Name
+}
+
+func queryQueryInterfaceType(t *testing.T, driver *RavenTestDriver) {
+	var err error
+	store := driver.getDocumentStoreMust(t)
+	defer store.Close()
+
+	// conventions must be set up before the store is used
+	conventions := store.GetConventions()
+	conventions.FindCollectionName = func(entity interface{}) string {
+		switch entity.(type) {
+		case *queryDog, *queryCat:
+			return "Animals"
+		}
+		return ravendb.GetCollectionNameDefault(entity)
+	}
+	conventions.RegisterEntityType(&queryDog{})
+	conventions.RegisterEntityType(&queryCat{})
+
+	{
+		session := openSessionMust(t, store)
+		err = session.StoreWithID(&queryDog{Name: "Rex", Breed: "beagle"}, "animals/1")
+		assert.NoError(t, err)
+		err = session.StoreWithID(&queryCat{Name: "Tom", Indoor: true}, "animals/2")
+		assert.NoError(t, err)
+		err = session.StoreWithID(&queryDog{Name: "Max", Breed: "boxer"}, "animals/3")
+		assert.NoError(t, err)
+		err = session.SaveChanges()
+		assert.NoError(t, err)
+		session.Close()
+	}
+
+	{
+		session := openSessionMust(t, store)
+
+		animalType := reflect.TypeOf((*queryAnimal)(nil)).Elem()
+		var results []queryAnimal
+		err = session.QueryCollectionForType(animalType).GetResults(&results)
+		assert.Equal(t, ravendb.ErrCannotInferCollectionForInterface, err)
+
+		err = session.QueryCollectionForType(animalType).FromCollection("Animals").OrderBy("name").GetResults(&results)
+		assert.NoError(t, err)
+		assert.Equal(t, 3, len(results))
+
+		results = nil
+		q := session.QueryInterface((*queryAnimal)(nil), "Animals", false)
+		q = q.WaitForNonStaleResults(0).OrderBy("name")
+		err = q.GetResults(&results)
+		assert.NoError(t, err)
+		assert.Equal(t, 3, len(results))
+		if len(results) == 3 {
+			assert.Equal(t, "Max (boxer)", results[0].Describe())
+			assert.Equal(t, "Rex (beagle)", results[1].Describe())
+			assert.Equal(t, "Tom (indoor)", results[2].Describe())
+			_, ok := results[2].(*queryCat)
+			assert.True(t, ok)
+		}
+
+		session.Close()
+	}
+}
+
+func queryQueryWhereInWithManyValues(t *testing.T, driver *RavenTestDriver) {
+	var err error
+	store := driver.getDocumentStoreMust(t)
+	defer store.Close()
+
+	const nDocs = 2000
+	{
+		bulkInsert := store.BulkInsert("")
+		for i := 0; i < nDocs; i++ {
+			user := &User{Age: i}
+			err = bulkInsert.StoreWithID(user, "users/"+strconv.Itoa(i), nil)
+			assert.NoError(t, err)
+		}
+		err = bulkInsert.Close()
+		assert.NoError(t, err)
+	}
+
+	// most of the ids don't exist
+	ids := make([]string, 50000)
+	for i := range ids {
+		ids[i] = "users/" + strconv.Itoa(i*2)
+	}
+
+	{
+		session := openSessionMust(t, store)
+		q := session.QueryCollectionForType(reflect.TypeOf(&User{})).WhereIDIn(ids)
+		iq, err := q.GetIndexQuery()
+		assert.NoError(t, err)
+		assert.Equal(t, 49, strings.Count(iq.GetQuery(), " in ("))
+		var users []*User
+		err = q.GetResults(&users)
+		assert.NoError(t, err)
+		assert.Equal(t, nDocs/2, len(users))
+		for _, user := range users {
+			assert.Equal(t, 0, user.Age%2)
+		}
+
+		q = session.QueryCollectionForType(reflect.TypeOf(&User{})).WhereLessThan("age", 10).Not().WhereIDIn(ids)
+		users = nil
+		err = q.GetResults(&users)
+		assert.NoError(t, err)
+		assert.Equal(t, 5, len(users))
+		session.Close()
+	}
+}
+
+func queryQuerySelectTimeSeries(t *testing.T, driver *RavenTestDriver) {
+	var err error
+	store := driver.getDocumentStoreMust(t)
+	defer store.Close()
+
+	{
+		session := openSessionMust(t, store)
+		for i := 1; i <= 2; i++ {
+			user := &User{}
+			user.setName("John" + strconv.Itoa(i))
+			err = session.StoreWithID(user, "users/"+strconv.Itoa(i))
+			assert.NoError(t, err)
+		}
+		err = session.SaveChanges()
+		assert.NoError(t, err)
+		session.Close()
+	}
+
+	base := time.Date(2023, 3, 1, 10, 0, 0, 0, time.UTC)
+	patchRequest := &ravendb.PatchRequest{
+		Script: `for (var i = 0; i < args.values.length; i++) {
+	timeseries(this, 'HeartRate').append(new Date(args.start + i * 60000), [args.values[i]], 'watch');
+}`,
+		Values: map[string]interface{}{
+			"start":  base.UnixNano() / int64(time.Millisecond),
+			"values": []float64{70, 75, 80},
+		},
+	}
+	patchOperation, err := ravendb.NewPatchOperation("users/1", nil, patchRequest, nil, false)
+	assert.NoError(t, err)
+	err = store.Operations().Send(patchOperation, nil)
+	assert.NoError(t, err)
+
+	{
+		session := openSessionMust(t, store)
+		var results []*struct {
+			ID         string
+			TimeSeries *ravendb.TimeSeriesRawResult
+		}
+		// the last entry is out of range
+		to := base.Add(time.Minute)
+		q := session.QueryCollection("Users").WhereEquals("name", "John1").SelectTimeSeries("HeartRate", base, to)
+		err = q.GetResults(&results)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, session.Advanced().GetNumberOfRequests())
+		assert.Equal(t, 1, len(results))
+		ts := results[0].TimeSeries
+		assert.NotNil(t, ts)
+		assert.Equal(t, int64(2), ts.Count)
+		assert.Equal(t, 2, len(ts.Results))
+		assert.Equal(t, []float64{75}, ts.Results[1].Values)
+		assert.Equal(t, "watch", ts.Results[1].Tag)
+		assert.True(t, to.Equal(ts.Results[1].GetTimestamp()))
+
+		// documents without the time series have no entries
+		results = nil
+		q = session.QueryCollection("Users").WhereEquals("name", "John2").SelectTimeSeries("HeartRate", base, to)
+		err = q.GetResults(&results)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(results))
+		if ts := results[0].TimeSeries; ts != nil {
+			assert.Equal(t, 0, len(ts.Results))
+		}
+		session.Close()
+	}
+}
+
 func TestQuery(t *testing.T) {
 	driver := createTestDriver(t)
 	destroy := func() { destroyDriver(t, driver) }
@@ -1408,4 +2131,21 @@ func TestQuery(t *testing.T) {
 	queryQueryWithWhereIn(t, driver)
 	queryQueryDistinct(t, driver)
 	queryQueryWithWhereLessThanOrEqual(t, driver)
+
+	// tests not ported from Java
+	queryQueryGetResultsWithStats(t, driver)
+	queryQueryOnEntityMaterialized(t, driver)
+	queryQueryOrderByAlphaNumeric(t, driver)
+	queryQuerySelectJS(t, driver)
+	queryQuerySelectWithLoad(t, driver)
+	queryQueryWhereNotSubclause(t, driver)
+	queryQueryRandomOrderWithSeedIsRepeatable(t, driver)
+	queryQueryStaticIndexFacetsAndSuggestions(t, driver)
+	queryQueryWhereEqualsNested(t, driver)
+	queryQueryStoreWideBeforeQueryCustomization(t, driver)
+	queryQueryProjectUsing(t, driver)
+	queryQueryGroupByHaving(t, driver)
+	queryQueryInterfaceType(t, driver)
+	queryQueryWhereInWithManyValues(t, driver)
+	queryQuerySelectTimeSeries(t, driver)
 }
@@ -349,6 +349,52 @@ func ravendb8761putDocs(t *testing.T, store *ravendb.DocumentStore) {
 	session.Close()
 }
 
+type ravendb8761Article struct {
+	ID   string
+	Tags []string `json:"tags"`
+}
+
+type ravendb8761TagCount struct {
+	Tag   string `json:"tag"`
+	Count int    `json:"count"`
+}
+
+func ravendb8761canGroupByArrayValuesOfPrimitives(t *testing.T, driver *RavenTestDriver) {
+	var err error
+	store := driver.getDocumentStoreMust(t)
+	defer store.Close()
+
+	{
+		session := openSessionMust(t, store)
+		for _, tags := range [][]string{{"go", "db"}, {"go"}, {"db", "nosql", "go"}} {
+			err = session.Store(&ravendb8761Article{Tags: tags})
+			assert.NoError(t, err)
+		}
+		err = session.SaveChanges()
+		assert.NoError(t, err)
+		session.Close()
+	}
+
+	{
+		session := openSessionMust(t, store)
+		q := session.QueryCollectionForType(reflect.TypeOf(&ravendb8761Article{}))
+		q2 := q.GroupByFieldWithMethod(ravendb.NewGroupByArrayValues("tags"))
+		q2 = q2.SelectKeyWithNameAndProjectedName("", "tag")
+		q = q2.SelectCount()
+		q = q.WaitForNonStaleResults(0)
+		var tagCounts []*ravendb8761TagCount
+		err = q.GetResults(&tagCounts)
+		assert.NoError(t, err)
+
+		counts := map[string]int{}
+		for _, tc := range tagCounts {
+			counts[tc.Tag] = tc.Count
+		}
+		assert.Equal(t, map[string]int{"go": 3, "db": 2, "nosql": 1}, counts)
+		session.Close()
+	}
+}
+
 func TestRavenDB8761(t *testing.T) {
 	driver := createTestDriver(t)
 	destroy := func() { destroyDriver(t, driver) }
@@ -358,4 +404,7 @@ func TestRavenDB8761(t *testing.T) {
 	ravendb8761canGroupByArrayContent(t, driver)
 
 	ravendb8761canGroupByArrayValues(t, driver)
+
+	// tests not ported from Java
+	ravendb8761canGroupByArrayValuesOfPrimitives(t, driver)
 }
@@ -0,0 +1,55 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func sessionDiagnosticsTestCountsRequests(t *testing.T, driver *RavenTestDriver) {
+	var err error
+	store := driver.getDocumentStoreMust(t)
+	defer store.Close()
+
+	{
+		session := openSessionMust(t, store)
+		advanced := session.Advanced()
+		assert.Equal(t, 0, advanced.GetNumberOfRequests())
+		assert.Equal(t, store.GetConventions().MaxNumberOfRequestsPerSession, advanced.MaxRequests())
+
+		for _, id := range []string{"users/1", "users/2", "users/3"} {
+			var user *User
+			err = session.Load(&user, id)
+			assert.NoError(t, err)
+		}
+		assert.Equal(t, 3, advanced.GetNumberOfRequests())
+
+		user := &User{}
+		user.setName("John")
+		err = session.Store(user)
+		assert.NoError(t, err)
+
+		diag := advanced.Diagnostics()
+		assert.Equal(t, 3, diag.NumberOfRequests)
+		assert.Equal(t, 1, diag.UniqueEntitiesInSession)
+		assert.Equal(t, 0, diag.NumberOfDeferredCommands)
+		assert.True(t, diag.PendingSessionChanges)
+
+		err = session.SaveChanges()
+		assert.NoError(t, err)
+
+		diag = advanced.Diagnostics()
+		assert.Equal(t, 4, diag.NumberOfRequests)
+		assert.Equal(t, 1, diag.UniqueEntitiesInSession)
+		assert.False(t, diag.PendingSessionChanges)
+		session.Close()
+	}
+}
+
+func TestSessionDiagnostics(t *testing.T) {
+	driver := createTestDriver(t)
+	destroy := func() { destroyDriver(t, driver) }
+	defer recoverTest(t, destroy)
+
+	sessionDiagnosticsTestCountsRequests(t, driver)
+}
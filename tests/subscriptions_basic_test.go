@@ -1080,6 +1080,102 @@ func subscriptionsBasic_disposingOneSubscriptionShouldNotAffectOnNotificationsOf
 	assert.Equal(t, u.ID, "users/4")
 }
 
+func subscriptionsBasic_canGetSubscriptionsWithOperations(t *testing.T, driver *RavenTestDriver) {
+	var err error
+	store := driver.getDocumentStoreMust(t)
+	defer store.Close()
+
+	query := "from Users where age > 20"
+	opts := &ravendb.SubscriptionCreationOptions{
+		Query: query,
+	}
+	name, err := store.Subscriptions().Create(opts, "")
+	assert.NoError(t, err)
+
+	listOp, err := ravendb.NewGetSubscriptionsOperation(0, 10)
+	assert.NoError(t, err)
+	err = store.Maintenance().Send(listOp)
+	assert.NoError(t, err)
+	found := false
+	for _, state := range listOp.Command.Result {
+		if state.SubscriptionName == name {
+			found = true
+		}
+	}
+	assert.True(t, found)
+
+	stateOp, err := ravendb.NewGetSubscriptionStateOperation(name)
+	assert.NoError(t, err)
+	err = store.Maintenance().Send(stateOp)
+	assert.NoError(t, err)
+	assert.Equal(t, name, stateOp.Command.Result.SubscriptionName)
+	assert.Equal(t, query, stateOp.Command.Result.Query)
+}
+
+func subscriptionsBasic_canDropConnectionWithOperation(t *testing.T, driver *RavenTestDriver) {
+	var err error
+	store := driver.getDocumentStoreMust(t)
+	defer store.Close()
+
+	opts := &ravendb.SubscriptionCreationOptions{}
+	clazz := reflect.TypeOf(&User{})
+	id, err := store.Subscriptions().CreateForType(clazz, opts, "")
+	assert.NoError(t, err)
+
+	putUserDoc(t, store)
+
+	results := make(chan *ravendb.SubscriptionBatch, 16)
+	cb := func(batch *ravendb.SubscriptionBatch) error {
+		results <- batch
+		return nil
+	}
+
+	options := ravendb.NewSubscriptionWorkerOptions(id)
+	options.Strategy = ravendb.SubscriptionOpeningStrategyOpenIfFree
+	worker, err := store.Subscriptions().GetSubscriptionWorker(clazz, options, "")
+	assert.NoError(t, err)
+	defer worker.Close()
+	err = worker.Run(cb)
+	assert.NoError(t, err)
+	select {
+	case <-results:
+	// no-op, got a result
+	case <-time.After(_reasonableWaitTime):
+		assert.Fail(t, "timed out waiting for batch")
+	}
+
+	stateOp, err := ravendb.NewGetSubscriptionStateOperation(id)
+	assert.NoError(t, err)
+	err = store.Maintenance().Send(stateOp)
+	assert.NoError(t, err)
+	assert.False(t, time.Time(stateOp.Command.Result.LastClientConnectionTime).IsZero())
+
+	dropOp, err := ravendb.NewDropSubscriptionConnectionOperation(id)
+	assert.NoError(t, err)
+	err = store.Maintenance().Send(dropOp)
+	assert.NoError(t, err)
+
+	// the dropped worker doesn't try to reconnect
+	err = worker.WaitUntilFinished(_reasonableWaitTime)
+	assert.Error(t, err)
+
+	// the subscription is free so another worker can take it over right away
+	options = ravendb.NewSubscriptionWorkerOptions(id)
+	options.Strategy = ravendb.SubscriptionOpeningStrategyOpenIfFree
+	newWorker, err := store.Subscriptions().GetSubscriptionWorker(clazz, options, "")
+	assert.NoError(t, err)
+	defer newWorker.Close()
+	err = newWorker.Run(cb)
+	assert.NoError(t, err)
+	putUserDoc(t, store)
+	select {
+	case <-results:
+	// no-op, got a result
+	case <-time.After(_reasonableWaitTime):
+		assert.Fail(t, "timed out waiting for batch")
+	}
+}
+
 func TestSubscriptionsBasic(t *testing.T) {
 	t.Skip("Need to be fixed")
 
@@ -1108,3 +1204,14 @@ func TestSubscriptionsBasic(t *testing.T) {
 	subscriptionsBasic_shouldSendAllNewAndModifiedDocs(t, driver)
 	subscriptionsBasic_ravenDB_3453_ShouldDeserializeTheWholeDocumentsAfterTypedSubscription(t, driver)
 }
+
+// TestSubscriptionsBasicOperations runs subscription tests not ported from
+// Java separately from TestSubscriptionsBasic, which is skipped
+func TestSubscriptionsBasicOperations(t *testing.T) {
+	driver := createTestDriver(t)
+	destroy := func() { destroyDriver(t, driver) }
+	defer recoverTest(t, destroy)
+
+	subscriptionsBasic_canGetSubscriptionsWithOperations(t, driver)
+	subscriptionsBasic_canDropConnectionWithOperation(t, driver)
+}
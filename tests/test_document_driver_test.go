@@ -0,0 +1,77 @@
+package tests
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	ravendb "github.com/ravendb/ravendb-go-client"
+	"github.com/ravendb/ravendb-go-client/testdriver"
+	"github.com/stretchr/testify/assert"
+)
+
+func testDocumentDriverStoresAreIsolated(t *testing.T, driver *RavenTestDriver) {
+	var err error
+	// makes sure the servers are running
+	mainStore := driver.getDocumentStoreMust(t)
+	defer mainStore.Close()
+
+	d := testdriver.NewTestDocumentDriver(t, mainStore.GetUrls()...)
+	d.Certificate = mainStore.Certificate
+	d.TrustStore = mainStore.TrustStore
+	defer d.Cleanup()
+
+	store1 := d.CreateDocumentStore()
+	store2 := d.CreateDocumentStore()
+	assert.NotEqual(t, store1.GetDatabase(), store2.GetDatabase())
+	assert.True(t, strings.HasPrefix(store1.GetDatabase(), "TestTestDocumentDriver_"))
+
+	{
+		session := openSessionMust(t, store1)
+		user := &User{}
+		user.setName("John")
+		err = session.StoreWithID(user, "users/1")
+		assert.NoError(t, err)
+		err = session.SaveChanges()
+		assert.NoError(t, err)
+		session.Close()
+	}
+
+	{
+		session := openSessionMust(t, store2)
+		exists, err := session.Exists("users/1")
+		assert.NoError(t, err)
+		assert.False(t, exists)
+		session.Close()
+	}
+
+	{
+		session := openSessionMust(t, store1)
+		var user *User
+		err = session.Load(&user, "users/1")
+		assert.NoError(t, err)
+		assert.NotNil(t, user)
+		session.Close()
+	}
+
+	err = d.WaitForIndexing(store1, "", time.Second*10)
+	assert.NoError(t, err)
+
+	names := []string{store1.GetDatabase(), store2.GetDatabase()}
+	d.Cleanup()
+
+	command := ravendb.NewGetDatabaseNamesCommand(0, 1024)
+	err = mainStore.GetRequestExecutor("").ExecuteCommand(command, nil)
+	assert.NoError(t, err)
+	for _, name := range names {
+		assert.NotContains(t, command.Result, name)
+	}
+}
+
+func TestTestDocumentDriver(t *testing.T) {
+	driver := createTestDriver(t)
+	destroy := func() { destroyDriver(t, driver) }
+	defer recoverTest(t, destroy)
+
+	testDocumentDriverStoresAreIsolated(t, driver)
+}
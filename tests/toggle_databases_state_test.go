@@ -0,0 +1,85 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/ravendb/ravendb-go-client"
+	"github.com/stretchr/testify/assert"
+)
+
+func toggleDatabasesStateCanDisableAndEnableDatabase(t *testing.T, driver *RavenTestDriver) {
+	var err error
+	store := driver.getDocumentStoreMust(t)
+	defer store.Close()
+
+	{
+		session := openSessionMust(t, store)
+		err = session.StoreWithID(&User{}, "users/1")
+		assert.NoError(t, err)
+		err = session.SaveChanges()
+		assert.NoError(t, err)
+		session.Close()
+	}
+
+	disableOp := ravendb.NewToggleDatabasesStateOperation([]string{store.GetDatabase()}, true)
+	err = store.Maintenance().Server().Send(disableOp)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(disableOp.Command.Result))
+	assert.True(t, disableOp.Command.Result[0].Success)
+	assert.True(t, disableOp.Command.Result[0].Disabled)
+
+	{
+		session := openSessionMust(t, store)
+		var user *User
+		err = session.Load(&user, "users/1")
+		_, ok := err.(*ravendb.DatabaseDisabledError)
+		assert.True(t, ok, "expected *DatabaseDisabledError, got %T (%v)", err, err)
+		session.Close()
+	}
+
+	enableOp := ravendb.NewToggleDatabasesStateOperation([]string{store.GetDatabase()}, false)
+	err = store.Maintenance().Server().Send(enableOp)
+	assert.NoError(t, err)
+	assert.False(t, enableOp.Command.Result[0].Disabled)
+
+	{
+		session := openSessionMust(t, store)
+		var user *User
+		err = session.Load(&user, "users/1")
+		assert.NoError(t, err)
+		assert.NotNil(t, user)
+		session.Close()
+	}
+}
+
+func setDatabasesLockCanPreventDeletes(t *testing.T, driver *RavenTestDriver) {
+	var err error
+	store := driver.getDocumentStoreMust(t)
+	defer store.Close()
+
+	lockOp := ravendb.NewSetDatabasesLockOperation(store.GetDatabase(), ravendb.DatabaseLockModePreventDeletesError)
+	err = store.Maintenance().Server().Send(lockOp)
+	assert.NoError(t, err)
+
+	recordOp := ravendb.NewGetDatabaseRecordOperation(store.GetDatabase())
+	err = store.Maintenance().Server().Send(recordOp)
+	assert.NoError(t, err)
+	assert.Equal(t, ravendb.DatabaseLockModePreventDeletesError, recordOp.Command.Result.LockMode)
+
+	err = store.Maintenance().Server().Send(ravendb.NewDeleteDatabasesOperation(store.GetDatabase(), true))
+	assert.Error(t, err)
+
+	// unlock so that the test driver can delete the database
+	lockOp = ravendb.NewSetDatabasesLockOperation(store.GetDatabase(), ravendb.DatabaseLockModeUnlock)
+	err = store.Maintenance().Server().Send(lockOp)
+	assert.NoError(t, err)
+}
+
+func TestToggleDatabasesState(t *testing.T) {
+	driver := createTestDriver(t)
+	destroy := func() { destroyDriver(t, driver) }
+	defer recoverTest(t, destroy)
+
+	toggleDatabasesStateCanDisableAndEnableDatabase(t, driver)
+	setDatabasesLockCanPreventDeletes(t, driver)
+}
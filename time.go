@@ -68,16 +68,14 @@ func (t *Time) UnmarshalJSON(d []byte) error {
 	s = strings.TrimLeft(s, `"`)
 	s = strings.TrimRight(s, `"`)
 
-	if s == "null" {
+	if s == "null" || s == "" {
 		return nil
 	}
 	tt, err := ParseTime(s)
-	*t = Time(tt)
 	if err != nil {
-		// TODO: for now make it a fatal error to catch bugs early
-		must(err)
 		return err
 	}
+	*t = Time(tt)
 	return nil
 }
 
@@ -0,0 +1,96 @@
+package ravendb
+
+import (
+	"sort"
+	"time"
+)
+
+// TimeSeriesEntry is a single entry of a time series
+type TimeSeriesEntry struct {
+	Timestamp Time      `json:"Timestamp"`
+	Tag       string    `json:"Tag"`
+	Values    []float64 `json:"Values"`
+	IsRollup  bool      `json:"IsRollup"`
+}
+
+// GetTimestamp returns time of the entry
+func (e *TimeSeriesEntry) GetTimestamp() time.Time {
+	return time.Time(e.Timestamp)
+}
+
+// TimeSeriesRangeResult is a range of entries of a time series.
+// Zero From or To means the range is open on that side.
+type TimeSeriesRangeResult struct {
+	From    Time               `json:"From"`
+	To      Time               `json:"To"`
+	Entries []*TimeSeriesEntry `json:"Entries"`
+}
+
+// timeSeriesFromBefore returns true if from a starts before from b
+// (zero from is the earliest)
+func timeSeriesFromBefore(a, b Time) bool {
+	ta, tb := time.Time(a), time.Time(b)
+	return !tb.IsZero() && (ta.IsZero() || ta.Before(tb))
+}
+
+// timeSeriesToAfter returns true if to a ends after to b
+// (zero to is the latest)
+func timeSeriesToAfter(a, b Time) bool {
+	ta, tb := time.Time(a), time.Time(b)
+	return !tb.IsZero() && (ta.IsZero() || ta.After(tb))
+}
+
+// timeSeriesFromAfterTo returns true if from is after to
+// (zero from is the earliest, zero to is the latest)
+func timeSeriesFromAfterTo(from, to Time) bool {
+	tf, tt := time.Time(from), time.Time(to)
+	return !tf.IsZero() && !tt.IsZero() && tf.After(tt)
+}
+
+// contains returns true if t is within the range
+func (r *TimeSeriesRangeResult) contains(t time.Time) bool {
+	from, to := time.Time(r.From), time.Time(r.To)
+	return (from.IsZero() || !t.Before(from)) && (to.IsZero() || !t.After(to))
+}
+
+// overlaps returns true if the ranges have common part
+func (r *TimeSeriesRangeResult) overlaps(other *TimeSeriesRangeResult) bool {
+	return !timeSeriesFromAfterTo(other.From, r.To) && !timeSeriesFromAfterTo(r.From, other.To)
+}
+
+// addTimeSeriesRange adds r to ranges sorted by From. Ranges that overlap
+// r are merged with it, entries of r replace their entries in r's range.
+func addTimeSeriesRange(ranges []*TimeSeriesRangeResult, r *TimeSeriesRangeResult) []*TimeSeriesRangeResult {
+	merged := &TimeSeriesRangeResult{
+		From: r.From,
+		To:   r.To,
+	}
+	var res []*TimeSeriesRangeResult
+	for _, existing := range ranges {
+		if !existing.overlaps(r) {
+			res = append(res, existing)
+			continue
+		}
+		if timeSeriesFromBefore(existing.From, merged.From) {
+			merged.From = existing.From
+		}
+		if timeSeriesToAfter(existing.To, merged.To) {
+			merged.To = existing.To
+		}
+		for _, e := range existing.Entries {
+			if !r.contains(e.GetTimestamp()) {
+				merged.Entries = append(merged.Entries, e)
+			}
+		}
+	}
+	merged.Entries = append(merged.Entries, r.Entries...)
+	sort.SliceStable(merged.Entries, func(i, j int) bool {
+		return merged.Entries[i].GetTimestamp().Before(merged.Entries[j].GetTimestamp())
+	})
+
+	res = append(res, merged)
+	sort.SliceStable(res, func(i, j int) bool {
+		return timeSeriesFromBefore(res[i].From, res[j].From)
+	})
+	return res
+}
@@ -0,0 +1,59 @@
+package ravendb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestTimeSeriesRange(from, to int, entries ...int) *TimeSeriesRangeResult {
+	day := func(d int) Time {
+		if d == 0 {
+			return Time{}
+		}
+		return Time(time.Date(2020, 1, d, 0, 0, 0, 0, time.UTC))
+	}
+	res := &TimeSeriesRangeResult{
+		From: day(from),
+		To:   day(to),
+	}
+	for _, d := range entries {
+		res.Entries = append(res.Entries, &TimeSeriesEntry{Timestamp: day(d), Values: []float64{float64(d)}})
+	}
+	return res
+}
+
+func timeSeriesRangeDays(r *TimeSeriesRangeResult) []int {
+	var res []int
+	for _, e := range r.Entries {
+		res = append(res, e.GetTimestamp().Day())
+	}
+	return res
+}
+
+func TestAddTimeSeriesRange(t *testing.T) {
+	var ranges []*TimeSeriesRangeResult
+	ranges = addTimeSeriesRange(ranges, newTestTimeSeriesRange(10, 12, 10, 11, 12))
+	ranges = addTimeSeriesRange(ranges, newTestTimeSeriesRange(2, 4, 3))
+	// the same range again
+	ranges = addTimeSeriesRange(ranges, newTestTimeSeriesRange(2, 4, 3))
+	require.Equal(t, 2, len(ranges))
+	assert.Equal(t, []int{3}, timeSeriesRangeDays(ranges[0]))
+	assert.Equal(t, []int{10, 11, 12}, timeSeriesRangeDays(ranges[1]))
+
+	// overlaps with the second range, replaces its entries from day 11
+	ranges = addTimeSeriesRange(ranges, newTestTimeSeriesRange(11, 15, 11, 14))
+	require.Equal(t, 2, len(ranges))
+	assert.Equal(t, newTestTimeSeriesRange(10, 15).From, ranges[1].From)
+	assert.Equal(t, newTestTimeSeriesRange(10, 15).To, ranges[1].To)
+	assert.Equal(t, []int{10, 11, 14}, timeSeriesRangeDays(ranges[1]))
+
+	// range open on both sides replaces everything
+	ranges = addTimeSeriesRange(ranges, newTestTimeSeriesRange(0, 0, 1, 20))
+	require.Equal(t, 1, len(ranges))
+	assert.True(t, time.Time(ranges[0].From).IsZero())
+	assert.True(t, time.Time(ranges[0].To).IsZero())
+	assert.Equal(t, []int{1, 20}, timeSeriesRangeDays(ranges[0]))
+}
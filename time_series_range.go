@@ -0,0 +1,20 @@
+package ravendb
+
+import "time"
+
+// TimeSeriesRange describes entries of a time series to include when
+// loading documents. nil From or To leaves that side of the range open.
+type TimeSeriesRange struct {
+	Name string
+	From *time.Time
+	To   *time.Time
+}
+
+// formatTimeSeriesRangeTime formats range boundary for a url, "" means
+// unbounded
+func formatTimeSeriesRangeTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return urlUtilsEscapeDataString(Time(t.UTC()).Format())
+}
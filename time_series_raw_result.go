@@ -0,0 +1,8 @@
+package ravendb
+
+// TimeSeriesRawResult is a time series projected by a query, see
+// DocumentQuery.SelectTimeSeries
+type TimeSeriesRawResult struct {
+	Count   int64              `json:"Count"`
+	Results []*TimeSeriesEntry `json:"Results"`
+}
@@ -0,0 +1,42 @@
+package ravendb
+
+import (
+	"strings"
+	"time"
+)
+
+var _ queryToken = &timeSeriesToken{}
+
+// timeSeriesMinTime and timeSeriesMaxTime are used in place of zero from
+// and to of a time series range. They match DateTime.MinValue and
+// DateTime.MaxValue of the server
+var (
+	timeSeriesMinTime = time.Date(1, 1, 1, 0, 0, 0, 0, time.UTC)
+	timeSeriesMaxTime = time.Date(9999, 12, 31, 23, 59, 59, 999999900, time.UTC)
+)
+
+// timeSeriesTokenAlias is the name under which SelectTimeSeries returns
+// the projected time series
+const timeSeriesTokenAlias = "TimeSeries"
+
+type timeSeriesToken struct {
+	timeSeriesName    string
+	fromParameterName string
+	toParameterName   string
+}
+
+func (t *timeSeriesToken) writeTo(writer *strings.Builder) error {
+	writer.WriteString("timeseries(from ")
+	writer.WriteString(t.timeSeriesName)
+
+	if t.fromParameterName != "" {
+		writer.WriteString(" between $")
+		writer.WriteString(t.fromParameterName)
+		writer.WriteString(" and $")
+		writer.WriteString(t.toParameterName)
+	}
+
+	writer.WriteString(") as ")
+	writer.WriteString(timeSeriesTokenAlias)
+	return nil
+}
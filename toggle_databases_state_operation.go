@@ -0,0 +1,108 @@
+package ravendb
+
+import (
+	"net/http"
+)
+
+var (
+	_ IServerOperation = &ToggleDatabasesStateOperation{}
+)
+
+// DisableDatabaseToggleResult is a result of enabling or disabling a
+// single database
+type DisableDatabaseToggleResult struct {
+	Name     string `json:"Name"`
+	Disabled bool   `json:"Disabled"`
+	Success  bool   `json:"Success"`
+	Reason   string `json:"Reason"`
+}
+
+// ToggleDatabasesStateOperation enables or disables databases
+type ToggleDatabasesStateOperation struct {
+	databaseNames []string
+	disable       bool
+
+	Command *ToggleDatabasesStateCommand
+}
+
+// NewToggleDatabasesStateOperation returns an operation that disables
+// (if disable is true) or enables databases
+func NewToggleDatabasesStateOperation(databaseNames []string, disable bool) *ToggleDatabasesStateOperation {
+	return &ToggleDatabasesStateOperation{
+		databaseNames: databaseNames,
+		disable:       disable,
+	}
+}
+
+func (o *ToggleDatabasesStateOperation) GetCommand(conventions *DocumentConventions) (RavenCommand, error) {
+	var err error
+	o.Command, err = NewToggleDatabasesStateCommand(o.databaseNames, o.disable)
+	if err != nil {
+		return nil, err
+	}
+	return o.Command, nil
+}
+
+var _ RavenCommand = &ToggleDatabasesStateCommand{}
+
+type ToggleDatabasesStateCommand struct {
+	RavenCommandBase
+
+	disable    bool
+	parameters []byte
+
+	// Result has a status of each database
+	Result []*DisableDatabaseToggleResult
+}
+
+func NewToggleDatabasesStateCommand(databaseNames []string, disable bool) (*ToggleDatabasesStateCommand, error) {
+	if len(databaseNames) == 0 {
+		return nil, newIllegalArgumentError("databaseNames cannot be empty")
+	}
+	for _, name := range databaseNames {
+		if name == "" {
+			return nil, newIllegalArgumentError("database name cannot be empty")
+		}
+	}
+	m := map[string]interface{}{
+		"DatabaseNames": databaseNames,
+	}
+	d, err := jsonMarshal(m)
+	if err != nil {
+		return nil, err
+	}
+	cmd := &ToggleDatabasesStateCommand{
+		RavenCommandBase: NewRavenCommandBase(),
+
+		disable:    disable,
+		parameters: d,
+	}
+	return cmd, nil
+}
+
+func (c *ToggleDatabasesStateCommand) CreateRequest(node *ServerNode) (*http.Request, error) {
+	toggle := "enable"
+	if c.disable {
+		toggle = "disable"
+	}
+	url := node.URL + "/admin/databases/" + toggle
+	return NewHttpPost(url, c.parameters)
+}
+
+func (c *ToggleDatabasesStateCommand) SetResponse(response []byte, fromCache bool) error {
+	if len(response) == 0 {
+		return throwInvalidResponse()
+	}
+
+	var res struct {
+		Status []*DisableDatabaseToggleResult `json:"Status"`
+	}
+	if err := jsonUnmarshal(response, &res); err != nil {
+		return err
+	}
+	if res.Status == nil {
+		return throwInvalidResponse()
+	}
+	c.Result = res.Status
+	return nil
+}
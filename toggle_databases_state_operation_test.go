@@ -0,0 +1,28 @@
+package ravendb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToggleDatabasesStateCommandSetResponse(t *testing.T) {
+	op := NewToggleDatabasesStateOperation([]string{"db1", "db2"}, true)
+	cmd, err := op.GetCommand(NewDocumentConventions())
+	require.NoError(t, err)
+
+	js := `{"Status":[{"Name":"db1","Success":true,"Disabled":true,"Reason":"Database state changed"},` +
+		`{"Name":"db2","Success":false,"Disabled":false,"Reason":"Database not found"}]}`
+	require.NoError(t, cmd.SetResponse([]byte(js), false))
+	res := op.Command.Result
+	require.Equal(t, 2, len(res))
+	assert.True(t, res[0].Success)
+	assert.True(t, res[0].Disabled)
+	assert.Equal(t, "db2", res[1].Name)
+	assert.False(t, res[1].Success)
+	assert.Equal(t, "Database not found", res[1].Reason)
+
+	err = cmd.SetResponse([]byte(`{}`), false)
+	assert.Error(t, err)
+}
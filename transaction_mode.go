@@ -0,0 +1,13 @@
+package ravendb
+
+// TransactionMode describes how SaveChanges of a session is executed
+type TransactionMode = string
+
+const (
+	// TransactionModeSingleNode commits changes on a single node
+	// and replicates them to other nodes (the default)
+	TransactionModeSingleNode = "SingleNode"
+	// TransactionModeClusterWide commits changes, together with compare exchange
+	// operations staged in Advanced().ClusterTransaction(), using cluster consensus
+	TransactionModeClusterWide = "ClusterWide"
+)
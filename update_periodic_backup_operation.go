@@ -0,0 +1,74 @@
+package ravendb
+
+import (
+	"net/http"
+)
+
+var (
+	_ IMaintenanceOperation = &UpdatePeriodicBackupOperation{}
+)
+
+// UpdatePeriodicBackupOperationResult is a result of UpdatePeriodicBackupOperation
+type UpdatePeriodicBackupOperationResult struct {
+	RaftCommandIndex int64 `json:"RaftCommandIndex"`
+	TaskID           int64 `json:"TaskId"`
+}
+
+// UpdatePeriodicBackupOperation creates or updates a periodic backup task
+type UpdatePeriodicBackupOperation struct {
+	configuration *PeriodicBackupConfiguration
+
+	Command *UpdatePeriodicBackupCommand
+}
+
+func NewUpdatePeriodicBackupOperation(configuration *PeriodicBackupConfiguration) *UpdatePeriodicBackupOperation {
+	return &UpdatePeriodicBackupOperation{
+		configuration: configuration,
+	}
+}
+
+func (o *UpdatePeriodicBackupOperation) GetCommand(conventions *DocumentConventions) (RavenCommand, error) {
+	var err error
+	o.Command, err = NewUpdatePeriodicBackupCommand(o.configuration)
+	return o.Command, err
+}
+
+var _ RavenCommand = &UpdatePeriodicBackupCommand{}
+
+type UpdatePeriodicBackupCommand struct {
+	RavenCommandBase
+
+	configuration *PeriodicBackupConfiguration
+
+	Result *UpdatePeriodicBackupOperationResult
+}
+
+func NewUpdatePeriodicBackupCommand(configuration *PeriodicBackupConfiguration) (*UpdatePeriodicBackupCommand, error) {
+	if configuration == nil {
+		return nil, newIllegalArgumentError("Configuration cannot be null")
+	}
+	cmd := &UpdatePeriodicBackupCommand{
+		RavenCommandBase: NewRavenCommandBase(),
+
+		configuration: configuration,
+	}
+	return cmd, nil
+}
+
+func (c *UpdatePeriodicBackupCommand) CreateRequest(node *ServerNode) (*http.Request, error) {
+	url := node.URL + "/databases/" + node.Database + "/admin/periodic-backup"
+
+	d, err := jsonMarshal(c.configuration)
+	if err != nil {
+		return nil, err
+	}
+	return NewHttpPost(url, d)
+}
+
+func (c *UpdatePeriodicBackupCommand) SetResponse(response []byte, fromCache bool) error {
+	if len(response) == 0 {
+		return throwInvalidResponse()
+	}
+
+	return jsonUnmarshal(response, &c.Result)
+}
@@ -0,0 +1,57 @@
+package ravendb
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommandUrlsEscapeDynamicValues(t *testing.T) {
+	// check that value survives a round-trip through the url
+	assertQueryValue := func(cmd RavenCommand, name string, expected ...string) {
+		req, _ := createTestRequest(t, cmd)
+		u, err := url.Parse(req.URL.String())
+		require.NoError(t, err)
+		assert.Equal(t, expected, u.Query()[name])
+	}
+
+	const tricky = "users/1 ü&x=#y"
+
+	assertQueryValue(NewGetConflictsCommand(tricky), "docId", tricky)
+
+	cmd, err := NewGetDocumentsCommand([]string{"users/1"}, []string{"Friends & Family"}, false)
+	require.NoError(t, err)
+	assertQueryValue(cmd, "include", "Friends & Family")
+
+	cmd, err = NewGetDocumentsCommandFull("users/", "users/1 ü", "a*|b&c", "", 0, 0, false)
+	require.NoError(t, err)
+	assertQueryValue(cmd, "matches", "a*|b&c")
+	assertQueryValue(cmd, "startAfter", "users/1 ü")
+
+	assertQueryValue(NewGetDatabaseRecordCommand(nil, "my db"), "name", "my db")
+
+	// document ids go through the same escaping in every command
+	const id = "people/Łukasz Müller/№5 a+b&c"
+	assertQueryValue(NewPutDocumentCommand(id, nil, map[string]interface{}{}), "id", id)
+	assertQueryValue(NewDeleteDocumentCommand(id, nil), "id", id)
+	assertQueryValue(NewHeadDocumentCommand(id, nil), "id", id)
+	assertQueryValue(NewNextIdentityForCommand(id), "name", id)
+	getCmd, err := NewGetDocumentsCommand([]string{id}, nil, false)
+	require.NoError(t, err)
+	assertQueryValue(getCmd, "id", id)
+	hiloCmd := NewNextHiLoCommand("people & co", 32, nil, "+", 0)
+	assertQueryValue(hiloCmd, "tag", "people & co")
+	assertQueryValue(hiloCmd, "identityPartsSeparator", "+")
+
+	// settings go in the body, the database name in the url
+	record := NewDatabaseRecord()
+	record.DatabaseName = "Zürich db"
+	record.Settings["Indexing.Path"] = "C:\\data dir\\ünicode"
+	createCmd, err := NewCreateDatabaseCommand(nil, record, 1)
+	require.NoError(t, err)
+	assertQueryValue(createCmd, "name", "Zürich db")
+	_, d := createTestRequest(t, createCmd)
+	assert.Contains(t, string(d), `"Indexing.Path":"C:\\data dir\\ünicode"`)
+}
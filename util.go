@@ -62,8 +62,23 @@ func firstNonZero(i1, i2 int) int {
 	return i2
 }
 
+// deepCopy copies JSON-like values i.e. nested map[string]interface{}
+// and []interface{}. Other values are returned as is
 func deepCopy(v interface{}) interface{} {
-	// TODO: implement me
+	switch v := v.(type) {
+	case map[string]interface{}:
+		res := make(map[string]interface{}, len(v))
+		for k, el := range v {
+			res[k] = deepCopy(el)
+		}
+		return res
+	case []interface{}:
+		res := make([]interface{}, len(v))
+		for i, el := range v {
+			res[i] = deepCopy(el)
+		}
+		return res
+	}
 	return v
 }
 
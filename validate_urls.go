@@ -0,0 +1,66 @@
+package ravendb
+
+import (
+	"crypto/tls"
+	"net/url"
+	"strings"
+)
+
+// requestExecutorValidateUrls validates and normalizes urls of server nodes:
+// removes trailing slashes, lower-cases scheme and host and removes
+// duplicates. If a certificate is given or any url uses https, all urls
+// must use https
+func requestExecutorValidateUrls(initialUrls []string, certificate *tls.Certificate) ([]string, error) {
+	var cleanUrls []string
+	seen := map[string]bool{}
+	requireHTTPS := certificate != nil
+	for _, uri := range initialUrls {
+		cleanURL, err := normalizeNodeURL(uri)
+		if err != nil {
+			return nil, err
+		}
+		requireHTTPS = requireHTTPS || strings.HasPrefix(cleanURL, "https://")
+		if seen[cleanURL] {
+			continue
+		}
+		seen[cleanURL] = true
+		cleanUrls = append(cleanUrls, cleanURL)
+	}
+
+	if !requireHTTPS {
+		return cleanUrls, nil
+	}
+
+	for _, uri := range cleanUrls {
+		if !strings.HasPrefix(uri, "http://") {
+			continue
+		}
+		if certificate != nil {
+			return nil, newIllegalStateError("The url %s is using HTTP, but a certificate is specified, which require us to use HTTPS", uri)
+		}
+		return nil, newIllegalStateError("The url %s is using HTTP, but other urls are using HTTPS, and mixing of HTTP and HTTPS is not allowed", uri)
+	}
+	return cleanUrls, nil
+}
+
+// normalizeNodeURL validates url of a server node and returns it with
+// lower-cased scheme and host and without trailing slashes
+func normalizeNodeURL(uri string) (string, error) {
+	s := strings.TrimSpace(uri)
+	u, err := url.Parse(s)
+	if err != nil {
+		return "", newIllegalArgumentError("The url '%s' is not valid: %s", uri, err.Error(), err)
+	}
+	scheme := strings.ToLower(u.Scheme)
+	if scheme != "http" && scheme != "https" {
+		return "", newIllegalArgumentError("The url '%s' is not valid, it must start with http:// or https://", uri)
+	}
+	if u.Host == "" {
+		return "", newIllegalArgumentError("The url '%s' is not valid, it has no host", uri)
+	}
+	if u.RawQuery != "" || u.Fragment != "" || u.User != nil {
+		return "", newIllegalArgumentError("The url '%s' is not valid, it can't have user info, query or fragment", uri)
+	}
+	path := strings.TrimRight(u.EscapedPath(), "/")
+	return scheme + "://" + strings.ToLower(u.Host) + path, nil
+}
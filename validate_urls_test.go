@@ -0,0 +1,63 @@
+package ravendb
+
+import (
+	"crypto/tls"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestExecutorValidateUrls(t *testing.T) {
+	cert := &tls.Certificate{}
+	tests := []struct {
+		urls        []string
+		certificate *tls.Certificate
+		exp         []string
+		expErr      error
+	}{
+		{[]string{"http://localhost:8080"}, nil, []string{"http://localhost:8080"}, nil},
+		{[]string{"http://localhost:8080/"}, nil, []string{"http://localhost:8080"}, nil},
+		{[]string{" HTTP://LocalHost:8080// "}, nil, []string{"http://localhost:8080"}, nil},
+		{[]string{"http://a:8080", "http://b:8080/", "http://A:8080"}, nil, []string{"http://a:8080", "http://b:8080"}, nil},
+		{[]string{"http://a:8080/raven/"}, nil, []string{"http://a:8080/raven"}, nil},
+		{[]string{"https://a", "https://b"}, nil, []string{"https://a", "https://b"}, nil},
+		{[]string{"https://a", "https://b"}, cert, []string{"https://a", "https://b"}, nil},
+		{[]string{"https://a", "http://b"}, nil, nil, &IllegalStateError{}},
+		{[]string{"http://a", "https://b"}, nil, nil, &IllegalStateError{}},
+		{[]string{"http://a"}, cert, nil, &IllegalStateError{}},
+		{[]string{"localhost:8080"}, nil, nil, &IllegalArgumentError{}},
+		{[]string{"ftp://a"}, nil, nil, &IllegalArgumentError{}},
+		{[]string{"http://"}, nil, nil, &IllegalArgumentError{}},
+		{[]string{"http://a?x=1"}, nil, nil, &IllegalArgumentError{}},
+		{[]string{"http://user:pwd@a"}, nil, nil, &IllegalArgumentError{}},
+		{[]string{"http://a b"}, nil, nil, &IllegalArgumentError{}},
+		{[]string{""}, nil, nil, &IllegalArgumentError{}},
+	}
+	for _, test := range tests {
+		urls, err := requestExecutorValidateUrls(test.urls, test.certificate)
+		if test.expErr != nil {
+			assert.IsType(t, test.expErr, err, "urls: %v", test.urls)
+			continue
+		}
+		assert.NoError(t, err, "urls: %v", test.urls)
+		assert.Equal(t, test.exp, urls)
+	}
+}
+
+func TestDocumentStoreInitializeValidatesUrls(t *testing.T) {
+	store := NewDocumentStore(nil, "db")
+	err := store.Initialize()
+	assert.IsType(t, &IllegalArgumentError{}, err)
+
+	store = NewDocumentStore([]string{"http://A:8080/", "http://b:8080", "http://a:8080"}, "db")
+	require.NoError(t, store.Initialize())
+	defer store.Close()
+	assert.Equal(t, []string{"http://a:8080", "http://b:8080"}, store.GetUrls())
+
+	store = NewDocumentStore([]string{"http://a:8080"}, "db")
+	store.Certificate = &tls.Certificate{}
+	err = store.Initialize()
+	assert.IsType(t, &IllegalStateError{}, err)
+	assert.False(t, store.isInitialized())
+}